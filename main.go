@@ -1,12 +1,36 @@
 package main
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"context"
+	"flag"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
 	"github.com/terraform-providers/terraform-provider-selectel/selectel"
 )
 
+// version is set by goreleaser through ldflags.
+var version = "dev"
+
 func main() {
-	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: selectel.Provider,
-	})
+	var debug bool
+	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
+	flag.Parse()
+
+	// The plugin framework provider and the SDK provider are served together
+	// while resources are being migrated to the framework.
+	serverFactory, err := selectel.ProviderServerFactory(context.Background(), version)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var serveOpts []tf5server.ServeOpt
+	if debug {
+		serveOpts = append(serveOpts, tf5server.WithManagedDebug())
+	}
+
+	err = tf5server.Serve("registry.terraform.io/selectel/selectel", serverFactory, serveOpts...)
+	if err != nil {
+		log.Fatal(err)
+	}
 }
@@ -0,0 +1,431 @@
+package selectel
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/hashicorp/go-cty/cty"
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/selectel/dbaas-go"
+	domainsV2 "github.com/selectel/domains-go/pkg/v2"
+	"github.com/selectel/iam-go/iamerrors"
+	"github.com/selectel/secretsmanager-go/secretsmanagererrors"
+)
+
+const (
+	hintQuotaExceeded = "The quota of the project is exceeded. Free unused resources or increase " +
+		"the quotas of the project in the control panel or with the selectel_vpc_project_quotas_v2 resource."
+	hintNameTaken = "An object with the same name already exists. Choose another name " +
+		"or import the existing object with terraform import."
+	hintInvalidFlavor = "The flavor is not available for the region or the datastore type. " +
+		"Use the selectel_dbaas_flavor_v1 data source to find the available flavors."
+	hintUnauthorized = "Check the credentials of the provider and that the user has access to the project."
+)
+
+var (
+	// sdkStatusCodeErrorRe matches errors of mks-go and craas-go which contain the status code
+	// and the raw body of the response.
+	sdkStatusCodeErrorRe = regexp.MustCompile(`^[a-z-]+: got the (\d{3}) status code from the server(?:: (.*))?$`)
+
+	// apiErrorFieldRes match the field names in API messages such as
+	// "flavor_id: not found" or "invalid value of the field 'name'".
+	apiErrorFieldRes = []*regexp.Regexp{
+		regexp.MustCompile(`^([a-z][a-z0-9_]*(?:\.[a-z0-9_]+)*):\s`),
+		regexp.MustCompile(`(?i)\b(?:field|parameter|attribute)\s+['"\x60]?([a-z][a-z0-9_]*(?:\.[a-z0-9_]+)*)['"\x60]?`),
+	}
+)
+
+// apiErrorFields maps the fields named by the APIs in the errors of the objects to the attributes
+// of the resources. The messages are free-form, so the fields that are not in the map are not
+// trusted to be attributes, and the error is reported without the attribute path.
+var apiErrorFields = map[string]map[string]string{
+	objectDatastore: {
+		"name":                  "name",
+		"type_id":               "type_id",
+		"subnet_id":             "subnet_id",
+		"node_count":            "node_count",
+		"flavor_id":             "flavor_id",
+		"flavor":                "flavor",
+		"backup_retention_days": "backup_retention_days",
+		"floating_ips":          "floating_ips",
+		"config":                "config",
+		"redis_password":        "redis_password",
+		"pooler.mode":           "pooler.0.mode",
+		"pooler.size":           "pooler.0.size",
+	},
+	objectDatabase: {
+		"name":       "name",
+		"owner_id":   "owner_id",
+		"lc_collate": "lc_collate",
+		"lc_ctype":   "lc_ctype",
+	},
+	objectUser: {
+		"name":     "name",
+		"password": "password",
+		"email":    "email",
+	},
+	objectTopic: {
+		"name":       "name",
+		"partitions": "partitions",
+	},
+	objectCluster: {
+		"name":                              "name",
+		"kube_version":                      "kube_version",
+		"network_id":                        "network_id",
+		"subnet_id":                         "subnet_id",
+		"maintenance_window_start":          "maintenance_window_start",
+		"enable_autorepair":                 "enable_autorepair",
+		"enable_patch_version_auto_upgrade": "enable_patch_version_auto_upgrade",
+		"zonal":                             "zonal",
+		"private_kube_api":                  "private_kube_api",
+	},
+	objectNodegroup: {
+		"count":               "nodes_count",
+		"flavor_id":           "flavor_id",
+		"cpus":                "cpus",
+		"ram_mb":              "ram_mb",
+		"volume_gb":           "volume_gb",
+		"volume_type":         "volume_type",
+		"local_volume":        "local_volume",
+		"keypair_name":        "keypair_name",
+		"affinity_policy":     "affinity_policy",
+		"availability_zone":   "availability_zone",
+		"labels":              "labels",
+		"taints":              "taints",
+		"enable_autoscale":    "enable_autoscale",
+		"autoscale_min_nodes": "autoscale_min_nodes",
+		"autoscale_max_nodes": "autoscale_max_nodes",
+		"user_data":           "user_data",
+		"preemptible":         "preemptible",
+	},
+	objectZone: {
+		"name":    "name",
+		"comment": "comment",
+	},
+	objectRRSet: {
+		"name":    "name",
+		"type":    "type",
+		"ttl":     "ttl",
+		"records": "records",
+		"comment": "comment",
+	},
+	objectProject: {
+		"name":       "name",
+		"custom_url": "custom_url",
+	},
+	objectRegistry: {
+		"name": "name",
+	},
+	objectSecret: {
+		"description": "description",
+	},
+	objectServiceUser: {
+		"name":     "name",
+		"password": "password",
+	},
+}
+
+// apiError is an error response of a Selectel API extracted from an error of one of the SDKs.
+type apiError struct {
+	StatusCode int
+
+	// Code is the error code of the API, for example "OVER_QUOTAS" or "USER_ALREADY_EXISTS".
+	Code string
+
+	Message string
+
+	// Field is the field named by the API in the error, if any.
+	Field string
+}
+
+func (e *apiError) Error() string {
+	return e.Message
+}
+
+// parseAPIError extracts the API error from the errors of the Selectel SDKs.
+func parseAPIError(err error) (*apiError, bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	var result *apiError
+
+	var (
+		dbaasErr          *dbaas.DBaaSAPIError
+		iamErr            iamerrors.Error
+		secretsManagerErr secretsmanagererrors.Error
+		domainsErr        *domainsV2.BadResponseError
+		responseCodeErr   gophercloud.ErrUnexpectedResponseCode
+	)
+	switch {
+	case errors.As(err, &dbaasErr):
+		result = &apiError{
+			StatusCode: dbaasErr.APIError.Code,
+			Code:       dbaasErr.APIError.Title,
+			Message:    dbaasErr.APIError.Message,
+		}
+	case errors.As(err, &iamErr):
+		result = &apiError{Code: errorCode(iamErr.Err), Message: iamErr.Desc}
+	case errors.As(err, &secretsManagerErr):
+		result = &apiError{Code: errorCode(secretsManagerErr.Err), Message: secretsManagerErr.Desc}
+	case errors.As(err, &domainsErr):
+		result = &apiError{
+			StatusCode: domainsErr.Code,
+			Code:       domainsErr.ErrorMsg,
+			Message:    domainsErr.Description,
+			Field:      strings.TrimPrefix(domainsErr.Location, "body."),
+		}
+	case errors.As(err, &responseCodeErr):
+		result = parseAPIErrorBody(responseCodeErr.Actual, responseCodeErr.Body)
+	default:
+		match := sdkStatusCodeErrorRe.FindStringSubmatch(err.Error())
+		if match == nil {
+			return nil, false
+		}
+		statusCode, _ := strconv.Atoi(match[1])
+		result = parseAPIErrorBody(statusCode, []byte(match[2]))
+	}
+
+	if result.Message == "" {
+		result.Message = result.Code
+	}
+	if result.Message == "" {
+		result.Message = http.StatusText(result.StatusCode)
+	}
+	if result.Field == "" {
+		result.Field = apiErrorField(result.Message)
+	}
+
+	return result, true
+}
+
+func errorCode(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	return err.Error()
+}
+
+// parseAPIErrorBody parses the common formats of error bodies of Selectel APIs:
+// {"error": {"code": ..., "message": ...}}, {"error": "...", "description": "..."} and {"message": "..."}.
+func parseAPIErrorBody(statusCode int, body []byte) *apiError {
+	result := &apiError{StatusCode: statusCode}
+
+	var payload struct {
+		Error       json.RawMessage `json:"error"`
+		Code        json.RawMessage `json:"code"`
+		Message     string          `json:"message"`
+		Description string          `json:"description"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		result.Message = strings.TrimSpace(string(body))
+
+		return result
+	}
+
+	var nested struct {
+		Code    json.RawMessage `json:"code"`
+		Title   string          `json:"title"`
+		Message string          `json:"message"`
+	}
+	var errorString string
+	switch {
+	case json.Unmarshal(payload.Error, &errorString) == nil:
+		result.Code = errorString
+	case json.Unmarshal(payload.Error, &nested) == nil:
+		result.Code = rawCode(nested.Code)
+		if result.Code == "" {
+			result.Code = nested.Title
+		}
+		result.Message = nested.Message
+	}
+	if result.Code == "" {
+		result.Code = rawCode(payload.Code)
+	}
+	if result.Message == "" {
+		result.Message = payload.Message
+	}
+	if result.Message == "" {
+		result.Message = payload.Description
+	}
+
+	return result
+}
+
+// rawCode returns error codes which are sent both as strings and numbers.
+func rawCode(raw json.RawMessage) string {
+	var code string
+	if err := json.Unmarshal(raw, &code); err == nil {
+		return code
+	}
+	var number json.Number
+	if err := json.Unmarshal(raw, &number); err == nil {
+		return number.String()
+	}
+
+	return ""
+}
+
+func apiErrorField(message string) string {
+	for _, re := range apiErrorFieldRes {
+		if match := re.FindStringSubmatch(message); match != nil {
+			return strings.ToLower(match[1])
+		}
+	}
+
+	return ""
+}
+
+// attributePath converts the dotted attribute name such as "pooler.0.mode" to the attribute path.
+func attributePath(field string) cty.Path {
+	var path cty.Path
+	for _, step := range strings.Split(field, ".") {
+		if index, err := strconv.Atoi(step); err == nil {
+			path = path.IndexInt(index)
+		} else {
+			path = path.GetAttr(step)
+		}
+	}
+
+	return path
+}
+
+// hint returns the remediation for the common errors.
+func (e *apiError) hint() string {
+	code := strings.ToUpper(e.Code)
+	message := strings.ToLower(e.Message)
+
+	switch {
+	case strings.Contains(code, "QUOTA") || strings.Contains(message, "quota"):
+		return hintQuotaExceeded
+	case strings.Contains(code, "ALREADY_EXISTS") || strings.Contains(message, "already exists") ||
+		strings.Contains(message, "already taken") || strings.Contains(message, "already in use"):
+		return hintNameTaken
+	case strings.Contains(message, "flavor") || strings.HasPrefix(e.Field, "flavor"):
+		return hintInvalidFlavor
+	case e.StatusCode == http.StatusUnauthorized || strings.Contains(code, "UNAUTHORIZED"):
+		return hintUnauthorized
+	default:
+		return ""
+	}
+}
+
+// detail describes the response of the API and the remediation.
+func (e *apiError) detail() string {
+	var details []string
+	switch {
+	case e.StatusCode != 0 && e.Code != "" && e.Code != e.Message:
+		details = append(details, fmt.Sprintf("The API responded with the status code %d and the error code %s.", e.StatusCode, e.Code))
+	case e.StatusCode != 0:
+		details = append(details, fmt.Sprintf("The API responded with the status code %d.", e.StatusCode))
+	case e.Code != "" && e.Code != e.Message:
+		details = append(details, fmt.Sprintf("The API responded with the error code %s.", e.Code))
+	}
+	if hint := e.hint(); hint != "" {
+		details = append(details, hint)
+	}
+
+	return strings.Join(details, "\n\n")
+}
+
+// diagFromAPIError translates the error of an SDK to the diagnostic with a readable summary,
+// the attribute of the object named by the API and the remediation hint. The errors that are
+// not API errors are returned as is. The wrap function adds the context of the operation.
+func diagFromAPIError(object string, err error, wrap func(error) error) diag.Diagnostics {
+	apiErr, ok := parseAPIError(err)
+	if !ok {
+		return diag.FromErr(wrap(err))
+	}
+
+	d := diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  wrap(apiErr).Error(),
+		Detail:   apiErr.detail(),
+	}
+	if attr, ok := apiErrorFields[object][apiErr.Field]; ok {
+		d.AttributePath = attributePath(attr)
+	}
+
+	return diag.Diagnostics{d}
+}
+
+func diagCreatingObject(object string, err error) diag.Diagnostics {
+	return diagFromAPIError(object, err, func(err error) error {
+		return errCreatingObject(object, err)
+	})
+}
+
+func diagUpdatingObject(object, id string, err error) diag.Diagnostics {
+	return diagFromAPIError(object, err, func(err error) error {
+		return errUpdatingObject(object, id, err)
+	})
+}
+
+func diagGettingObject(object, id string, err error) diag.Diagnostics {
+	return diagFromAPIError(object, err, func(err error) error {
+		return errGettingObject(object, id, err)
+	})
+}
+
+func diagDeletingObject(object, id string, err error) diag.Diagnostics {
+	return diagFromAPIError(object, err, func(err error) error {
+		return errDeletingObject(object, id, err)
+	})
+}
+
+// frameworkDiagnostics converts the diagnostics of the SDK to the diagnostics of the plugin
+// framework, so resources of the framework provider report API errors in the same way.
+func frameworkDiagnostics(diags diag.Diagnostics) fwdiag.Diagnostics {
+	var result fwdiag.Diagnostics
+	for _, d := range diags {
+		attrPath, ok := frameworkPath(d.AttributePath)
+		switch {
+		case d.Severity == diag.Warning && ok:
+			result.AddAttributeWarning(attrPath, d.Summary, d.Detail)
+		case d.Severity == diag.Warning:
+			result.AddWarning(d.Summary, d.Detail)
+		case ok:
+			result.AddAttributeError(attrPath, d.Summary, d.Detail)
+		default:
+			result.AddError(d.Summary, d.Detail)
+		}
+	}
+
+	return result
+}
+
+// frameworkPath converts the attribute path of the SDK to the path of the plugin framework.
+func frameworkPath(attrPath cty.Path) (path.Path, bool) {
+	if len(attrPath) == 0 {
+		return path.Empty(), false
+	}
+
+	var result path.Path
+	for i, step := range attrPath {
+		switch step := step.(type) {
+		case cty.GetAttrStep:
+			if i == 0 {
+				result = path.Root(step.Name)
+			} else {
+				result = result.AtName(step.Name)
+			}
+		case cty.IndexStep:
+			if i == 0 || step.Key.Type() != cty.Number {
+				return path.Empty(), false
+			}
+			index, _ := step.Key.AsBigFloat().Int64()
+			result = result.AtListIndex(int(index))
+		}
+	}
+
+	return result, true
+}
@@ -0,0 +1,227 @@
+package selectel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/selectel/dbaas-go"
+	domainsV2 "github.com/selectel/domains-go/pkg/v2"
+	"github.com/selectel/iam-go/iamerrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAPIErrorDBaaS(t *testing.T) {
+	dbaasErr := &dbaas.DBaaSAPIError{}
+	dbaasErr.APIError.Code = 400
+	dbaasErr.APIError.Title = "Bad Request"
+	dbaasErr.APIError.Message = "flavor_id: flavor 'abc' not found"
+
+	actual, ok := parseAPIError(fmt.Errorf("creating datastore: %w", dbaasErr))
+	require.True(t, ok)
+	assert.Equal(t, &apiError{
+		StatusCode: 400,
+		Code:       "Bad Request",
+		Message:    "flavor_id: flavor 'abc' not found",
+		Field:      "flavor_id",
+	}, actual)
+}
+
+func TestParseAPIErrorIAM(t *testing.T) {
+	actual, ok := parseAPIError(iamerrors.Error{Err: iamerrors.ErrUserAlreadyExists, Desc: "user with this email already exists"})
+	require.True(t, ok)
+	assert.Equal(t, "USER_ALREADY_EXISTS", actual.Code)
+	assert.Equal(t, "user with this email already exists", actual.Message)
+	assert.Equal(t, hintNameTaken, actual.hint())
+}
+
+func TestParseAPIErrorDomains(t *testing.T) {
+	actual, ok := parseAPIError(&domainsV2.BadResponseError{
+		ErrorMsg:    "bad_request",
+		Description: "invalid ttl",
+		Location:    "body.ttl",
+		Code:        400,
+	})
+	require.True(t, ok)
+	assert.Equal(t, &apiError{StatusCode: 400, Code: "bad_request", Message: "invalid ttl", Field: "ttl"}, actual)
+}
+
+func TestParseAPIErrorMKS(t *testing.T) {
+	actual, ok := parseAPIError(errors.New(`mks-go: got the 409 status code from the server: ` +
+		`{"error":{"code":"OVER_QUOTAS","message":"not enough quota for compute_cores in ru-1a"}}`))
+	require.True(t, ok)
+	assert.Equal(t, &apiError{StatusCode: 409, Code: "OVER_QUOTAS", Message: "not enough quota for compute_cores in ru-1a"}, actual)
+	assert.Equal(t, hintQuotaExceeded, actual.hint())
+
+	actual, ok = parseAPIError(errors.New("craas-go: got the 502 status code from the server"))
+	require.True(t, ok)
+	assert.Equal(t, &apiError{StatusCode: 502, Message: "Bad Gateway"}, actual)
+}
+
+func TestParseAPIErrorResell(t *testing.T) {
+	err := gophercloud.ErrDefault409{ErrUnexpectedResponseCode: gophercloud.ErrUnexpectedResponseCode{
+		Actual: 409,
+		Body:   []byte(`{"error": "project_name_already_exists", "message": "project with this name already exists"}`),
+	}}
+
+	actual, ok := parseAPIError(err)
+	require.True(t, ok)
+	assert.Equal(t, &apiError{
+		StatusCode: 409,
+		Code:       "project_name_already_exists",
+		Message:    "project with this name already exists",
+	}, actual)
+}
+
+func TestParseAPIErrorNotAPIError(t *testing.T) {
+	_, ok := parseAPIError(errors.New("dial tcp: connection refused"))
+	assert.False(t, ok)
+}
+
+func TestAttributePath(t *testing.T) {
+	assert.Equal(t, cty.GetAttrPath("nodegroups").IndexInt(0).GetAttr("flavor_id"), attributePath("nodegroups.0.flavor_id"))
+	assert.Equal(t, "name", apiErrorField(`invalid value of the field "name"`))
+	assert.Empty(t, apiErrorField("internal server error"))
+}
+
+func TestDiagCreatingObject(t *testing.T) {
+	dbaasErr := &dbaas.DBaaSAPIError{}
+	dbaasErr.APIError.Code = 400
+	dbaasErr.APIError.Title = "Bad Request"
+	dbaasErr.APIError.Message = "flavor_id: flavor 'abc' not found"
+
+	expected := diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  "error creating datastore: flavor_id: flavor 'abc' not found",
+		Detail: "The API responded with the status code 400 and the error code Bad Request.\n\n" +
+			hintInvalidFlavor,
+		AttributePath: cty.GetAttrPath("flavor_id"),
+	}}
+
+	assert.Equal(t, expected, diagCreatingObject(objectDatastore, dbaasErr))
+}
+
+func TestDiagCreatingObjectField(t *testing.T) {
+	testCases := []struct {
+		name         string
+		object       string
+		message      string
+		expectedPath cty.Path
+	}{
+		{
+			name:         "field with another attribute name",
+			object:       objectNodegroup,
+			message:      "count: must be greater than 0",
+			expectedPath: cty.GetAttrPath("nodes_count"),
+		},
+		{
+			name:         "nested field",
+			object:       objectDatastore,
+			message:      "pooler.mode: unknown mode",
+			expectedPath: cty.GetAttrPath("pooler").IndexInt(0).GetAttr("mode"),
+		},
+		{
+			name:    "unknown field",
+			object:  objectDatastore,
+			message: "internal: database is not ready",
+		},
+		{
+			name:    "field of another object",
+			object:  objectDatabase,
+			message: "flavor_id: flavor 'abc' not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dbaasErr := &dbaas.DBaaSAPIError{}
+			dbaasErr.APIError.Code = 400
+			dbaasErr.APIError.Message = tc.message
+
+			diags := diagCreatingObject(tc.object, dbaasErr)
+			require.Len(t, diags, 1)
+			assert.Equal(t, tc.expectedPath, diags[0].AttributePath)
+		})
+	}
+}
+
+// TestAPIErrorFieldsAttributes checks that the fields of the API errors are mapped
+// to the attributes that exist in the resources of the objects.
+func TestAPIErrorFieldsAttributes(t *testing.T) {
+	objectResources := map[string][]string{
+		objectDatastore: {
+			"selectel_dbaas_datastore_v1",
+			"selectel_dbaas_postgresql_datastore_v1",
+			"selectel_dbaas_mysql_datastore_v1",
+			"selectel_dbaas_redis_datastore_v1",
+			"selectel_dbaas_kafka_datastore_v1",
+		},
+		objectDatabase:    {"selectel_dbaas_database_v1", "selectel_dbaas_postgresql_database_v1", "selectel_dbaas_mysql_database_v1"},
+		objectUser:        {"selectel_dbaas_user_v1", "selectel_iam_user_v1"},
+		objectTopic:       {"selectel_dbaas_kafka_topic_v1"},
+		objectCluster:     {"selectel_mks_cluster_v1"},
+		objectNodegroup:   {"selectel_mks_nodegroup_v1"},
+		objectZone:        {"selectel_domains_zone_v2"},
+		objectRRSet:       {"selectel_domains_rrset_v2"},
+		objectProject:     {"selectel_vpc_project_v2"},
+		objectRegistry:    {"selectel_craas_registry_v1"},
+		objectSecret:      {"selectel_secretsmanager_secret_v1"},
+		objectServiceUser: {"selectel_iam_serviceuser_v1"},
+	}
+
+	factory, err := ProviderServerFactory(context.Background(), "dev")
+	require.NoError(t, err)
+	schemaResp, err := factory().GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	require.NoError(t, err)
+
+	for object, fields := range apiErrorFields {
+		require.Contains(t, objectResources, object)
+		for field, attr := range fields {
+			assert.Condition(t, func() bool {
+				for _, resourceType := range objectResources[object] {
+					if testSchemaBlockHasAttribute(schemaResp.ResourceSchemas[resourceType].Block, attr) {
+						return true
+					}
+				}
+
+				return false
+			}, "the field %s of %s is mapped to the unknown attribute %s", field, object, attr)
+		}
+	}
+}
+
+// testSchemaBlockHasAttribute reports whether the dotted attribute such as "pooler.0.mode" is in the block.
+func testSchemaBlockHasAttribute(block *tfprotov5.SchemaBlock, attr string) bool {
+	name, rest, nested := strings.Cut(attr, ".")
+	for _, a := range block.Attributes {
+		if a.Name == name {
+			return !nested
+		}
+	}
+	for _, b := range block.BlockTypes {
+		if b.TypeName == name {
+			if !nested {
+				return true
+			}
+			// Skip the index of the element.
+			_, rest, _ = strings.Cut(rest, ".")
+
+			return testSchemaBlockHasAttribute(b.Block, rest)
+		}
+	}
+
+	return false
+}
+
+func TestDiagDeletingObjectNotAPIError(t *testing.T) {
+	err := errors.New(testErrString)
+
+	assert.Equal(t, diag.FromErr(errDeletingObject(objectDatastore, "123", err)), diagDeletingObject(objectDatastore, "123", err))
+}
@@ -0,0 +1,336 @@
+package selectel
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/rand" // nosemgrep: go.lang.security.audit.crypto.math_random.math-random-used
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/recorder"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/waiter"
+)
+
+const (
+	// recorderModeEnvVar sets the mode of recording acceptance tests: record or replay.
+	recorderModeEnvVar = "SEL_ACC_RECORDER"
+
+	cassettesDir = "testdata/cassettes"
+)
+
+// recordedEnvVars are environment variables stored in cassettes, so the provider
+// is configured on replay the same way as on recording. Credentials are never stored.
+var recordedEnvVars = []string{
+	"OS_AUTH_URL",
+	"OS_REGION_NAME",
+	"OS_DOMAIN_NAME",
+	"OS_USER_DOMAIN_NAME",
+	"INFRA_PROJECT_ID",
+	"INFRA_REGION",
+}
+
+// testAccRecording provides random names and the time of an acceptance test which
+// are the same on recording and on replay.
+type testAccRecording struct {
+	rand *rand.Rand
+	now  time.Time
+}
+
+// RandomWithPrefix works like acctest.RandomWithPrefix.
+func (r *testAccRecording) RandomWithPrefix(name string) string {
+	return fmt.Sprintf("%s-%d", name, r.rand.Int())
+}
+
+// Now returns the time the test was started at.
+func (r *testAccRecording) Now() time.Time {
+	return r.now
+}
+
+// testAccStartRecording records or replays HTTP interactions of the test with Selectel APIs
+// depending on the SEL_ACC_RECORDER environment variable. Cassettes are stored
+// in testdata/cassettes and named after the test. It must be called at the start of the test,
+// before names and times are generated, and tests using it must not run in parallel.
+//
+// On recording, the cassette is written only if the test passes. On replay, requests
+// are not sent, the provider is configured with the recorded environment and dummy
+// credentials, and waiters don't sleep between polls.
+func testAccStartRecording(t *testing.T) *testAccRecording {
+	t.Helper()
+
+	mode := recorder.Mode(os.Getenv(recorderModeEnvVar))
+	if mode == "" {
+		now := time.Now()
+
+		// #nosec G404
+		return &testAccRecording{rand: rand.New(rand.NewSource(now.UnixNano())), now: now}
+	}
+
+	path := filepath.Join(cassettesDir, t.Name()+".json")
+	rec, err := recorder.New(path, mode, http.DefaultTransport, scrubInteraction)
+	if err != nil {
+		t.Fatalf("can't start recording of %s: %s", t.Name(), err)
+	}
+	cassette := rec.Cassette()
+
+	switch mode {
+	case recorder.ModeRecord:
+		for _, name := range recordedEnvVars {
+			if v := os.Getenv(name); v != "" {
+				cassette.Env[name] = v
+			}
+		}
+		t.Cleanup(func() {
+			if t.Failed() {
+				return
+			}
+			if err := rec.Save(); err != nil {
+				t.Errorf("can't save cassette %s: %s", path, err)
+			}
+		})
+	case recorder.ModeReplay:
+		for _, name := range recordedEnvVars {
+			t.Setenv(name, cassette.Env[name])
+		}
+		for _, name := range []string{"OS_TOKEN", "OS_AUTH_TOKEN", "OS_APPLICATION_CREDENTIAL_ID", "OS_APPLICATION_CREDENTIAL_SECRET"} {
+			t.Setenv(name, "")
+		}
+		t.Setenv("OS_USERNAME", redactedValue)
+		t.Setenv("OS_PASSWORD", redactedValue)
+
+		defaultClock := waiter.DefaultClock
+		waiter.DefaultClock = waiter.NewInstantClock(cassette.RecordedAt)
+		t.Cleanup(func() { waiter.DefaultClock = defaultClock })
+	}
+
+	// Clients of the provider use newBaseTransport, clients of test checks use http.DefaultTransport.
+	defaultBaseTransport, defaultTransport := newBaseTransport, http.DefaultTransport
+	newBaseTransport = func() http.RoundTripper { return rec }
+	http.DefaultTransport = rec
+	t.Cleanup(func() {
+		newBaseTransport, http.DefaultTransport = defaultBaseTransport, defaultTransport
+	})
+
+	// #nosec G404
+	return &testAccRecording{rand: rand.New(rand.NewSource(cassette.Seed)), now: cassette.RecordedAt}
+}
+
+// testStartReplay works like testAccStartRecording, but replays the cassette when
+// SEL_ACC_RECORDER is not set, so the test runs without credentials by default.
+func testStartReplay(t *testing.T) *testAccRecording {
+	t.Helper()
+
+	if os.Getenv(recorderModeEnvVar) == "" {
+		t.Setenv(recorderModeEnvVar, string(recorder.ModeReplay))
+	}
+
+	return testAccStartRecording(t)
+}
+
+// scrubInteraction removes auth headers, tokens and secrets from the interaction. The identity
+// is removed from Keystone token requests, so they match on replay with dummy credentials
+// whatever auth method was recorded. Secrets in bodies are replaced with placeholders of
+// the same format, and the rest of bodies is kept as is, so clients parse replayed
+// responses the same way as recorded ones.
+func scrubInteraction(interaction *recorder.Interaction) {
+	if interaction.Request.Method == http.MethodPost && strings.HasSuffix(interaction.Request.URL, "/auth/tokens") {
+		var body map[string]map[string]interface{}
+		if err := json.Unmarshal([]byte(interaction.Request.Body), &body); err == nil && body["auth"] != nil {
+			delete(body["auth"], "identity")
+			if scrubbed, err := json.Marshal(body); err == nil {
+				interaction.Request.Body = string(scrubbed)
+			}
+		}
+	}
+
+	for _, header := range []http.Header{interaction.Request.Header, interaction.Response.Header} {
+		for name := range header {
+			if _, ok := redactedHeaders[http.CanonicalHeaderKey(name)]; ok {
+				header.Set(name, redactedValue)
+			}
+		}
+	}
+
+	var serviceFields map[string]struct{}
+	if strings.Contains(interaction.Request.URL, "/"+SecretsManager+"/") {
+		serviceFields = serviceRedactedFields[SecretsManager]
+	}
+	interaction.Request.Body = scrubBody(serviceFields, interaction.Request.Body)
+	interaction.Response.Body = scrubBody(serviceFields, interaction.Response.Body)
+}
+
+// scrubBody replaces secrets in the JSON or YAML body. The body is re-encoded only
+// if it contains secrets.
+func scrubBody(serviceFields map[string]struct{}, body string) string {
+	if strings.TrimSpace(body) == "" {
+		return body
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(body))
+	decoder.UseNumber()
+
+	var data interface{}
+	if err := decoder.Decode(&data); err != nil || decoder.More() {
+		return scrubYAML(body)
+	}
+	if !scrubJSON(serviceFields, "", data) {
+		return body
+	}
+	scrubbed, err := json.Marshal(data)
+	if err != nil {
+		return body
+	}
+
+	return string(scrubbed)
+}
+
+// scrubJSON replaces string values of the fields redacted in logs in place and reports
+// whether anything was replaced. Kubeconfigs are scrubbed line by line.
+func scrubJSON(serviceFields map[string]struct{}, parent string, data interface{}) bool {
+	scrubbed := false
+	switch v := data.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			lowerKey := strings.ToLower(key)
+			_, sensitive := redactedFields[lowerKey]
+			if _, ok := serviceFields[lowerKey]; ok {
+				sensitive = true
+			}
+			if _, ok := parentRedactedFields[parent][lowerKey]; ok {
+				sensitive = true
+			}
+			if s, ok := value.(string); sensitive && ok && s != "" {
+				if lowerKey == "kubeconfig" {
+					v[key] = scrubYAML(s)
+				} else {
+					v[key] = scrubbedValue(s)
+				}
+				scrubbed = true
+
+				continue
+			}
+			if scrubJSON(serviceFields, lowerKey, value) {
+				scrubbed = true
+			}
+		}
+	case []interface{}:
+		for _, value := range v {
+			if scrubJSON(serviceFields, parent, value) {
+				scrubbed = true
+			}
+		}
+	}
+
+	return scrubbed
+}
+
+// scrubYAML replaces values of the fields redacted in logs in the YAML document,
+// such as credentials of a kubeconfig.
+func scrubYAML(body string) string {
+	return yamlFieldRe.ReplaceAllStringFunc(body, func(line string) string {
+		prefix := yamlFieldRe.FindStringSubmatch(line)[1]
+
+		return prefix + scrubbedValue(strings.TrimSpace(line[len(prefix):]))
+	})
+}
+
+// scrubbedValue returns the placeholder of the same format as the secret: PEM blocks
+// and base64 strings are replaced with the encoded placeholder, so they are still decoded.
+func scrubbedValue(value string) string {
+	if block, _ := pem.Decode([]byte(value)); block != nil {
+		return string(pem.EncodeToMemory(&pem.Block{Type: block.Type, Bytes: []byte(redactedValue)}))
+	}
+	if _, err := base64.StdEncoding.DecodeString(value); err == nil && value != redactedValue {
+		return base64.StdEncoding.EncodeToString([]byte(redactedValue))
+	}
+
+	return redactedValue
+}
+
+func TestScrubInteraction(t *testing.T) {
+	interaction := &recorder.Interaction{
+		Request: recorder.Request{
+			Method: http.MethodPost,
+			URL:    "https://cloud.api.selcloud.ru/identity/v3/auth/tokens",
+			Header: http.Header{"X-Auth-Token": {"gAAAAAB"}, "Content-Type": {"application/json"}},
+			Body:   `{"auth":{"identity":{"password":{"user":{"name":"user","password":"secret"}}},"scope":{"project":{"id":"project"}}}}`,
+		},
+		Response: recorder.Response{
+			StatusCode: http.StatusCreated,
+			Header:     http.Header{"X-Subject-Token": {"gAAAAAC"}},
+			Body:       `{"token":{"expires_at":"2024-01-01T00:00:00Z"}}`,
+		},
+	}
+
+	scrubInteraction(interaction)
+
+	assert.Equal(t, redactedValue, interaction.Request.Header.Get("X-Auth-Token"))
+	assert.Equal(t, "application/json", interaction.Request.Header.Get("Content-Type"))
+	assert.Equal(t, redactedValue, interaction.Response.Header.Get("X-Subject-Token"))
+	assert.NotContains(t, interaction.Request.Body, "secret")
+	assert.NotContains(t, interaction.Request.Body, "identity")
+	assert.Contains(t, interaction.Request.Body, `"id":"project"`)
+	assert.Equal(t, `{"token":{"expires_at":"2024-01-01T00:00:00Z"}}`, interaction.Response.Body)
+}
+
+func TestScrubBody(t *testing.T) {
+	placeholder := base64.StdEncoding.EncodeToString([]byte(redactedValue))
+
+	// Bodies without secrets are kept as is.
+	quotas := `{"quotas": {"compute_cores": [{"zone": "ru-1a", "value": 16, "used": 4}]}}`
+	assert.Equal(t, quotas, scrubBody(nil, quotas))
+
+	token := `{"prometheus-metrics-token":{"id":"a2b7c1f0","value":"metrics-token-value"}}`
+	assert.Equal(t, `{"prometheus-metrics-token":{"id":"a2b7c1f0","value":"REDACTED"}}`, scrubBody(nil, token))
+
+	secret := `{"version":{"value":"c2VjcmV0LXZhbHVl"}}`
+	assert.Equal(t, secret, scrubBody(nil, secret))
+	assert.Equal(t, `{"version":{"value":"`+placeholder+`"}}`, scrubBody(serviceRedactedFields[SecretsManager], secret))
+
+	redis := `{"datastore":{"name":"redis","redis_password":"hunter2"}}`
+	assert.Equal(t, `{"datastore":{"name":"redis","redis_password":"REDACTED"}}`, scrubBody(nil, redis))
+
+	redisPassword := `{"password":{"redis_password":"hunter2"}}`
+	assert.Equal(t, `{"password":{"redis_password":"REDACTED"}}`, scrubBody(nil, redisPassword))
+
+	kubeconfig := "users:\n- name: admin\n  user:\n    client-key-data: ZmFrZS1rZXk=\n    username: admin\n"
+	assert.Equal(t, "users:\n- name: admin\n  user:\n    client-key-data: "+placeholder+"\n    username: admin\n",
+		scrubBody(nil, kubeconfig))
+
+	privateKey := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("key")}))
+	scrubbed := scrubBody(nil, `{"private_key":`+strconv.Quote(privateKey)+`}`)
+	var body map[string]string
+	require.NoError(t, json.Unmarshal([]byte(scrubbed), &body))
+	block, _ := pem.Decode([]byte(body["private_key"]))
+	require.NotNil(t, block)
+	assert.Equal(t, []byte(redactedValue), block.Bytes)
+}
+
+func TestStartRecordingReplay(t *testing.T) {
+	path := filepath.Join(cassettesDir, t.Name()+".json")
+	require.FileExists(t, path)
+	t.Setenv(recorderModeEnvVar, string(recorder.ModeReplay))
+
+	recording := testAccStartRecording(t)
+
+	assert.Equal(t, time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC), recording.Now())
+	assert.Equal(t, "tf-acc-5577006791947779410", recording.RandomWithPrefix("tf-acc"))
+	assert.Equal(t, "ru-9", os.Getenv("OS_REGION_NAME"))
+	assert.Equal(t, redactedValue, os.Getenv("OS_PASSWORD"))
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://cloud.api.selcloud.ru/identity/v3/", nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
@@ -2,56 +2,230 @@ package selectel
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"net/http"
+	"strings"
 	"sync"
+	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/tokens"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/selectel/go-selvpcclient/v4/selvpcclient"
+	"github.com/selectel/go-selvpcclient/v4/selvpcclient/clients"
+	clientservices "github.com/selectel/go-selvpcclient/v4/selvpcclient/clients/services"
 )
 
-var (
-	cfgSingletone *Config
-	once          sync.Once
+// tokenRefreshMargin is how long before the expiration a token is considered stale
+// and gets re-issued, so that long operations don't start with an almost expired token.
+const tokenRefreshMargin = 5 * time.Minute
+
+var errNoAuthMethod = errors.New(
+	"one of auth_token, application_credential_id/application_credential_secret or username/password must be set",
 )
 
 // Config contains all available configuration options.
+// Every configured provider instance (including aliases) gets its own Config,
+// so credentials, cached clients and tokens are never shared between them.
 type Config struct {
 	Region    string
 	ProjectID string
 
-	Context        context.Context
-	AuthURL        string
-	AuthRegion     string
-	Username       string
-	Password       string
-	UserDomainName string
-	DomainName     string
-	clientsCache   map[string]*selvpcclient.Client
-	lock           sync.Mutex
-}
-
-func getConfig(d *schema.ResourceData) (*Config, diag.Diagnostics) {
-	once.Do(func() {
-		cfgSingletone = &Config{
-			Username:   d.Get("username").(string),
-			Password:   d.Get("password").(string),
-			DomainName: d.Get("domain_name").(string),
-			AuthURL:    d.Get("auth_url").(string),
-			AuthRegion: d.Get("auth_region").(string),
-		}
-		if v, ok := d.GetOk("user_domain_name"); ok {
-			cfgSingletone.UserDomainName = v.(string)
+	Context                     context.Context
+	AuthURL                     string
+	AuthRegion                  string
+	Username                    string
+	Password                    string
+	UserDomainName              string
+	DomainName                  string
+	AuthToken                   string
+	ApplicationCredentialID     string
+	ApplicationCredentialSecret string
+	Endpoints                   map[string]string
+	MaxRetries                  int
+	MaxBackoff                  time.Duration
+	UserAgent                   string
+	clientsCache                map[string]*scopedClient
+	limiters                    map[string]*serviceLimiter
+	namingPolicy                *namingPolicy
+	allowQuotasBelowUsage       bool
+	lock                        sync.Mutex
+	transport                   http.RoundTripper
+	transportOnce               sync.Once
+}
+
+// scopedClient holds a selvpc client together with the Keystone service client
+// that owns the X-Auth-Token issued for the same scope.
+type scopedClient struct {
+	client        *selvpcclient.Client
+	serviceClient *gophercloud.ServiceClient
+}
+
+// providerOptions are the settings of the provider block with the environment defaults applied.
+// Both the SDK and the plugin framework providers read their blocks into providerOptions,
+// so they build the same Config.
+type providerOptions struct {
+	ProjectID                   string
+	Region                      string
+	AuthURL                     string
+	AuthRegion                  string
+	DomainName                  string
+	Username                    string
+	UserDomainName              string
+	Password                    string
+	AuthToken                   string
+	ApplicationCredentialID     string
+	ApplicationCredentialSecret string
+	UserAgentSuffix             string
+	AllowQuotasBelowUsage       bool
+	// Endpoints are the custom endpoints keyed by attributes of the endpoints block.
+	Endpoints    map[string]string
+	RateLimits   []rateLimitOptions
+	NamingPolicy *namingPolicyOptions
+	MaxRetries   int
+	MaxBackoff   int
+}
+
+func expandProviderOptions(d *schema.ResourceData) providerOptions {
+	return providerOptions{
+		ProjectID:                   d.Get("project_id").(string),
+		Region:                      d.Get("region").(string),
+		AuthURL:                     d.Get("auth_url").(string),
+		AuthRegion:                  d.Get("auth_region").(string),
+		DomainName:                  d.Get("domain_name").(string),
+		Username:                    d.Get("username").(string),
+		UserDomainName:              d.Get("user_domain_name").(string),
+		Password:                    d.Get("password").(string),
+		AuthToken:                   d.Get("auth_token").(string),
+		ApplicationCredentialID:     d.Get("application_credential_id").(string),
+		ApplicationCredentialSecret: d.Get("application_credential_secret").(string),
+		UserAgentSuffix:             d.Get("user_agent_suffix").(string),
+		AllowQuotasBelowUsage:       d.Get("allow_quotas_below_usage").(bool),
+		Endpoints:                   flattenEndpointsBlock(d),
+		RateLimits:                  flattenRateLimitBlocks(d),
+		NamingPolicy:                flattenNamingPolicyBlock(d),
+		MaxRetries:                  d.Get("max_retries").(int),
+		MaxBackoff:                  d.Get("max_backoff").(int),
+	}
+}
+
+// newConfig validates the options and builds the Config of a provider instance.
+func newConfig(opts providerOptions, userAgent string) (*Config, error) {
+	required := []struct{ attr, value string }{
+		{"auth_url", opts.AuthURL},
+		{"auth_region", opts.AuthRegion},
+		{"domain_name", opts.DomainName},
+	}
+	for _, r := range required {
+		if r.value == "" {
+			return nil, fmt.Errorf("%s must be set in the provider block or with the %s environment variable",
+				r.attr, providerEnvVars[r.attr][0])
 		}
-		if v, ok := d.GetOk("project_id"); ok {
-			cfgSingletone.ProjectID = v.(string)
+	}
+	if opts.MaxRetries < 0 {
+		return nil, errors.New("max_retries must be at least 0")
+	}
+	if opts.MaxBackoff < 1 {
+		return nil, errors.New("max_backoff must be at least 1")
+	}
+
+	config := &Config{
+		ProjectID:                   opts.ProjectID,
+		Region:                      opts.Region,
+		AuthURL:                     opts.AuthURL,
+		AuthRegion:                  opts.AuthRegion,
+		DomainName:                  opts.DomainName,
+		Username:                    opts.Username,
+		UserDomainName:              opts.UserDomainName,
+		Password:                    opts.Password,
+		AuthToken:                   opts.AuthToken,
+		ApplicationCredentialID:     opts.ApplicationCredentialID,
+		ApplicationCredentialSecret: opts.ApplicationCredentialSecret,
+		UserAgent:                   strings.TrimSpace(userAgent + " " + opts.UserAgentSuffix),
+		Endpoints:                   newEndpoints(opts.Endpoints),
+		MaxRetries:                  opts.MaxRetries,
+		MaxBackoff:                  time.Duration(opts.MaxBackoff) * time.Second,
+		allowQuotasBelowUsage:       opts.AllowQuotasBelowUsage,
+	}
+
+	limiters, err := newServiceLimiters(opts.RateLimits)
+	if err != nil {
+		return nil, err
+	}
+	config.limiters = limiters
+
+	namingPolicy, err := newNamingPolicy(opts.NamingPolicy)
+	if err != nil {
+		return nil, err
+	}
+	config.namingPolicy = namingPolicy
+
+	if err := config.validateAuthMethod(); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// validateAuthMethod checks that at least one complete set of credentials is provided.
+func (c *Config) validateAuthMethod() error {
+	switch {
+	case c.AuthToken != "":
+		return nil
+	case c.ApplicationCredentialID != "" || c.ApplicationCredentialSecret != "":
+		if c.ApplicationCredentialID == "" || c.ApplicationCredentialSecret == "" {
+			return errors.New("both application_credential_id and application_credential_secret must be set")
 		}
-		if v, ok := d.GetOk("region"); ok {
-			cfgSingletone.Region = v.(string)
+
+		return nil
+	case c.Username != "" && c.Password != "":
+		return nil
+	default:
+		return errNoAuthMethod
+	}
+}
+
+// authOptions builds Keystone auth options for the given project scope.
+// Auth methods are tried in the following order: a pre-issued token,
+// application credentials, service user username and password.
+func (c *Config) authOptions(projectID string) *gophercloud.AuthOptions {
+	opts := &gophercloud.AuthOptions{
+		AllowReauth:      true,
+		IdentityEndpoint: c.AuthURL,
+	}
+
+	switch {
+	case c.AuthToken != "":
+		opts.TokenID = c.AuthToken
+	case c.ApplicationCredentialID != "":
+		// Application credentials are always bound to the project they were created in,
+		// Keystone rejects any explicit scope for them.
+		opts.ApplicationCredentialID = c.ApplicationCredentialID
+		opts.ApplicationCredentialSecret = c.ApplicationCredentialSecret
+
+		return opts
+	default:
+		opts.Username = c.Username
+		opts.Password = c.Password
+		opts.DomainName = c.UserDomainName
+		// If UserDomainName is not set, the user is located in the same domain the token is issued in.
+		if opts.DomainName == "" {
+			opts.DomainName = c.DomainName
 		}
-	})
+	}
 
-	return cfgSingletone, nil
+	opts.Scope = &gophercloud.AuthScope{
+		ProjectID: projectID,
+	}
+	// If project scope is not set, we use domain scope.
+	if projectID == "" {
+		opts.Scope.DomainName = c.DomainName
+	}
+
+	return opts
 }
 
 func (c *Config) GetSelVPCClient() (*selvpcclient.Client, error) {
@@ -59,35 +233,225 @@ func (c *Config) GetSelVPCClient() (*selvpcclient.Client, error) {
 }
 
 func (c *Config) GetSelVPCClientWithProjectScope(projectID string) (*selvpcclient.Client, error) {
+	scoped, err := c.getScopedClient(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return scoped.client, nil
+}
+
+// GetXAuthToken returns the X-Auth-Token issued with the domain scope.
+func (c *Config) GetXAuthToken() (string, error) {
+	return c.GetXAuthTokenWithProjectScope("")
+}
+
+// GetXAuthTokenWithProjectScope returns the X-Auth-Token issued for the project scope.
+func (c *Config) GetXAuthTokenWithProjectScope(projectID string) (string, error) {
+	scoped, err := c.getScopedClient(projectID)
+	if err != nil {
+		return "", err
+	}
+
+	return scoped.serviceClient.Token(), nil
+}
+
+func (c *Config) getScopedClient(projectID string) (*scopedClient, error) {
+	scoped, err := c.getCachedScopedClient(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := scoped.refreshTokenIfExpiring(); err != nil {
+		return nil, fmt.Errorf("failed to refresh token, err: %w", err)
+	}
+
+	return scoped, nil
+}
+
+func (c *Config) getCachedScopedClient(projectID string) (*scopedClient, error) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
 	clientsCacheKey := fmt.Sprintf("client_%s", projectID)
 
-	if client, ok := c.clientsCache[clientsCacheKey]; ok {
-		return client, nil
+	if scoped, ok := c.clientsCache[clientsCacheKey]; ok {
+		return scoped, nil
 	}
 
-	opts := &selvpcclient.ClientOptions{
-		DomainName:     c.DomainName,
-		Username:       c.Username,
-		Password:       c.Password,
-		ProjectID:      projectID,
-		AuthURL:        c.AuthURL,
-		AuthRegion:     c.AuthRegion,
-		UserDomainName: c.UserDomainName,
+	scoped, err := c.newScopedClient(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.clientsCache == nil {
+		c.clientsCache = map[string]*scopedClient{}
 	}
 
-	client, err := selvpcclient.NewClient(opts)
+	c.clientsCache[clientsCacheKey] = scoped
+
+	return scoped, nil
+}
+
+// reauthenticateWithProjectScope re-issues the token of the project scope if it is still equal to staleToken
+// and returns the actual token. It is used when an API rejects the token with 401.
+func (c *Config) reauthenticateWithProjectScope(projectID, staleToken string) (string, error) {
+	scoped, err := c.getCachedScopedClient(projectID)
 	if err != nil {
+		return "", err
+	}
+
+	provider := scoped.serviceClient.ProviderClient
+	if err := provider.Reauthenticate(staleToken); err != nil {
+		return "", fmt.Errorf("failed to re-authenticate, err: %w", err)
+	}
+
+	return provider.Token(), nil
+}
+
+// newBaseTransport returns the transport that sends requests to Selectel APIs.
+// Acceptance tests replace it to record and replay the HTTP interactions.
+var newBaseTransport = func() http.RoundTripper {
+	return clientservices.NewHTTPClient().Transport
+}
+
+// httpTransport returns the transport shared by all HTTP clients of the provider instance.
+func (c *Config) httpTransport() http.RoundTripper {
+	c.transportOnce.Do(func() {
+		c.transport = newBaseTransport()
+	})
+
+	return c.transport
+}
+
+// serviceTransport returns the transport that sends a single attempt of the request to the API
+// of the service type: it respects rate limits, sets the User-Agent and logs the request.
+func (c *Config) serviceTransport(serviceType string) http.RoundTripper {
+	var transport http.RoundTripper = newLoggingTransport(serviceType, c.httpTransport())
+	if c.UserAgent != "" {
+		transport = &userAgentTransport{userAgent: c.UserAgent, next: transport}
+	}
+
+	return c.newRateLimitTransport(serviceType, transport)
+}
+
+// newHTTPClient returns an HTTP client for the Selectel service API that authenticates
+// every request with the actual token of the project scope, respects rate limits
+// of the service type, retries throttled and failed requests and logs every attempt.
+func (c *Config) newHTTPClient(serviceType, projectID string) *http.Client {
+	return &http.Client{
+		Transport: c.newRetryableTransport(&authTransport{
+			config:    c,
+			projectID: projectID,
+			next:      c.serviceTransport(serviceType),
+		}),
+	}
+}
+
+// newScopedClient authenticates in Keystone and builds a selvpc client on top of the issued token.
+// It mirrors selvpcclient.NewClient, which only supports the password auth method.
+func (c *Config) newScopedClient(projectID string) (*scopedClient, error) {
+	if err := c.validateAuthMethod(); err != nil {
 		return nil, err
 	}
+	if c.DomainName == "" || c.AuthURL == "" || c.AuthRegion == "" {
+		return nil, errors.New("domain_name, auth_url and auth_region must be set")
+	}
 
-	if c.clientsCache == nil {
-		c.clientsCache = map[string]*selvpcclient.Client{}
+	authProvider, err := openstack.NewClient(c.AuthURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create auth provider, err: %w", err)
+	}
+	// Keystone and Resell API requests go through the same retries as service APIs.
+	authProvider.HTTPClient = http.Client{
+		Transport: c.newRetryableTransport(c.serviceTransport(clients.ResellServiceType)),
 	}
 
-	c.clientsCache[clientsCacheKey] = client
+	authOptions := c.authOptions(projectID)
+	err = openstack.Authenticate(authProvider, *authOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create auth provider, err: %w", err)
+	}
+	// Application credentials can't be scoped, so the token of another project can be issued.
+	if authOptions.ApplicationCredentialID != "" && projectID != "" {
+		if tokenProjectID, ok := tokenProjectID(authProvider.GetAuthResult()); ok && tokenProjectID != projectID {
+			return nil, fmt.Errorf("application credential is bound to project %s, cannot act on project %s",
+				tokenProjectID, projectID)
+		}
+	}
+	authProvider.Context = c.Context
+
+	serviceClient, err := openstack.NewIdentityV3(authProvider, gophercloud.EndpointOpts{
+		Availability: gophercloud.AvailabilityPublic,
+		Region:       c.AuthRegion,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create service client, err: %w", err)
+	}
+	serviceClient.UserAgent.Prepend(selvpcclient.AppName)
+
+	catalogService, err := clientservices.NewCatalogService(serviceClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize endpoints catalog service, err: %w", err)
+	}
+
+	requestService := clientservices.NewRequestService(serviceClient)
+
+	return &scopedClient{
+		client: &selvpcclient.Client{
+			Resell:       clients.NewResellClient(requestService, catalogService, c.AuthRegion),
+			QuotaManager: clients.NewQuotaManagerClient(requestService, catalogService),
+			Catalog:      catalogService,
+		},
+		serviceClient: serviceClient,
+	}, nil
+}
+
+// refreshTokenIfExpiring re-issues the token if it expires within tokenRefreshMargin.
+func (s *scopedClient) refreshTokenIfExpiring() error {
+	provider := s.serviceClient.ProviderClient
+	token := provider.Token()
+
+	expiresAt, ok := tokenExpiresAt(provider.GetAuthResult())
+	if !ok || time.Until(expiresAt) > tokenRefreshMargin {
+		return nil
+	}
+
+	log.Printf("[DEBUG] Keystone token expires at %s, re-authenticating", expiresAt.Format(time.RFC3339))
+
+	return provider.Reauthenticate(token)
+}
+
+// tokenProjectID returns the ID of the project the token is issued for.
+func tokenProjectID(authResult gophercloud.AuthResult) (string, bool) {
+	result, ok := authResult.(interface {
+		ExtractProject() (*tokens.Project, error)
+	})
+	if !ok {
+		return "", false
+	}
+
+	project, err := result.ExtractProject()
+	if err != nil || project == nil || project.ID == "" {
+		return "", false
+	}
+
+	return project.ID, true
+}
+
+// tokenExpiresAt extracts the expiration time of the token from the Keystone response.
+func tokenExpiresAt(authResult gophercloud.AuthResult) (time.Time, bool) {
+	result, ok := authResult.(interface {
+		ExtractToken() (*tokens.Token, error)
+	})
+	if !ok {
+		return time.Time{}, false
+	}
+
+	token, err := result.ExtractToken()
+	if err != nil || token.ExpiresAt.IsZero() {
+		return time.Time{}, false
+	}
 
-	return client, nil
+	return token.ExpiresAt, true
 }
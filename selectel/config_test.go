@@ -0,0 +1,499 @@
+package selectel
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/selectel/go-selvpcclient/v4/selvpcclient/clients"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testKeystoneAuthRegion = "ru-1"
+	testKeystoneDomainName = "123456"
+
+	// testKeystoneApplicationCredentialProject is the project application credentials are bound to.
+	testKeystoneApplicationCredentialProject = "project-app-cred"
+)
+
+// testKeystone is a minimal Keystone v3 emulation that issues tokens for the password,
+// token and application credential methods and serves a service catalog with the identity endpoint
+// and the resell and managed-kubernetes endpoints of testKeystoneServiceRegions.
+type testKeystone struct {
+	*httptest.Server
+
+	// TTL is the lifetime of issued tokens.
+	TTL time.Duration
+
+	lock     sync.Mutex
+	issued   map[string]string
+	expires  map[string]time.Time
+	projects map[string]string
+	count    int
+}
+
+func newTestKeystone(t *testing.T) *testKeystone {
+	t.Helper()
+
+	keystone := &testKeystone{
+		TTL:      24 * time.Hour,
+		issued:   map[string]string{},
+		expires:  map[string]time.Time{},
+		projects: map[string]string{},
+	}
+	keystone.Server = httptest.NewServer(http.HandlerFunc(keystone.handle))
+	t.Cleanup(keystone.Close)
+
+	return keystone
+}
+
+// AuthURL returns the Keystone v3 URL that should be used in the provider block.
+func (k *testKeystone) AuthURL() string {
+	return k.URL + "/v3/"
+}
+
+// IssuedTo returns the name of the user that the token was issued to.
+func (k *testKeystone) IssuedTo(token string) string {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	return k.issued[token]
+}
+
+// Issue creates a new token for the user, as if it was issued out of band.
+func (k *testKeystone) Issue(user string) string {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	k.count++
+	token := fmt.Sprintf("token-%s-%d", user, k.count)
+	k.issued[token] = user
+	k.expires[token] = time.Now().Add(k.TTL)
+
+	return token
+}
+
+// Revoke invalidates the token before its expiration.
+func (k *testKeystone) Revoke(token string) {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	k.expires[token] = time.Now()
+}
+
+// Valid reports whether the token has been issued and has not expired yet.
+func (k *testKeystone) Valid(token string) bool {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	expiresAt, ok := k.expires[token]
+
+	return ok && time.Now().Before(expiresAt)
+}
+
+// Issued returns the number of tokens issued so far.
+func (k *testKeystone) Issued() int {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	return k.count
+}
+
+func (k *testKeystone) expiresAt(token string) time.Time {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	return k.expires[token]
+}
+
+func (k *testKeystone) handle(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/v3/auth/tokens" {
+		http.NotFound(w, r)
+
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPost:
+		var body struct {
+			Auth struct {
+				Identity struct {
+					Password struct {
+						User struct {
+							Name     string `json:"name"`
+							Password string `json:"password"`
+						} `json:"user"`
+					} `json:"password"`
+					Token struct {
+						ID string `json:"id"`
+					} `json:"token"`
+					ApplicationCredential struct {
+						ID     string `json:"id"`
+						Secret string `json:"secret"`
+					} `json:"application_credential"`
+				} `json:"identity"`
+				Scope struct {
+					Project struct {
+						ID string `json:"id"`
+					} `json:"project"`
+				} `json:"scope"`
+			} `json:"auth"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		identity := body.Auth.Identity
+		user, project := "", body.Auth.Scope.Project.ID
+		switch {
+		case identity.Token.ID != "":
+			if k.Valid(identity.Token.ID) {
+				user = k.IssuedTo(identity.Token.ID)
+			}
+		case identity.ApplicationCredential.ID != "":
+			if identity.ApplicationCredential.Secret == "secret-"+identity.ApplicationCredential.ID {
+				user = identity.ApplicationCredential.ID
+				project = testKeystoneApplicationCredentialProject
+			}
+		case identity.Password.User.Password == "secret-"+identity.Password.User.Name:
+			user = identity.Password.User.Name
+		}
+		if user == "" {
+			http.Error(w, `{"error": {"code": 401, "message": "The request you have made requires authentication."}}`, http.StatusUnauthorized)
+
+			return
+		}
+
+		token := k.Issue(user)
+		k.lock.Lock()
+		k.projects[token] = project
+		k.lock.Unlock()
+		w.Header().Set("X-Subject-Token", token)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		k.writeToken(w, token)
+	case http.MethodGet:
+		token := r.Header.Get("X-Subject-Token")
+		if !k.Valid(token) {
+			http.Error(w, "token not found", http.StatusNotFound)
+
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		k.writeToken(w, token)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (k *testKeystone) writeToken(w http.ResponseWriter, token string) {
+	k.lock.Lock()
+	project := k.projects[token]
+	k.lock.Unlock()
+
+	tokenBody := map[string]interface{}{
+		"expires_at": k.expiresAt(token).UTC().Format(time.RFC3339Nano),
+		"catalog": []map[string]interface{}{
+			{
+				"type": "identity",
+				"name": "keystone",
+				"endpoints": []map[string]interface{}{
+					{
+						"interface": "public",
+						"region":    testKeystoneAuthRegion,
+						"region_id": testKeystoneAuthRegion,
+						"url":       k.AuthURL(),
+					},
+				},
+			},
+			testCatalogEntry(clients.ResellServiceType),
+			testCatalogEntry(MKS),
+		},
+	}
+	if project != "" {
+		tokenBody["project"] = map[string]interface{}{"id": project, "domain": map[string]interface{}{"name": testKeystoneDomainName}}
+	}
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"token": tokenBody})
+}
+
+// testKeystoneServiceRegions are the regions of the services in the catalog of testKeystone.
+var testKeystoneServiceRegions = map[string][]string{
+	clients.ResellServiceType: {"ru-1", "ru-3"},
+	MKS:                       {"ru-1", "ru-7"},
+}
+
+func testCatalogEntry(serviceType string) map[string]interface{} {
+	endpoints := make([]map[string]interface{}, 0)
+	for _, region := range testKeystoneServiceRegions[serviceType] {
+		for _, iface := range []string{"public", "admin"} {
+			endpoints = append(endpoints, map[string]interface{}{
+				"interface": iface,
+				"region":    region,
+				"region_id": region,
+				"url":       fmt.Sprintf("https://%s.%s.example.com", region, serviceType),
+			})
+		}
+	}
+
+	return map[string]interface{}{
+		"type":      serviceType,
+		"name":      serviceType,
+		"endpoints": endpoints,
+	}
+}
+
+func testConfigureProvider(t *testing.T, raw map[string]interface{}) *Config {
+	t.Helper()
+
+	p := Provider()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(raw))
+	require.False(t, diags.HasError(), "unexpected diagnostics: %+v", diags)
+
+	return p.Meta().(*Config)
+}
+
+func TestConfigureProviderAliasesAreIndependent(t *testing.T) {
+	keystone := newTestKeystone(t)
+
+	first := testConfigureProvider(t, map[string]interface{}{
+		"auth_url":    keystone.AuthURL(),
+		"auth_region": testKeystoneAuthRegion,
+		"domain_name": testKeystoneDomainName,
+		"username":    "alice",
+		"password":    "secret-alice",
+		"project_id":  "project-a",
+		"region":      "ru-1",
+	})
+	second := testConfigureProvider(t, map[string]interface{}{
+		"auth_url":    keystone.AuthURL(),
+		"auth_region": testKeystoneAuthRegion,
+		"domain_name": testKeystoneDomainName,
+		"username":    "bob",
+		"password":    "secret-bob",
+		"project_id":  "project-b",
+		"region":      "ru-3",
+	})
+
+	assert.NotSame(t, first, second)
+	assert.Equal(t, "alice", first.Username)
+	assert.Equal(t, "project-a", first.ProjectID)
+	assert.Equal(t, "ru-1", first.Region)
+	assert.Equal(t, "bob", second.Username)
+	assert.Equal(t, "project-b", second.ProjectID)
+	assert.Equal(t, "ru-3", second.Region)
+
+	firstClient, err := first.GetSelVPCClient()
+	require.NoError(t, err)
+	secondClient, err := second.GetSelVPCClient()
+	require.NoError(t, err)
+	assert.NotSame(t, firstClient, secondClient)
+
+	firstToken, err := first.GetXAuthToken()
+	require.NoError(t, err)
+	secondToken, err := second.GetXAuthToken()
+	require.NoError(t, err)
+	assert.Equal(t, "alice", keystone.IssuedTo(firstToken))
+	assert.Equal(t, "bob", keystone.IssuedTo(secondToken))
+
+	// Cached clients are reused within a single provider instance only.
+	cachedClient, err := first.GetSelVPCClient()
+	require.NoError(t, err)
+	assert.Same(t, firstClient, cachedClient)
+}
+
+func TestConfigureProviderAliasWithWrongCredentials(t *testing.T) {
+	keystone := newTestKeystone(t)
+
+	valid := testConfigureProvider(t, map[string]interface{}{
+		"auth_url":    keystone.AuthURL(),
+		"auth_region": testKeystoneAuthRegion,
+		"domain_name": testKeystoneDomainName,
+		"username":    "alice",
+		"password":    "secret-alice",
+	})
+	invalid := testConfigureProvider(t, map[string]interface{}{
+		"auth_url":    keystone.AuthURL(),
+		"auth_region": testKeystoneAuthRegion,
+		"domain_name": testKeystoneDomainName,
+		"username":    "bob",
+		"password":    "wrong",
+	})
+
+	_, err := invalid.GetSelVPCClient()
+	require.Error(t, err)
+
+	token, err := valid.GetXAuthToken()
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(token, "token-alice-"))
+}
+
+func TestConfigureProviderWithAuthToken(t *testing.T) {
+	keystone := newTestKeystone(t)
+	preIssued := keystone.Issue("ci-runner")
+
+	config := testConfigureProvider(t, map[string]interface{}{
+		"auth_url":    keystone.AuthURL(),
+		"auth_region": testKeystoneAuthRegion,
+		"domain_name": testKeystoneDomainName,
+		"auth_token":  preIssued,
+	})
+
+	token, err := config.GetXAuthTokenWithProjectScope("project-a")
+	require.NoError(t, err)
+	assert.NotEqual(t, preIssued, token)
+	assert.Equal(t, "ci-runner", keystone.IssuedTo(token))
+}
+
+func TestConfigureProviderWithApplicationCredentials(t *testing.T) {
+	keystone := newTestKeystone(t)
+
+	config := testConfigureProvider(t, map[string]interface{}{
+		"auth_url":                      keystone.AuthURL(),
+		"auth_region":                   testKeystoneAuthRegion,
+		"domain_name":                   testKeystoneDomainName,
+		"application_credential_id":     "app-cred",
+		"application_credential_secret": "secret-app-cred",
+	})
+
+	token, err := config.GetXAuthToken()
+	require.NoError(t, err)
+	assert.Equal(t, "app-cred", keystone.IssuedTo(token))
+
+	token, err = config.GetXAuthTokenWithProjectScope(testKeystoneApplicationCredentialProject)
+	require.NoError(t, err)
+	assert.Equal(t, "app-cred", keystone.IssuedTo(token))
+
+	_, err = config.GetXAuthTokenWithProjectScope("project-a")
+	assert.EqualError(t, err, "application credential is bound to project project-app-cred, cannot act on project project-a")
+}
+
+func TestConfigureProviderWithoutCredentials(t *testing.T) {
+	for _, env := range []string{
+		"OS_USERNAME", "OS_PASSWORD", "OS_TOKEN", "OS_AUTH_TOKEN",
+		"OS_APPLICATION_CREDENTIAL_ID", "OS_APPLICATION_CREDENTIAL_SECRET",
+	} {
+		t.Setenv(env, "")
+	}
+
+	p := Provider()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"auth_url":    "https://cloud.api.selcloud.ru/identity/v3/",
+		"auth_region": testKeystoneAuthRegion,
+		"domain_name": testKeystoneDomainName,
+		"username":    "alice",
+	}))
+
+	require.True(t, diags.HasError())
+	assert.Equal(t, errNoAuthMethod.Error(), diags[0].Summary)
+}
+
+func TestConfigAuthOptions(t *testing.T) {
+	config := &Config{
+		AuthURL:    "https://cloud.api.selcloud.ru/identity/v3/",
+		DomainName: testKeystoneDomainName,
+		Username:   "alice",
+		Password:   "secret",
+	}
+
+	opts := config.authOptions("")
+	assert.Equal(t, "alice", opts.Username)
+	assert.Equal(t, testKeystoneDomainName, opts.DomainName)
+	assert.Equal(t, testKeystoneDomainName, opts.Scope.DomainName)
+
+	opts = config.authOptions("project-a")
+	assert.Equal(t, "project-a", opts.Scope.ProjectID)
+	assert.Empty(t, opts.Scope.DomainName)
+
+	config.AuthToken = "token"
+	opts = config.authOptions("project-a")
+	assert.Equal(t, "token", opts.TokenID)
+	assert.Empty(t, opts.Username)
+	assert.Equal(t, "project-a", opts.Scope.ProjectID)
+
+	config.AuthToken = ""
+	config.ApplicationCredentialID = "app-cred"
+	config.ApplicationCredentialSecret = "app-secret"
+	opts = config.authOptions("project-a")
+	assert.Equal(t, "app-cred", opts.ApplicationCredentialID)
+	assert.Empty(t, opts.Username)
+	assert.Nil(t, opts.Scope)
+}
+
+func TestConfigKeepsValidToken(t *testing.T) {
+	keystone := newTestKeystone(t)
+
+	config := testConfigureProvider(t, map[string]interface{}{
+		"auth_url":    keystone.AuthURL(),
+		"auth_region": testKeystoneAuthRegion,
+		"domain_name": testKeystoneDomainName,
+		"username":    "alice",
+		"password":    "secret-alice",
+	})
+
+	first, err := config.GetXAuthTokenWithProjectScope("project-a")
+	require.NoError(t, err)
+	second, err := config.GetXAuthTokenWithProjectScope("project-a")
+	require.NoError(t, err)
+
+	assert.Equal(t, first, second)
+	assert.Equal(t, 1, keystone.Issued())
+}
+
+func TestConfigRefreshesExpiringToken(t *testing.T) {
+	keystone := newTestKeystone(t)
+	keystone.TTL = tokenRefreshMargin / 2
+
+	config := testConfigureProvider(t, map[string]interface{}{
+		"auth_url":    keystone.AuthURL(),
+		"auth_region": testKeystoneAuthRegion,
+		"domain_name": testKeystoneDomainName,
+		"username":    "alice",
+		"password":    "secret-alice",
+	})
+
+	first, err := config.GetXAuthTokenWithProjectScope("project-a")
+	require.NoError(t, err)
+	second, err := config.GetXAuthTokenWithProjectScope("project-a")
+	require.NoError(t, err)
+
+	assert.NotEqual(t, first, second)
+	assert.True(t, keystone.Valid(second))
+	assert.Equal(t, "alice", keystone.IssuedTo(second))
+}
+
+func TestConfigReauthenticateWithProjectScope(t *testing.T) {
+	keystone := newTestKeystone(t)
+
+	config := testConfigureProvider(t, map[string]interface{}{
+		"auth_url":    keystone.AuthURL(),
+		"auth_region": testKeystoneAuthRegion,
+		"domain_name": testKeystoneDomainName,
+		"username":    "alice",
+		"password":    "secret-alice",
+	})
+
+	stale, err := config.GetXAuthTokenWithProjectScope("project-a")
+	require.NoError(t, err)
+	keystone.Revoke(stale)
+
+	fresh, err := config.reauthenticateWithProjectScope("project-a", stale)
+	require.NoError(t, err)
+	assert.NotEqual(t, stale, fresh)
+	assert.True(t, keystone.Valid(fresh))
+
+	// A second 401 with the same stale token must not issue one more token.
+	again, err := config.reauthenticateWithProjectScope("project-a", stale)
+	require.NoError(t, err)
+	assert.Equal(t, fresh, again)
+}
@@ -3,80 +3,132 @@ package selectel
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	v1 "github.com/selectel/craas-go/pkg"
 	"github.com/selectel/craas-go/pkg/v1/registry"
 	"github.com/selectel/go-selvpcclient/v4/selvpcclient"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/waiter"
 )
 
 const (
 	craasV1TokenUsername = "token"
+
+	// craasRegistryV1StatusDeleted is the status of the registry that is not found.
+	craasRegistryV1StatusDeleted registry.Status = "DELETED"
 )
 
 func waitForCRaaSRegistryV1StableState(
 	ctx context.Context, client *v1.ServiceClient, registryID string, timeout time.Duration,
 ) error {
-	pending := []string{
-		string(registry.StatusCreating),
-		string(registry.StatusDeleting),
-		string(registry.StatusGC),
-	}
-	target := []string{
-		string(registry.StatusActive),
-	}
-
-	stateConf := &resource.StateChangeConf{
-		Pending:      pending,
-		Target:       target,
-		Timeout:      timeout,
-		Refresh:      craasRegistryV1StateRefreshFunc(ctx, client, registryID),
-		Delay:        1 * time.Second,
-		PollInterval: 1 * time.Second,
+	stateConf := &waiter.StateConf[*registry.Registry, registry.Status]{
+		Object: "registry " + registryID,
+		Pending: []registry.Status{
+			registry.StatusCreating,
+			registry.StatusDeleting,
+			registry.StatusGC,
+		},
+		Target:  []registry.Status{registry.StatusActive},
+		Failure: []registry.Status{registry.StatusError},
+		Refresh: func(ctx context.Context) (*registry.Registry, registry.Status, error) {
+			r, _, err := registry.Get(ctx, client, registryID)
+			if err != nil {
+				return nil, "", err
+			}
+
+			return r, r.Status, nil
+		},
+		Timeout:     timeout,
+		Delay:       1 * time.Second,
+		MinInterval: 1 * time.Second,
 	}
 
-	_, err := stateConf.WaitForStateContext(ctx)
+	_, err := stateConf.WaitForState(ctx)
 	if err != nil {
 		return fmt.Errorf(
-			"error waiting for registry %s to achieve a stable state: %s",
+			"error waiting for registry %s to achieve a stable state: %w",
 			registryID, err)
 	}
 
 	return nil
 }
 
-func craasRegistryV1StateRefreshFunc(
-	ctx context.Context, client *v1.ServiceClient, registryID string,
-) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		r, _, err := registry.Get(ctx, client, registryID)
-		if err != nil {
-			return nil, "", err
-		}
+func waitForCRaaSRegistryV1Deleted(
+	ctx context.Context, client *v1.ServiceClient, registryID string, timeout time.Duration,
+) error {
+	stateConf := &waiter.StateConf[*registry.Registry, registry.Status]{
+		Object: "registry " + registryID,
+		Pending: []registry.Status{
+			registry.StatusActive,
+			registry.StatusCreating,
+			registry.StatusDeleting,
+			registry.StatusGC,
+		},
+		Target:  []registry.Status{craasRegistryV1StatusDeleted},
+		Failure: []registry.Status{registry.StatusError},
+		Refresh: func(ctx context.Context) (*registry.Registry, registry.Status, error) {
+			r, response, err := registry.Get(ctx, client, registryID)
+			if err != nil {
+				if response != nil && response.StatusCode == http.StatusNotFound {
+					return nil, craasRegistryV1StatusDeleted, nil
+				}
+
+				return nil, "", err
+			}
+
+			return r, r.Status, nil
+		},
+		Timeout:     timeout,
+		Delay:       1 * time.Second,
+		MinInterval: 1 * time.Second,
+	}
 
-		return r, string(r.Status), nil
+	_, err := stateConf.WaitForState(ctx)
+	if err != nil {
+		return fmt.Errorf("error waiting for registry %s to become deleted: %w", registryID, err)
 	}
+
+	return nil
 }
 
 func getCRaaSClient(d *schema.ResourceData, meta interface{}) (*v1.ServiceClient, diag.Diagnostics) {
 	config := meta.(*Config)
-	selvpcClient, err := config.GetSelVPCClientWithProjectScope(d.Get("project_id").(string))
+	projectID := d.Get("project_id").(string)
+
+	craasClient, err := newCRaaSClient(config, projectID)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	return craasClient, nil
+}
+
+// newCRaaSClient creates the CRaaS client for the project. It is used by
+// the resources of both the SDK and the framework providers.
+func newCRaaSClient(config *Config, projectID string) (*v1.ServiceClient, error) {
+	selvpcClient, err := config.GetSelVPCClientWithProjectScope(projectID)
 	if err != nil {
-		return nil, diag.FromErr(fmt.Errorf("can't get project-scope selvpc client for craas: %w", err))
+		return nil, fmt.Errorf("can't get project-scope selvpc client for craas: %w", err)
 	}
 
-	endpoint, diagErr := getEndpointForCRaaS(selvpcClient)
-	if diagErr != nil {
-		return nil, diag.FromErr(fmt.Errorf("can't get endpoint to init craas client: %w", err))
+	endpoint, ok := config.endpointOverride(CRaaS)
+	if !ok {
+		endpoint, err = getEndpointForCRaaS(selvpcClient)
+		if err != nil {
+			return nil, fmt.Errorf("can't get endpoint to init craas client: %w", err)
+		}
 	}
 
-	craasClient := v1.NewCRaaSClientV1(selvpcClient.GetXAuthToken(), endpoint)
+	token, err := config.GetXAuthTokenWithProjectScope(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("can't get project-scope token for craas: %w", err)
+	}
 
-	return craasClient, nil
+	return v1.NewCRaaSClientV1WithCustomHTTP(config.newHTTPClient(CRaaS, projectID), token, endpoint), nil
 }
 
 // https://cr.selcloud.ru/api/v1 -> https://cr.selcloud.ru
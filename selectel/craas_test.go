@@ -29,7 +29,12 @@ func newCRaaSTestClient(rs *terraform.ResourceState, testAccProvider *schema.Pro
 		return nil, fmt.Errorf("can't get endpoint for craas acc tests: %w", err)
 	}
 
-	craasClient := v1.NewCRaaSClientV1(selvpcClient.GetXAuthToken(), craasEndpoint)
+	token, err := config.GetXAuthTokenWithProjectScope(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("can't get token for craas acc tests: %w", err)
+	}
+
+	craasClient := v1.NewCRaaSClientV1(token, craasEndpoint)
 
 	return craasClient, nil
 }
@@ -23,8 +23,8 @@ func TestAccDBaaSAvailableExtensionsV1Basic(t *testing.T) {
 	projectName := acctest.RandomWithPrefix("tf-acc")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDBaaSAvailableExtensionsV1Basic(projectName, availableExtensionName),
@@ -25,9 +25,9 @@ func TestAccDBaaSConfigurationParametersV1Basic(t *testing.T) {
 	parameterNameWithChoices := "session_replication_role"
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDBaaSConfigurationParametersV1Basic(projectName, datastoreTypeEngine, datastoreTypeVersion, parameterName),
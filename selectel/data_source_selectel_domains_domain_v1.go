@@ -37,7 +37,7 @@ func dataSourceDomainsDomainV1Read(ctx context.Context, d *schema.ResourceData,
 
 	domainObj, _, err := domain.GetByName(ctx, client, domainName)
 	if err != nil {
-		return diag.FromErr(errGettingObject(objectDomain, domainName, err))
+		return diagGettingObject(objectDomain, domainName, err)
 	}
 
 	d.SetId(strconv.Itoa(domainObj.ID))
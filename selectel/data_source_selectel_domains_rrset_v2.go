@@ -27,7 +27,8 @@ func dataSourceDomainsRRSetV2() *schema.Resource {
 			},
 			"project_id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 			},
 			"comment": {
 				Type:     schema.TypeString,
@@ -18,9 +18,9 @@ func TestAccDomainsRRSetV2DataSourceBasic(t *testing.T) {
 	testRRSetContent := fmt.Sprintf("\"%[1]s\"", acctest.RandString(16))
 	dataSourceRRSetName := fmt.Sprintf("data.selectel_domains_rrset_v2.%[1]s", resourceRRSetName)
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckDomainsV2RRSetDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckDomainsV2RRSetDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDomainsRRSetV2DataSourceBasic(testProjectName, resourceRRSetName, testRRSetName, string(testRRSetType), testRRSetContent, testRRSetTTL, resourceZoneName, testZoneName),
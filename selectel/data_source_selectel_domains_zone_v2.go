@@ -18,7 +18,8 @@ func dataSourceDomainsZoneV2() *schema.Resource {
 			},
 			"project_id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 			},
 			"comment": {
 				Type:     schema.TypeString,
@@ -69,7 +70,7 @@ func dataSourceDomainsZoneV2Read(ctx context.Context, d *schema.ResourceData, me
 
 	err = setZoneToResourceData(d, zone)
 	if err != nil {
-		return diag.FromErr(errGettingObject(objectZone, zoneName, err))
+		return diagGettingObject(objectZone, zoneName, err)
 	}
 
 	return nil
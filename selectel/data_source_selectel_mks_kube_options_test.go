@@ -5,7 +5,6 @@ import (
 	"strconv"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/selectel/go-selvpcclient/v4/selvpcclient/resell/v2/projects"
@@ -17,19 +16,21 @@ const (
 )
 
 func TestAccMKSAvailableFeatureGatesV1Basic(t *testing.T) {
+	recording := testAccStartRecording(t)
+
 	var project projects.Project
 
-	projectName := acctest.RandomWithPrefix("tf-acc")
-	kubeVersion := testAccMKSClusterV1GetDefaultKubeVersion(t)
+	projectName := recording.RandomWithPrefix("tf-acc")
+	kubeVersion := testAccMKSClusterV1GetDefaultKubeVersion(t, recording)
 	kubeVersionMinor, err := kubeVersionTrimToMinor(kubeVersion)
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testKubeOptionsV1BasicConfig(projectName, dataSourceFeatureGates, kubeVersion),
@@ -44,14 +45,16 @@ func TestAccMKSAvailableFeatureGatesV1Basic(t *testing.T) {
 }
 
 func TestAccMKSAvailableFeatureGatesV1NoFilter(t *testing.T) {
+	recording := testAccStartRecording(t)
+
 	var project projects.Project
 
-	projectName := acctest.RandomWithPrefix("tf-acc")
+	projectName := recording.RandomWithPrefix("tf-acc")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testKubeOptionsV1ConfigNoFilter(projectName, dataSourceFeatureGates),
@@ -65,19 +68,21 @@ func TestAccMKSAvailableFeatureGatesV1NoFilter(t *testing.T) {
 }
 
 func TestAccMKSAvailableAdmissionControllersV1Basic(t *testing.T) {
+	recording := testAccStartRecording(t)
+
 	var project projects.Project
 
-	projectName := acctest.RandomWithPrefix("tf-acc")
-	kubeVersion := testAccMKSClusterV1GetDefaultKubeVersion(t)
+	projectName := recording.RandomWithPrefix("tf-acc")
+	kubeVersion := testAccMKSClusterV1GetDefaultKubeVersion(t, recording)
 	kubeVersionMinor, err := kubeVersionTrimToMinor(kubeVersion)
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testKubeOptionsV1BasicConfig(projectName, dataSourceAdmissionControllers, kubeVersion),
@@ -92,14 +97,16 @@ func TestAccMKSAvailableAdmissionControllersV1Basic(t *testing.T) {
 }
 
 func TestAccMKSAvailableAdmissionControllersV1NoFilter(t *testing.T) {
+	recording := testAccStartRecording(t)
+
 	var project projects.Project
 
-	projectName := acctest.RandomWithPrefix("tf-acc")
+	projectName := recording.RandomWithPrefix("tf-acc")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testKubeOptionsV1ConfigNoFilter(projectName, dataSourceAdmissionControllers),
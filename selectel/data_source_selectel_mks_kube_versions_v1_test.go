@@ -13,9 +13,9 @@ import (
 func TestAccMKSKubeVersionsV1DataSourceBasic(t *testing.T) {
 	projectName := acctest.RandomWithPrefix("tf-acc")
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccMKSKubeVersionsV1Basic(projectName),
@@ -14,7 +14,8 @@ func dataSourceMKSKubeconfigV1() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"project_id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"cluster_id": {
@@ -24,7 +25,8 @@ func dataSourceMKSKubeconfigV1() *schema.Resource {
 			},
 			"region": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 			},
 			"raw_config": {
 				Type:      schema.TypeString,
@@ -65,12 +67,12 @@ func dataSourceMKSKubeconfigV1Read(ctx context.Context, d *schema.ResourceData,
 
 	mksCluster, _, err := cluster.Get(ctx, mksClient, clusterID)
 	if err != nil {
-		return diag.FromErr(errGettingObject(objectCluster, clusterID, err))
+		return diagGettingObject(objectCluster, clusterID, err)
 	}
 
 	parsedKubeconfig, _, err := cluster.GetParsedKubeconfig(ctx, mksClient, mksCluster.ID)
 	if err != nil {
-		return diag.FromErr(errGettingObject(objectKubeConfig, clusterID, err))
+		return diagGettingObject(objectKubeConfig, clusterID, err)
 	}
 
 	d.SetId(clusterID)
@@ -6,21 +6,22 @@ import (
 	"testing"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccMKSKubeconfigV1DataSourceBasic(t *testing.T) {
-	projectName := acctest.RandomWithPrefix("tf-acc")
-	clusterName := acctest.RandomWithPrefix("tf-acc-cl")
-	kubeVersion := testAccMKSClusterV1GetDefaultKubeVersion(t)
-	maintenanceWindowStart := testAccMKSClusterV1GetMaintenanceWindowStart(12 * time.Hour)
+	recording := testAccStartRecording(t)
+
+	projectName := recording.RandomWithPrefix("tf-acc")
+	clusterName := recording.RandomWithPrefix("tf-acc-cl")
+	kubeVersion := testAccMKSClusterV1GetDefaultKubeVersion(t, recording)
+	maintenanceWindowStart := testAccMKSClusterV1GetMaintenanceWindowStart(recording, 12*time.Hour)
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccMKSKubeconfigV1Basic(projectName, clusterName, kubeVersion, maintenanceWindowStart),
@@ -0,0 +1,184 @@
+package selectel
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/selectel/go-selvpcclient/v4/selvpcclient"
+	"github.com/selectel/go-selvpcclient/v4/selvpcclient/resell/v2/projects"
+	resellQuotas "github.com/selectel/go-selvpcclient/v4/selvpcclient/resell/v2/quotas"
+)
+
+func dataSourceVPCProjectV2() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceVPCProjectV2Read,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+			},
+			"url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"custom_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"theme": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"all_quotas": resourceVPCProjectV2().Schema["all_quotas"],
+			"quotas_headroom": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"resource_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"region": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"zone": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"headroom": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceVPCProjectV2Read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	selvpcClient, err := config.GetSelVPCClient()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("can't get selvpc client for project object: %w", err))
+	}
+
+	var project *projects.Project
+	if projectID := d.Get("id").(string); projectID != "" {
+		log.Print(msgGet(objectProject, projectID))
+		project, _, err = projects.Get(selvpcClient, projectID)
+		if err != nil {
+			return diagGettingObject(objectProject, projectID, err)
+		}
+	} else {
+		projectName := d.Get("name").(string)
+		log.Print(msgGet(objectProject, projectName))
+		project, err = getVPCProjectV2ByName(selvpcClient, projectName)
+		if err != nil {
+			return diagGettingObject(objectProject, projectName, err)
+		}
+	}
+
+	d.SetId(project.ID)
+
+	projectCustomURL, err := resourceVPCProjectV2URLWithoutSchema(project.CustomURL)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("custom_url", projectCustomURL)
+	d.Set("name", project.Name)
+	d.Set("url", project.URL)
+	d.Set("enabled", project.Enabled)
+	if err := d.Set("theme", flattenVPCProjectV2Theme(project.Theme)); err != nil {
+		log.Print(errSettingComplexAttr("theme", err))
+	}
+
+	allQuotas := resourceVPCProjectV2QuotasToSet(project.Quotas)
+	if err := d.Set("all_quotas", allQuotas); err != nil {
+		log.Print(errSettingComplexAttr("all_quotas", err))
+	}
+	if err := d.Set("quotas_headroom", flattenVPCProjectV2QuotasHeadroom(project.Quotas)); err != nil {
+		log.Print(errSettingComplexAttr("quotas_headroom", err))
+	}
+
+	return nil
+}
+
+// getVPCProjectV2ByName returns the only project with the name. Project names are not
+// unique, so the lookup fails if several projects have the name.
+func getVPCProjectV2ByName(selvpcClient *selvpcclient.Client, name string) (*projects.Project, error) {
+	allProjects, _, err := projects.List(selvpcClient)
+	if err != nil {
+		return nil, err
+	}
+
+	var found *projects.Project
+	for _, project := range allProjects {
+		if project.Name != name {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("found multiple projects with the name, use id to choose one of them: %s, %s",
+				found.ID, project.ID)
+		}
+		found = project
+	}
+	if found == nil {
+		return nil, fmt.Errorf("project with the name %q is not found", name)
+	}
+
+	return found, nil
+}
+
+// flattenVPCProjectV2QuotasHeadroom returns the quotas that are left for every resource
+// in every region and zone, sorted by the resource name, the region and the zone.
+func flattenVPCProjectV2QuotasHeadroom(quotasStructures []resellQuotas.Quota) []interface{} {
+	headroom := make([]map[string]interface{}, 0, len(quotasStructures))
+	for _, quota := range quotasStructures {
+		for _, entity := range quota.ResourceQuotasEntities {
+			headroom = append(headroom, map[string]interface{}{
+				"resource_name": quota.Name,
+				"region":        entity.Region,
+				"zone":          entity.Zone,
+				"headroom":      entity.Value - entity.Used,
+			})
+		}
+	}
+
+	sort.Slice(headroom, func(i, j int) bool {
+		for _, key := range []string{"resource_name", "region", "zone"} {
+			a, b := headroom[i][key].(string), headroom[j][key].(string)
+			if a != b {
+				return a < b
+			}
+		}
+
+		return false
+	})
+
+	result := make([]interface{}, len(headroom))
+	for i, h := range headroom {
+		result[i] = h
+	}
+
+	return result
+}
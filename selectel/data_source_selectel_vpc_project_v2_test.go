@@ -0,0 +1,140 @@
+package selectel
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/selectel/go-selvpcclient/v4/selvpcclient/resell/v2/projects"
+	resellQuotas "github.com/selectel/go-selvpcclient/v4/selvpcclient/resell/v2/quotas"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/fakeapi"
+)
+
+func TestAccVPCV2ProjectDataSourceBasic(t *testing.T) {
+	var project projects.Project
+	projectName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVPCV2ProjectDataSourceBasic(projectName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVPCV2ProjectExists("selectel_vpc_project_v2.project_tf_acc_test_1", &project),
+					resource.TestCheckResourceAttrPair("data.selectel_vpc_project_v2.project_tf_acc_test_1", "id", "selectel_vpc_project_v2.project_tf_acc_test_1", "id"),
+					resource.TestCheckResourceAttr("data.selectel_vpc_project_v2.project_tf_acc_test_1", "name", projectName),
+					resource.TestCheckResourceAttr("data.selectel_vpc_project_v2.project_tf_acc_test_1", "enabled", "true"),
+					resource.TestCheckResourceAttrSet("data.selectel_vpc_project_v2.project_tf_acc_test_1", "url"),
+					resource.TestCheckResourceAttrSet("data.selectel_vpc_project_v2.project_tf_acc_test_1", "quotas_headroom.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitVPCV2ProjectDataSourceBasic(t *testing.T) {
+	api := newTestFakeAPI(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: api.ProviderConfig() + testUnitVPCV2ProjectDataSourceBasic(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.selectel_vpc_project_v2.project_tf_acc_test_1", "id", fakeapi.ProjectID),
+					resource.TestCheckResourceAttr("data.selectel_vpc_project_v2.project_tf_acc_test_1", "theme.color", fakeapi.ProjectThemeColor),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceVPCProjectV2Read(t *testing.T) {
+	ctx := context.Background()
+	api := newTestFakeAPI(t)
+	config := testVPCProjectQuotasV2Config(t, api)
+	api.SetQuotaUsage(fakeapi.ProjectID, fakeapi.Region, "compute_cores", fakeapi.Region+"a", 3)
+
+	for name, attrs := range map[string]map[string]interface{}{
+		"by id":   {"id": fakeapi.ProjectID},
+		"by name": {"name": fakeapi.ProjectName},
+	} {
+		t.Run(name, func(t *testing.T) {
+			d := dataSourceVPCProjectV2().TestResourceData()
+			for attr, value := range attrs {
+				require.NoError(t, d.Set(attr, value))
+			}
+
+			require.False(t, dataSourceVPCProjectV2Read(ctx, d, config).HasError())
+			assert.Equal(t, fakeapi.ProjectID, d.Id())
+			assert.Equal(t, fakeapi.ProjectName, d.Get("name"))
+			assert.Equal(t, true, d.Get("enabled"))
+			assert.Equal(t, map[string]interface{}{"color": fakeapi.ProjectThemeColor}, d.Get("theme"))
+			assert.Equal(t, 4, d.Get("all_quotas.#"))
+
+			headroom := map[string]int{}
+			for _, raw := range d.Get("quotas_headroom").([]interface{}) {
+				h := raw.(map[string]interface{})
+				headroom[fmt.Sprintf("%s/%s/%s", h["resource_name"], h["region"], h["zone"])] = h["headroom"].(int)
+			}
+			// The usage above the quota gives a negative headroom.
+			assert.Equal(t, -3, headroom["compute_cores/ru-1/ru-1a"])
+			assert.Equal(t, clusterQuotaTestValue, headroom["mks_cluster_zonal/ru-3/"])
+		})
+	}
+
+	d := dataSourceVPCProjectV2().TestResourceData()
+	require.NoError(t, d.Set("name", "unknown"))
+	assert.True(t, dataSourceVPCProjectV2Read(ctx, d, config).HasError())
+}
+
+func TestFlattenVPCProjectV2QuotasHeadroom(t *testing.T) {
+	quotas := []resellQuotas.Quota{
+		{
+			Name: "compute_ram",
+			ResourceQuotasEntities: []resellQuotas.ResourceQuotaEntity{
+				{Region: "ru-3", Zone: "ru-3a", Value: 2048, Used: 1024},
+				{Region: "ru-1", Zone: "ru-1b", Value: 1024},
+			},
+		},
+		{
+			Name: "compute_cores",
+			ResourceQuotasEntities: []resellQuotas.ResourceQuotaEntity{
+				{Region: "ru-1", Zone: "ru-1a", Value: 4, Used: 4},
+			},
+		},
+	}
+
+	expected := []interface{}{
+		map[string]interface{}{"resource_name": "compute_cores", "region": "ru-1", "zone": "ru-1a", "headroom": 0},
+		map[string]interface{}{"resource_name": "compute_ram", "region": "ru-1", "zone": "ru-1b", "headroom": 1024},
+		map[string]interface{}{"resource_name": "compute_ram", "region": "ru-3", "zone": "ru-3a", "headroom": 1024},
+	}
+
+	assert.Equal(t, expected, flattenVPCProjectV2QuotasHeadroom(quotas))
+}
+
+func testAccVPCV2ProjectDataSourceBasic(projectName string) string {
+	return fmt.Sprintf(`
+resource "selectel_vpc_project_v2" "project_tf_acc_test_1" {
+  name = "%s"
+}
+
+data "selectel_vpc_project_v2" "project_tf_acc_test_1" {
+  name = selectel_vpc_project_v2.project_tf_acc_test_1.name
+}`, projectName)
+}
+
+func testUnitVPCV2ProjectDataSourceBasic() string {
+	return fmt.Sprintf(`
+data "selectel_vpc_project_v2" "project_tf_acc_test_1" {
+  name = %q
+}`, fakeapi.ProjectName)
+}
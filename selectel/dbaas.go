@@ -39,17 +39,17 @@ func getDBaaSClient(d *schema.ResourceData, meta interface{}) (*dbaas.API, diag.
 		return nil, diag.FromErr(fmt.Errorf("can't get project-scope selvpc client for dbaas: %w", err))
 	}
 
-	err = validateRegion(selvpcClient, DBaaS, region)
+	endpoint, err := config.getEndpoint(selvpcClient, DBaaS, region)
 	if err != nil {
-		return nil, diag.FromErr(fmt.Errorf("can't validate region: %w", err))
+		return nil, diag.FromErr(fmt.Errorf("can't get endpoint to init dbaas client: %w", err))
 	}
 
-	endpoint, err := selvpcClient.Catalog.GetEndpoint(DBaaS, region)
+	token, err := config.GetXAuthTokenWithProjectScope(projectID)
 	if err != nil {
-		return nil, diag.FromErr(fmt.Errorf("can't get endpoint to init dbaas client: %w", err))
+		return nil, diag.FromErr(fmt.Errorf("can't get project-scope token for dbaas: %w", err))
 	}
 
-	client, err := dbaas.NewDBAASClient(selvpcClient.GetXAuthToken(), endpoint.URL)
+	client, err := dbaas.NewDBAASClientV1WithCustomHTTP(config.newHTTPClient(DBaaS, projectID), token, endpoint)
 	if err != nil {
 		return nil, diag.FromErr(fmt.Errorf("can't create dbaas client: %w", err))
 	}
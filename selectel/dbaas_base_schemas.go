@@ -10,12 +10,14 @@ func resourceDBaaSDatastoreV1BaseSchema() map[string]*schema.Schema {
 		},
 		"project_id": {
 			Type:     schema.TypeString,
-			Required: true,
+			Optional: true,
+			Computed: true,
 			ForceNew: true,
 		},
 		"region": {
 			Type:     schema.TypeString,
-			Required: true,
+			Optional: true,
+			Computed: true,
 			ForceNew: true,
 		},
 		"subnet_id": {
@@ -129,12 +131,14 @@ func resourceDBaaSDatabaseV1BaseSchema() map[string]*schema.Schema {
 		},
 		"project_id": {
 			Type:     schema.TypeString,
-			Required: true,
+			Optional: true,
+			Computed: true,
 			ForceNew: true,
 		},
 		"region": {
 			Type:     schema.TypeString,
-			Required: true,
+			Optional: true,
+			Computed: true,
 			ForceNew: true,
 		},
 		"datastore_id": {
@@ -11,7 +11,7 @@ import (
 
 func updateRedisDatastorePassword(ctx context.Context, d *schema.ResourceData, client *dbaas.API) error {
 	passwordOpts := dbaas.DatastorePasswordOpts{
-		RedisPassword: d.Get("redis_password").(string),
+		RedisPassword: secretValue(d, "redis_password", "redis_password_wo"),
 	}
 
 	log.Print(msgUpdate(objectDatastore, d.Id(), passwordOpts))
@@ -32,7 +32,12 @@ func newTestDBaaSClient(_ context.Context, rs *terraform.ResourceState, testAccP
 		endpoint = dbaasEndpoint.URL
 	}
 
-	dbaasClient, err := dbaas.NewDBAASClient(selvpcClient.GetXAuthToken(), endpoint)
+	token, err := config.GetXAuthTokenWithProjectScope(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("can't get token for dbaas acc tests: %w", err)
+	}
+
+	dbaasClient, err := dbaas.NewDBAASClient(token, endpoint)
 	if err != nil {
 		return nil, fmt.Errorf("can't get dbaas client for dbaas acc tests: %w", err)
 	}
@@ -0,0 +1,113 @@
+package selectel
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const deletionProtectionKey = "deletion_protection"
+
+// withDeletionProtection adds the deletion_protection attribute to the stateful resource.
+// While the attribute is true in the state, the resource can't be deleted or replaced,
+// so the protection has to be turned off in a separate apply first.
+func withDeletionProtection(object string, r *schema.Resource) *schema.Resource {
+	r.Schema[deletionProtectionKey] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Description: "Prevents the deletion and replacement of the resource while it is true.",
+	}
+
+	if r.CustomizeDiff != nil {
+		r.CustomizeDiff = customdiff.All(r.CustomizeDiff, customizeDiffDeletionProtection(object, r.Schema))
+	} else {
+		r.CustomizeDiff = customizeDiffDeletionProtection(object, r.Schema)
+	}
+
+	deleteFunc := r.DeleteContext
+	r.DeleteContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		if d.Get(deletionProtectionKey).(bool) {
+			return diagDeletionProtection(object, d.Id())
+		}
+
+		return deleteFunc(ctx, d, meta)
+	}
+
+	updateFunc, readFunc := r.UpdateContext, r.ReadContext
+	r.UpdateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		// There is nothing to update in the API when only the protection is changed.
+		if updateFunc == nil || !d.HasChangesExcept(deletionProtectionKey) {
+			return readFunc(ctx, d, meta)
+		}
+
+		return updateFunc(ctx, d, meta)
+	}
+
+	return r
+}
+
+// customizeDiffDeletionProtection fails the plans which replace the protected resource.
+// The protection is read from the state, so it can't be turned off in the same plan.
+func customizeDiffDeletionProtection(object string, s map[string]*schema.Schema) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+		if d.Id() == "" {
+			return nil
+		}
+		protected, _ := d.GetChange(deletionProtectionKey)
+		if !protected.(bool) {
+			return nil
+		}
+
+		changedKeys := d.GetChangedKeysPrefix("")
+		sort.Strings(changedKeys)
+		for _, key := range changedKeys {
+			if schemaRequiresNew(s, key) {
+				return fmt.Errorf("%s '%s' can't be replaced because of the change of %s while deletion_protection is enabled, "+
+					"set deletion_protection to false and apply the configuration first", object, d.Id(), strings.SplitN(key, ".", 2)[0])
+			}
+		}
+
+		return nil
+	}
+}
+
+// schemaRequiresNew reports whether the change of the key of the diff, for example "nodes.0.flavor_id",
+// replaces the resource, that is the attribute or one of its parent blocks is ForceNew.
+func schemaRequiresNew(s map[string]*schema.Schema, key string) bool {
+	parts := strings.Split(key, ".")
+	for len(parts) > 0 {
+		attr, ok := s[parts[0]]
+		if !ok {
+			return false
+		}
+		if attr.ForceNew {
+			return true
+		}
+
+		// The next part is the index of the list or set element, or the count of the elements.
+		elem, ok := attr.Elem.(*schema.Resource)
+		if !ok || len(parts) < 3 {
+			return false
+		}
+		s, parts = elem.Schema, parts[2:]
+	}
+
+	return false
+}
+
+func diagDeletionProtection(object, id string) diag.Diagnostics {
+	return diag.Diagnostics{
+		{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("%s '%s' can't be deleted while deletion_protection is enabled", object, id),
+			Detail:        "Set deletion_protection to false and apply the configuration, then the resource can be deleted.",
+			AttributePath: cty.GetAttrPath(deletionProtectionKey),
+		},
+	}
+}
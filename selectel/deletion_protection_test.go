@@ -0,0 +1,233 @@
+package selectel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDeletionProtectionResource(deleted, updated, read *bool) *schema.Resource {
+	return withDeletionProtection(objectRegistry, &schema.Resource{
+		CreateContext: func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics { return nil },
+		ReadContext: func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+			*read = true
+
+			return nil
+		},
+		UpdateContext: func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+			*updated = true
+
+			return nil
+		},
+		DeleteContext: func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+			*deleted = true
+
+			return nil
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"network": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"subnet": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"comment": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestDeletionProtectionDelete(t *testing.T) {
+	var deleted, updated, read bool
+	r := testDeletionProtectionResource(&deleted, &updated, &read)
+
+	d := r.TestResourceData()
+	d.SetId("id")
+	require.NoError(t, d.Set(deletionProtectionKey, true))
+
+	diags := r.DeleteContext(context.Background(), d, nil)
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "can't be deleted while deletion_protection is enabled")
+	assert.False(t, deleted)
+
+	require.NoError(t, d.Set(deletionProtectionKey, false))
+
+	diags = r.DeleteContext(context.Background(), d, nil)
+	assert.False(t, diags.HasError())
+	assert.True(t, deleted)
+}
+
+func testDeletionProtectionResourceData(t *testing.T, r *schema.Resource, config map[string]interface{}) *schema.ResourceData {
+	t.Helper()
+
+	state := &terraform.InstanceState{
+		ID: "id",
+		Attributes: map[string]string{
+			"id":   "id",
+			"name": "name",
+		},
+	}
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), nil)
+	require.NoError(t, err)
+
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	require.NoError(t, err)
+
+	return d
+}
+
+func TestDeletionProtectionUpdate(t *testing.T) {
+	var deleted, updated, read bool
+	r := testDeletionProtectionResource(&deleted, &updated, &read)
+
+	d := testDeletionProtectionResourceData(t, r, map[string]interface{}{
+		"name":                "name",
+		deletionProtectionKey: true,
+	})
+
+	diags := r.UpdateContext(context.Background(), d, nil)
+	assert.False(t, diags.HasError())
+	assert.True(t, read)
+	assert.False(t, updated)
+
+	d = testDeletionProtectionResourceData(t, r, map[string]interface{}{
+		"name":        "name",
+		"description": "description",
+	})
+
+	diags = r.UpdateContext(context.Background(), d, nil)
+	assert.False(t, diags.HasError())
+	assert.True(t, updated)
+}
+
+func TestDeletionProtectionReplace(t *testing.T) {
+	var deleted, updated, read bool
+	r := testDeletionProtectionResource(&deleted, &updated, &read)
+
+	testCases := []struct {
+		name          string
+		protected     string
+		config        map[string]interface{}
+		expectedError string
+	}{
+		{
+			name:          "replacement of the protected resource",
+			protected:     "true",
+			config:        map[string]interface{}{"name": "new-name", deletionProtectionKey: true},
+			expectedError: "can't be replaced because of the change of name while deletion_protection is enabled",
+		},
+		{
+			name:          "protection is turned off in the same plan",
+			protected:     "true",
+			config:        map[string]interface{}{"name": "new-name"},
+			expectedError: "can't be replaced because of the change of name while deletion_protection is enabled",
+		},
+		{
+			name:      "replacement because of a nested attribute",
+			protected: "true",
+			config: map[string]interface{}{
+				"name":                "name",
+				"network":             []interface{}{map[string]interface{}{"subnet": "new-subnet", "comment": "comment"}},
+				deletionProtectionKey: true,
+			},
+			expectedError: "can't be replaced because of the change of network while deletion_protection is enabled",
+		},
+		{
+			name:      "update of a nested attribute",
+			protected: "true",
+			config: map[string]interface{}{
+				"name":                "name",
+				"network":             []interface{}{map[string]interface{}{"subnet": "subnet", "comment": "new-comment"}},
+				deletionProtectionKey: true,
+			},
+		},
+		{
+			name:      "update of the protected resource",
+			protected: "true",
+			config: map[string]interface{}{
+				"name":                "name",
+				"description":         "description",
+				"network":             []interface{}{map[string]interface{}{"subnet": "subnet", "comment": "comment"}},
+				deletionProtectionKey: true,
+			},
+		},
+		{
+			name:      "replacement of the unprotected resource",
+			protected: "false",
+			config:    map[string]interface{}{"name": "new-name", deletionProtectionKey: true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			state := &terraform.InstanceState{
+				ID: "id",
+				Attributes: map[string]string{
+					"id":                  "id",
+					"name":                "name",
+					"network.#":           "1",
+					"network.0.subnet":    "subnet",
+					"network.0.comment":   "comment",
+					deletionProtectionKey: tc.protected,
+				},
+			}
+
+			_, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(tc.config), nil)
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expectedError)
+		})
+	}
+}
+
+func TestDeletionProtectionSchema(t *testing.T) {
+	resources := map[string]*schema.Resource{
+		"selectel_craas_registry_v1":             resourceCRaaSRegistryV1(),
+		"selectel_dbaas_datastore_v1":            resourceDBaaSDatastoreV1(),
+		"selectel_dbaas_kafka_datastore_v1":      resourceDBaaSKafkaDatastoreV1(),
+		"selectel_dbaas_mysql_datastore_v1":      resourceDBaaSMySQLDatastoreV1(),
+		"selectel_dbaas_postgresql_datastore_v1": resourceDBaaSPostgreSQLDatastoreV1(),
+		"selectel_dbaas_redis_datastore_v1":      resourceDBaaSRedisDatastoreV1(),
+		"selectel_domains_zone_v2":               resourceDomainsZoneV2(),
+		"selectel_mks_cluster_v1":                resourceMKSClusterV1(),
+		"selectel_vpc_project_v2":                resourceVPCProjectV2(),
+	}
+
+	for name, r := range resources {
+		t.Run(name, func(t *testing.T) {
+			require.Contains(t, r.Schema, deletionProtectionKey)
+			assert.Equal(t, schema.TypeBool, r.Schema[deletionProtectionKey].Type)
+			assert.NotNil(t, r.UpdateContext)
+			assert.NotNil(t, r.CustomizeDiff)
+			assert.NoError(t, r.InternalValidate(nil, true))
+		})
+	}
+}
@@ -4,38 +4,46 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
-	"time"
 
-	"github.com/hashicorp/go-retryablehttp"
 	domainsV1 "github.com/selectel/domains-go/pkg/v1"
 )
 
-const (
-	domainsV1DefaultRetryWaitMin = time.Second //nolint:revive
-	domainsV1DefaultRetryWaitMax = 5 * time.Second
-	domainsV1DefaultRetry        = 5
-)
+const domainsV1DefaultAPIURL = "https://api.selectel.ru/domains/v1"
 
 func getDomainsClient(meta interface{}) (*domainsV1.ServiceClient, error) {
 	config := meta.(*Config)
 
-	selvpcClient, err := config.GetSelVPCClient()
+	token, err := config.GetXAuthToken()
 	if err != nil {
-		return nil, fmt.Errorf("can't get selvpc client for domains: %w", err)
+		return nil, fmt.Errorf("can't get token for domains: %w", err)
 	}
 
-	domainsClient := domainsV1.NewDomainsClientV1WithDefaultEndpoint(selvpcClient.GetXAuthToken()).WithOSToken()
+	endpoint, err := domainsV1Endpoint(config)
+	if err != nil {
+		return nil, err
+	}
 
-	retryClient := retryablehttp.NewClient()
-	retryClient.Logger = nil // Ignore retyablehttp client logs
-	retryClient.RetryWaitMin = domainsV1DefaultRetryWaitMin
-	retryClient.RetryWaitMax = domainsV1DefaultRetryWaitMax
-	retryClient.RetryMax = domainsV1DefaultRetry
-	domainsClient.HTTPClient = retryClient.StandardClient()
+	domainsClient := domainsV1.NewDomainsClientV1WithCustomHTTP(config.newHTTPClient(Domains, ""), token, endpoint).WithOSToken()
 
 	return domainsClient, nil
 }
 
+// domainsV1Endpoint returns the URL of the Domains API v1. The domains endpoint override
+// points to the API v2, the v1 URL is the same URL with v1 as the last path element.
+func domainsV1Endpoint(config *Config) (string, error) {
+	endpoint, ok := config.endpointOverride(Domains)
+	if !ok {
+		return domainsV1DefaultAPIURL, nil
+	}
+
+	base, found := strings.CutSuffix(strings.TrimSuffix(endpoint, "/"), "/v2")
+	if !found {
+		return "", fmt.Errorf("can't get domains v1 endpoint from the %s override: it must end with /v2", endpoint)
+	}
+
+	return base + "/v1", nil
+}
+
 const (
 	TypeRecordA     string = "A"
 	TypeRecordAAAA  string = "AAAA"
@@ -71,3 +71,40 @@ func TestGetIntPtrOrNil(_ *testing.T) {
 		getIntPtrOrNil(test.input)
 	}
 }
+
+func TestDomainsV1Endpoint(t *testing.T) {
+	tableTest := []struct {
+		override string
+		expected string
+		err      bool
+	}{
+		{
+			expected: "https://api.selectel.ru/domains/v1",
+		},
+		{
+			override: "https://api.staging.example.com/domains/v2",
+			expected: "https://api.staging.example.com/domains/v1",
+		},
+		{
+			override: "http://127.0.0.1:8080/domains/v2/",
+			expected: "http://127.0.0.1:8080/domains/v1",
+		},
+		{
+			override: "http://127.0.0.1:8080/dns",
+			err:      true,
+		},
+	}
+
+	for _, test := range tableTest {
+		config := &Config{Endpoints: map[string]string{Domains: test.override}}
+
+		actual, err := domainsV1Endpoint(config)
+		if test.err {
+			assert.Error(t, err)
+
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, actual)
+	}
+}
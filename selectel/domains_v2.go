@@ -14,23 +14,26 @@ import (
 	domainsV2 "github.com/selectel/domains-go/pkg/v2"
 )
 
+const domainsV2DefaultAPIURL = "https://api.selectel.ru/domains/v2"
+
 var ErrProjectIDNotSetupForDNSV2 = errors.New("env variable INFRA_PROJECT_ID or variable project_id must be set for the dns v2")
 
 func getDomainsV2Client(d *schema.ResourceData, meta interface{}) (domainsV2.DNSClient[domainsV2.Zone, domainsV2.RRSet], error) {
 	config := meta.(*Config)
 	projectID := d.Get("project_id").(string)
-	selvpcClient, err := config.GetSelVPCClientWithProjectScope(projectID)
+	token, err := config.GetXAuthTokenWithProjectScope(projectID)
 	if err != nil {
-		return nil, fmt.Errorf("can't get selvpc client for domains v2: %w", err)
+		return nil, fmt.Errorf("can't get token for domains v2: %w", err)
 	}
 
-	httpClient := &http.Client{}
-	userAgent := "terraform-provider-selectel"
-	defaultAPIURL := "https://api.selectel.ru/domains/v2"
+	httpClient := config.newHTTPClient(Domains, projectID)
+	apiURL, ok := config.endpointOverride(Domains)
+	if !ok {
+		apiURL = domainsV2DefaultAPIURL
+	}
 	hdrs := http.Header{}
-	hdrs.Add("X-Auth-Token", selvpcClient.GetXAuthToken())
-	hdrs.Add("User-Agent", userAgent)
-	domainsClient := domainsV2.NewClient(defaultAPIURL, httpClient, hdrs)
+	hdrs.Add("X-Auth-Token", token)
+	domainsClient := domainsV2.NewClient(apiURL, httpClient, hdrs)
 
 	return domainsClient, nil
 }
@@ -20,18 +20,21 @@ func getDomainsV2ClientTest(rs *terraform.ResourceState, testAccProvider *schema
 	if !ok {
 		return nil, ErrProjectIDNotSetupForDNSV2
 	}
-	selvpcClient, err := config.GetSelVPCClientWithProjectScope(projectID)
+	token, err := config.GetXAuthTokenWithProjectScope(projectID)
 	if err != nil {
-		return nil, fmt.Errorf("can't get selvpc client for domains v2: %w", err)
+		return nil, fmt.Errorf("can't get token for domains v2: %w", err)
 	}
 
 	httpClient := &http.Client{}
 	userAgent := "terraform-provider-selectel"
-	defaultAPIURL := "https://api.selectel.ru/domains/v2"
+	apiURL, ok := config.endpointOverride(Domains)
+	if !ok {
+		apiURL = domainsV2DefaultAPIURL
+	}
 	hdrs := http.Header{}
-	hdrs.Add("X-Auth-Token", selvpcClient.GetXAuthToken())
+	hdrs.Add("X-Auth-Token", token)
 	hdrs.Add("User-Agent", userAgent)
-	domainsClient := domainsV2.NewClient(defaultAPIURL, httpClient, hdrs)
+	domainsClient := domainsV2.NewClient(apiURL, httpClient, hdrs)
 
 	return domainsClient, nil
 }
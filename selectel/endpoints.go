@@ -0,0 +1,120 @@
+package selectel
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/selectel/go-selvpcclient/v4/selvpcclient"
+)
+
+// endpointsServiceTypes maps attributes of the endpoints block to service types.
+var endpointsServiceTypes = map[string]string{
+	"dbaas":               DBaaS,
+	"mks":                 MKS,
+	"craas":               CRaaS,
+	"iam":                 IAM,
+	"secrets_manager":     SecretsManager,
+	"certificate_manager": CertificateManager,
+	"domains":             Domains,
+}
+
+const endpointsDescription = "Custom endpoints of Selectel APIs, for example staging or mock servers."
+
+func endpointsSchema() *schema.Schema {
+	endpoints := make(map[string]*schema.Schema, len(endpointsServiceTypes))
+	for attr := range endpointsServiceTypes {
+		endpoints[attr] = &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: endpointDescription(attr),
+		}
+	}
+
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: endpointsDescription,
+		Elem: &schema.Resource{
+			Schema: endpoints,
+		},
+	}
+}
+
+func endpointDescription(attr string) string {
+	return fmt.Sprintf("Custom endpoint of the %s API. Overrides the endpoint from the Keystone catalog in all regions. "+
+		"Can also be set with the %s environment variable.", attr, endpointEnvVar(attr))
+}
+
+// endpointEnvVar returns the environment variable that overrides the endpoint attribute.
+func endpointEnvVar(attr string) string {
+	return fmt.Sprintf("SEL_%s_ENDPOINT", strings.ToUpper(attr))
+}
+
+// flattenEndpointsBlock returns the values of the endpoints block keyed by attributes.
+func flattenEndpointsBlock(d *schema.ResourceData) map[string]string {
+	configured := make(map[string]string)
+
+	v, ok := d.GetOk("endpoints")
+	if !ok {
+		return configured
+	}
+	list := v.([]interface{})
+	if len(list) == 0 || list[0] == nil {
+		return configured
+	}
+	for attr, value := range list[0].(map[string]interface{}) {
+		if value, ok := value.(string); ok && value != "" {
+			configured[attr] = value
+		}
+	}
+
+	return configured
+}
+
+// newEndpoints returns endpoint overrides keyed by service types. The configured values
+// are keyed by attributes of the endpoints block and take precedence over environment variables.
+func newEndpoints(configured map[string]string) map[string]string {
+	endpoints := make(map[string]string)
+
+	for attr, serviceType := range endpointsServiceTypes {
+		if v := configured[attr]; v != "" {
+			endpoints[serviceType] = v
+
+			continue
+		}
+		if v := os.Getenv(endpointEnvVar(attr)); v != "" {
+			endpoints[serviceType] = v
+		}
+	}
+
+	return endpoints
+}
+
+// endpointOverride returns the custom endpoint of the service type if it is set.
+func (c *Config) endpointOverride(serviceType string) (string, bool) {
+	endpoint, ok := c.Endpoints[serviceType]
+
+	return endpoint, ok && endpoint != ""
+}
+
+// getEndpoint returns the URL of the service type in the region. A custom endpoint
+// takes precedence over the Keystone catalog, in that case the region is not validated.
+func (c *Config) getEndpoint(selvpcClient *selvpcclient.Client, serviceType, region string) (string, error) {
+	if endpoint, ok := c.endpointOverride(serviceType); ok {
+		return endpoint, nil
+	}
+
+	if err := validateRegion(selvpcClient, serviceType, region); err != nil {
+		return "", fmt.Errorf("can't validate region: %w", err)
+	}
+
+	endpoint, err := selvpcClient.Catalog.GetEndpoint(serviceType, region)
+	if err != nil {
+		return "", err
+	}
+
+	return endpoint.URL, nil
+}
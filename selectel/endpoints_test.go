@@ -0,0 +1,94 @@
+package selectel
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigureProviderEndpoints(t *testing.T) {
+	keystone := newTestKeystone(t)
+	t.Setenv("SEL_MKS_ENDPOINT", "https://mks.env.example.com")
+	t.Setenv("SEL_DBAAS_ENDPOINT", "https://dbaas.env.example.com")
+
+	config := testConfigureProvider(t, map[string]interface{}{
+		"auth_url":    keystone.AuthURL(),
+		"auth_region": testKeystoneAuthRegion,
+		"domain_name": testKeystoneDomainName,
+		"username":    "alice",
+		"password":    "secret-alice",
+		"endpoints": []interface{}{
+			map[string]interface{}{
+				"dbaas":   "https://dbaas.example.com",
+				"domains": "http://127.0.0.1:8080/domains/v2",
+			},
+		},
+	})
+
+	expected := map[string]string{
+		DBaaS:   "https://dbaas.example.com",
+		MKS:     "https://mks.env.example.com",
+		Domains: "http://127.0.0.1:8080/domains/v2",
+	}
+
+	assert.Equal(t, expected, config.Endpoints)
+}
+
+func TestConfigureProviderEndpointsEmpty(t *testing.T) {
+	keystone := newTestKeystone(t)
+	for attr := range endpointsServiceTypes {
+		t.Setenv(endpointEnvVar(attr), "")
+	}
+
+	config := testConfigureProvider(t, map[string]interface{}{
+		"auth_url":    keystone.AuthURL(),
+		"auth_region": testKeystoneAuthRegion,
+		"domain_name": testKeystoneDomainName,
+		"username":    "alice",
+		"password":    "secret-alice",
+	})
+
+	assert.Empty(t, config.Endpoints)
+}
+
+func TestGetDBaaSClientWithEndpointOverride(t *testing.T) {
+	keystone := newTestKeystone(t)
+
+	var gotToken string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotToken = r.Header.Get("X-Auth-Token")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"datastore-types": []}`))
+	}))
+	t.Cleanup(api.Close)
+
+	config := testConfigureProvider(t, map[string]interface{}{
+		"auth_url":    keystone.AuthURL(),
+		"auth_region": testKeystoneAuthRegion,
+		"domain_name": testKeystoneDomainName,
+		"username":    "alice",
+		"password":    "secret-alice",
+		"endpoints": []interface{}{
+			map[string]interface{}{
+				"dbaas": api.URL,
+			},
+		},
+	})
+
+	d := resourceDBaaSDatastoreV1().TestResourceData()
+	require.NoError(t, d.Set("project_id", "project-a"))
+	// The region is absent in the fake catalog, an override skips the validation.
+	require.NoError(t, d.Set("region", "ru-9"))
+
+	client, diagErr := getDBaaSClient(d, config)
+	require.Nil(t, diagErr)
+	assert.Equal(t, api.URL, client.Endpoint)
+
+	_, err := client.DatastoreTypes(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "alice", keystone.IssuedTo(gotToken))
+}
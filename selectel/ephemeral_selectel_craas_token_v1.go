@@ -0,0 +1,187 @@
+package selectel
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/selectel/craas-go/pkg/v1/token"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/hashcode"
+)
+
+// craasTokenV1PrivateKey is the key of the private data of the ephemeral token
+// which is needed to revoke the token on Close.
+const craasTokenV1PrivateKey = "craas_token"
+
+// craasTokenV1EphemeralResource creates a Container Registry token for the duration
+// of a Terraform run and revokes it when Terraform no longer needs it.
+type craasTokenV1EphemeralResource struct {
+	frameworkEphemeralResource
+}
+
+var (
+	_ ephemeral.EphemeralResourceWithConfigure = &craasTokenV1EphemeralResource{}
+	_ ephemeral.EphemeralResourceWithClose     = &craasTokenV1EphemeralResource{}
+)
+
+func newCRaaSTokenV1EphemeralResource() ephemeral.EphemeralResource {
+	return &craasTokenV1EphemeralResource{}
+}
+
+type craasTokenV1EphemeralModel struct {
+	ProjectID types.String `tfsdk:"project_id"`
+	TokenTTL  types.String `tfsdk:"token_ttl"`
+	Username  types.String `tfsdk:"username"`
+	Token     types.String `tfsdk:"token"`
+}
+
+type craasTokenV1PrivateData struct {
+	ProjectID string `json:"project_id"`
+	Token     string `json:"token"`
+}
+
+func (r *craasTokenV1EphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_craas_token_v1"
+}
+
+func (r *craasTokenV1EphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Container Registry token which is revoked at the end of the run and is not stored in the state",
+		Attributes: map[string]schema.Attribute{
+			"project_id": schema.StringAttribute{
+				Description: "id of a project of the registry",
+				Optional:    true,
+				Computed:    true,
+			},
+			"token_ttl": schema.StringAttribute{
+				Description: "lifetime of the token, the token is revoked at the end of the run anyway",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(string(token.TTL12Hours), string(token.TTL1Year)),
+				},
+			},
+			"username": schema.StringAttribute{
+				Description: "username to access the registry",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"token": schema.StringAttribute{
+				Description: "token to access the registry",
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+func (r *craasTokenV1EphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data craasTokenV1EphemeralModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.config == nil {
+		resp.Diagnostics.AddError("The provider is not configured", "")
+
+		return
+	}
+
+	var err error
+	data.ProjectID, err = providerDefaultValue(r.config, "project_id", data.ProjectID)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("project_id"), err.Error(), "")
+
+		return
+	}
+	// The token lives only for the run, so the shortest lifetime is the default.
+	if data.TokenTTL.ValueString() == "" {
+		data.TokenTTL = types.StringValue(string(token.TTL12Hours))
+	}
+
+	craasClient, err := newCRaaSClient(r.config, data.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(err.Error(), "")
+
+		return
+	}
+
+	createOpts := &token.CreateOpts{
+		TokenTTL: token.TTL(data.TokenTTL.ValueString()),
+	}
+
+	log.Print(msgCreate(objectRegistryToken, createOpts))
+
+	newToken, _, err := token.Create(ctx, craasClient, createOpts)
+	if err != nil {
+		resp.Diagnostics.Append(frameworkDiagnostics(diagCreatingObject(objectRegistryToken, err))...)
+
+		return
+	}
+
+	privateData, err := json.Marshal(craasTokenV1PrivateData{
+		ProjectID: data.ProjectID.ValueString(),
+		Token:     newToken.Token,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(err.Error(), "")
+
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, craasTokenV1PrivateKey, privateData)...)
+
+	data.Username = types.StringValue(craasV1TokenUsername)
+	data.Token = types.StringValue(newToken.Token)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, data)...)
+}
+
+// Close revokes the token, so it can't be used after the run.
+func (r *craasTokenV1EphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	privateData, diags := req.Private.GetKey(ctx, craasTokenV1PrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || privateData == nil {
+		return
+	}
+
+	var data craasTokenV1PrivateData
+	if err := json.Unmarshal(privateData, &data); err != nil {
+		resp.Diagnostics.AddError(err.Error(), "")
+
+		return
+	}
+
+	if r.config == nil {
+		resp.Diagnostics.AddError("The provider is not configured", "")
+
+		return
+	}
+
+	craasClient, err := newCRaaSClient(r.config, data.ProjectID)
+	if err != nil {
+		resp.Diagnostics.AddError(err.Error(), "")
+
+		return
+	}
+
+	tokenID := strconv.Itoa(hashcode.String(data.Token))
+
+	log.Print(msgDelete(objectRegistryToken, tokenID))
+
+	response, err := token.Revoke(ctx, craasClient, data.Token)
+	if err != nil {
+		if response != nil && response.StatusCode == http.StatusNotFound {
+			return
+		}
+		resp.Diagnostics.Append(frameworkDiagnostics(diagDeletingObject(objectRegistryToken, tokenID, err))...)
+	}
+}
@@ -0,0 +1,57 @@
+package selectel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/fakeapi"
+)
+
+func TestCRaaSTokenV1EphemeralResource(t *testing.T) {
+	ctx := context.Background()
+	server, api, _ := testFakeAPIProviderServer(t)
+
+	result, resp := testOpenEphemeralResource(t, server, "selectel_craas_token_v1", nil)
+	require.Empty(t, resp.Diagnostics)
+
+	var tokenValue, username, projectID, tokenTTL string
+	require.NoError(t, result["token"].As(&tokenValue))
+	require.NoError(t, result["username"].As(&username))
+	require.NoError(t, result["project_id"].As(&projectID))
+	require.NoError(t, result["token_ttl"].As(&tokenTTL))
+	assert.NotEmpty(t, tokenValue)
+	assert.Equal(t, craasV1TokenUsername, username)
+	assert.Equal(t, fakeapi.ProjectID, projectID)
+	assert.Equal(t, "12h", tokenTTL)
+	assert.Equal(t, 1, api.Len(fakeapi.KindCRaaSTokens))
+
+	closeResp, err := server.CloseEphemeralResource(ctx, &tfprotov5.CloseEphemeralResourceRequest{
+		TypeName: "selectel_craas_token_v1",
+		Private:  resp.Private,
+	})
+	require.NoError(t, err)
+	require.Empty(t, closeResp.Diagnostics)
+	assert.Equal(t, 0, api.Len(fakeapi.KindCRaaSTokens))
+}
+
+func TestCRaaSTokenV1EphemeralResourceInvalidTTL(t *testing.T) {
+	ctx := context.Background()
+	server, _, _ := testFakeAPIProviderServer(t)
+
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	require.NoError(t, err)
+
+	resp, err := server.ValidateEphemeralResourceConfig(ctx, &tfprotov5.ValidateEphemeralResourceConfigRequest{
+		TypeName: "selectel_craas_token_v1",
+		Config: testProviderServerConfig(t, schemaResp.EphemeralResourceSchemas["selectel_craas_token_v1"], map[string]tftypes.Value{
+			"token_ttl": tftypes.NewValue(tftypes.String, "1d"),
+		}),
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Diagnostics, 1)
+	assert.Contains(t, resp.Diagnostics[0].Detail, "token_ttl")
+}
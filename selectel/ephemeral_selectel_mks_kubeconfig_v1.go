@@ -0,0 +1,146 @@
+package selectel
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/selectel/mks-go/pkg/v1/cluster"
+)
+
+// mksKubeconfigV1EphemeralResource returns the same values as the selectel_mks_kubeconfig_v1
+// data source, but Terraform never stores them in the state or plan.
+type mksKubeconfigV1EphemeralResource struct {
+	frameworkEphemeralResource
+}
+
+var _ ephemeral.EphemeralResourceWithConfigure = &mksKubeconfigV1EphemeralResource{}
+
+func newMKSKubeconfigV1EphemeralResource() ephemeral.EphemeralResource {
+	return &mksKubeconfigV1EphemeralResource{}
+}
+
+type mksKubeconfigV1EphemeralModel struct {
+	ProjectID     types.String `tfsdk:"project_id"`
+	ClusterID     types.String `tfsdk:"cluster_id"`
+	Region        types.String `tfsdk:"region"`
+	RawConfig     types.String `tfsdk:"raw_config"`
+	Server        types.String `tfsdk:"server"`
+	ClusterCACert types.String `tfsdk:"cluster_ca_cert"`
+	ClientCert    types.String `tfsdk:"client_cert"`
+	ClientKey     types.String `tfsdk:"client_key"`
+}
+
+func (r *mksKubeconfigV1EphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_mks_kubeconfig_v1"
+}
+
+func (r *mksKubeconfigV1EphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "kubeconfig of a Managed Kubernetes cluster which is not stored in the state",
+		Attributes: map[string]schema.Attribute{
+			"project_id": schema.StringAttribute{
+				Description: "id of a project of the cluster",
+				Optional:    true,
+				Computed:    true,
+			},
+			"cluster_id": schema.StringAttribute{
+				Description: "id of the cluster",
+				Required:    true,
+			},
+			"region": schema.StringAttribute{
+				Description: "region of the cluster",
+				Optional:    true,
+				Computed:    true,
+			},
+			"raw_config": schema.StringAttribute{
+				Description: "raw content of the kubeconfig file",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"server": schema.StringAttribute{
+				Description: "address of the Kube API server",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"cluster_ca_cert": schema.StringAttribute{
+				Description: "CA certificate of the cluster",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"client_cert": schema.StringAttribute{
+				Description: "client certificate for authorization",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"client_key": schema.StringAttribute{
+				Description: "client key for authorization",
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+func (r *mksKubeconfigV1EphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data mksKubeconfigV1EphemeralModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.config == nil {
+		resp.Diagnostics.AddError("The provider is not configured", "")
+
+		return
+	}
+
+	var err error
+	data.ProjectID, err = providerDefaultValue(r.config, "project_id", data.ProjectID)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("project_id"), err.Error(), "")
+	}
+	data.Region, err = providerDefaultValue(r.config, "region", data.Region)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("region"), err.Error(), "")
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	mksClient, err := newMKSClient(r.config, data.ProjectID.ValueString(), data.Region.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(err.Error(), "")
+
+		return
+	}
+
+	clusterID := data.ClusterID.ValueString()
+
+	log.Print(msgGet(objectKubeConfig, clusterID))
+
+	mksCluster, _, err := cluster.Get(ctx, mksClient, clusterID)
+	if err != nil {
+		resp.Diagnostics.Append(frameworkDiagnostics(diagGettingObject(objectCluster, clusterID, err))...)
+
+		return
+	}
+
+	parsedKubeconfig, _, err := cluster.GetParsedKubeconfig(ctx, mksClient, mksCluster.ID)
+	if err != nil {
+		resp.Diagnostics.Append(frameworkDiagnostics(diagGettingObject(objectKubeConfig, clusterID, err))...)
+
+		return
+	}
+
+	data.RawConfig = types.StringValue(parsedKubeconfig.KubeconfigRaw)
+	data.Server = types.StringValue(parsedKubeconfig.Server)
+	data.ClusterCACert = types.StringValue(parsedKubeconfig.ClusterCA)
+	data.ClientCert = types.StringValue(parsedKubeconfig.ClientCert)
+	data.ClientKey = types.StringValue(parsedKubeconfig.ClientKey)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, data)...)
+}
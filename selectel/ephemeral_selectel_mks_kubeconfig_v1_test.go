@@ -0,0 +1,56 @@
+package selectel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/selectel/mks-go/pkg/v1/cluster"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/fakeapi"
+)
+
+func TestMKSKubeconfigV1EphemeralResource(t *testing.T) {
+	ctx := context.Background()
+	server, _, shared := testFakeAPIProviderServer(t)
+
+	mksClient, err := newMKSClient(shared.config, fakeapi.ProjectID, fakeapi.Region)
+	require.NoError(t, err)
+	mksCluster, _, err := cluster.Create(ctx, mksClient, &cluster.CreateOpts{
+		Name:        "cluster",
+		KubeVersion: fakeapi.MKSKubeVersion,
+		Region:      fakeapi.Region,
+	})
+	require.NoError(t, err)
+
+	result, resp := testOpenEphemeralResource(t, server, "selectel_mks_kubeconfig_v1", map[string]tftypes.Value{
+		"cluster_id": tftypes.NewValue(tftypes.String, mksCluster.ID),
+	})
+	require.Empty(t, resp.Diagnostics)
+
+	values := make(map[string]string)
+	for _, attr := range []string{"project_id", "region", "raw_config", "server", "cluster_ca_cert", "client_cert", "client_key"} {
+		var value string
+		require.NoError(t, result[attr].As(&value), attr)
+		values[attr] = value
+	}
+	assert.Equal(t, fakeapi.ProjectID, values["project_id"])
+	assert.Equal(t, fakeapi.Region, values["region"])
+	assert.Contains(t, values["raw_config"], "client-key-data")
+	assert.Equal(t, "https://192.0.2.10:6443", values["server"])
+	assert.NotEmpty(t, values["cluster_ca_cert"])
+	assert.NotEmpty(t, values["client_cert"])
+	assert.NotEmpty(t, values["client_key"])
+}
+
+func TestMKSKubeconfigV1EphemeralResourceNotFound(t *testing.T) {
+	server, _, _ := testFakeAPIProviderServer(t)
+
+	_, resp := testOpenEphemeralResource(t, server, "selectel_mks_kubeconfig_v1", map[string]tftypes.Value{
+		"cluster_id": tftypes.NewValue(tftypes.String, "00000000-0000-4000-8000-000000000000"),
+	})
+	require.Len(t, resp.Diagnostics, 1)
+	assert.Equal(t, tfprotov5.DiagnosticSeverityError, resp.Diagnostics[0].Severity)
+}
@@ -2,6 +2,9 @@ package selectel
 
 import (
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 )
 
 func errParsingPrefixLength(object, id string, err error) string {
@@ -107,3 +110,24 @@ func errParseDatastoreV1Restore(err error) error {
 func errParseDatastoreV1FloatingIPs(err error) error {
 	return fmt.Errorf("got error parsing floating IPs opts: %s", err)
 }
+
+func errProjectQuotasBelowUsage(belowUsage []projectQuotaBelowUsage) error {
+	var (
+		resourceNames []string
+		details       []string
+	)
+	for _, q := range belowUsage {
+		if !slices.Contains(resourceNames, q.resourceName) {
+			resourceNames = append(resourceNames, q.resourceName)
+		}
+		location := q.region
+		if q.zone != "" {
+			location = q.zone
+		}
+		details = append(details, fmt.Sprintf("%s in %s: value %d, used %d", q.resourceName, location, q.value, q.used))
+	}
+	sort.Strings(resourceNames)
+
+	return fmt.Errorf("quotas of %s are lower than the current usage of the project: %s",
+		strings.Join(resourceNames, ", "), strings.Join(details, "; "))
+}
@@ -243,3 +243,20 @@ func TestErrParseDatastoreV1Restore(t *testing.T) {
 
 	assert.Equal(t, expected, actual)
 }
+
+func TestErrProjectQuotasBelowUsage(t *testing.T) {
+	belowUsage := []projectQuotaBelowUsage{
+		{resourceName: "compute_ram", region: "ru-3", zone: "ru-3a", value: 1024, used: 2048},
+		{resourceName: "compute_cores", region: "ru-3", zone: "ru-3b", value: 2, used: 4},
+		{resourceName: "compute_ram", region: "ru-3", zone: "ru-3b", value: 0, used: 512},
+		{resourceName: "image_gigabytes", region: "ru-3", value: 1, used: 5},
+	}
+
+	expected := "quotas of compute_cores, compute_ram, image_gigabytes are lower than the current usage of the project: " +
+		"compute_ram in ru-3a: value 1024, used 2048; compute_cores in ru-3b: value 2, used 4; " +
+		"compute_ram in ru-3b: value 0, used 512; image_gigabytes in ru-3: value 1, used 5"
+
+	actual := errProjectQuotasBelowUsage(belowUsage)
+
+	assert.EqualError(t, actual, expected)
+}
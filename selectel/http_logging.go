@@ -0,0 +1,284 @@
+package selectel
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
+)
+
+const (
+	redactedValue = "REDACTED"
+
+	// maxLoggedBodySize limits the size of request and response bodies written to TRACE logs.
+	maxLoggedBodySize = 64 << 10
+)
+
+// redactedHeaders contains canonical names of headers which values never get into logs.
+var redactedHeaders = map[string]struct{}{
+	"Authorization":   {},
+	"Cookie":          {},
+	"Set-Cookie":      {},
+	"X-Auth-Token":    {},
+	"X-Subject-Token": {},
+}
+
+// redactedFields contains lower-cased names of JSON and YAML fields which values never get into logs:
+// Keystone and datastore credentials, S3 secrets, kubeconfig credentials, registry and metrics tokens.
+var redactedFields = map[string]struct{}{
+	"password":                   {},
+	"redis_password":             {},
+	"secret":                     {},
+	"secret_key":                 {},
+	"token":                      {},
+	"kubeconfig":                 {},
+	"client-certificate-data":    {},
+	"client-key-data":            {},
+	"certificate-authority-data": {},
+	"private_key":                {},
+}
+
+// serviceRedactedFields contains lower-cased names of JSON fields which values are redacted
+// only in bodies of the service type. The "value" field holds a secret in Secrets Manager,
+// while in other APIs it is a quota, a configuration parameter or a record value.
+var serviceRedactedFields = map[string]map[string]struct{}{
+	SecretsManager: {"value": {}},
+}
+
+// parentRedactedFields contains lower-cased names of JSON fields which values are redacted
+// only inside objects with the parent key, such as the value of a DBaaS metrics token.
+var parentRedactedFields = map[string]map[string]struct{}{
+	"prometheus-metrics-token":  {"value": {}},
+	"prometheus-metrics-tokens": {"value": {}},
+}
+
+// requestIDHeaders contains headers with the request ID set by different Selectel APIs.
+var requestIDHeaders = []string{
+	"X-Request-Id",
+	"X-Openstack-Request-Id",
+	"Request-Id",
+}
+
+var yamlFieldRe = buildYAMLFieldRe()
+
+func buildYAMLFieldRe() *regexp.Regexp {
+	fields := make([]string, 0, len(redactedFields))
+	for field := range redactedFields {
+		fields = append(fields, regexp.QuoteMeta(field))
+	}
+	sort.Strings(fields)
+
+	return regexp.MustCompile(`(?mi)^(\s*(?:-\s+)?"?(?:` + strings.Join(fields, "|") + `)"?\s*:[ \t]*)\S.*$`)
+}
+
+// loggingTransport writes method, URL, status, latency and request ID of every request
+// to DEBUG logs and adds redacted headers and bodies in TRACE logs.
+type loggingTransport struct {
+	serviceType string
+	trace       bool
+	logf        func(format string, v ...interface{})
+	next        http.RoundTripper
+}
+
+// newLoggingTransport wraps the transport with wire logging if TF_LOG is DEBUG or TRACE.
+func newLoggingTransport(serviceType string, next http.RoundTripper) http.RoundTripper {
+	if !logging.IsDebugOrHigher() {
+		return next
+	}
+
+	return &loggingTransport{
+		serviceType: serviceType,
+		trace:       strings.EqualFold(logging.LogLevel(), "TRACE"),
+		logf:        log.Printf,
+		next:        next,
+	}
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.trace {
+		body, err := readRequestBody(req)
+		if err != nil {
+			return nil, err
+		}
+		t.logf("[TRACE] %s API request: %s %s\n%s\n%s",
+			t.serviceType, req.Method, req.URL.Redacted(), formatHeaders(req.Header), formatBody(t.serviceType, body))
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	latency := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		t.logf("[DEBUG] %s API request: %s %s failed in %s: %s",
+			t.serviceType, req.Method, req.URL.Redacted(), latency, err)
+
+		return nil, err
+	}
+
+	t.logf("[DEBUG] %s API request: %s %s -> %s in %s (request ID: %s)",
+		t.serviceType, req.Method, req.URL.Redacted(), resp.Status, latency, responseRequestID(resp))
+
+	if t.trace {
+		body, err := readResponseBody(resp)
+		if err != nil {
+			return nil, err
+		}
+		t.logf("[TRACE] %s API response: %s %s -> %s\n%s\n%s",
+			t.serviceType, req.Method, req.URL.Redacted(), resp.Status, formatHeaders(resp.Header), formatBody(t.serviceType, body))
+	}
+
+	return resp, nil
+}
+
+// readRequestBody returns a copy of the request body and leaves the request readable.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+
+		return io.ReadAll(body)
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
+
+// readResponseBody returns a copy of the response body and leaves the response readable.
+func readResponseBody(resp *http.Response) ([]byte, error) {
+	if resp.Body == nil || resp.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
+
+func responseRequestID(resp *http.Response) string {
+	for _, header := range requestIDHeaders {
+		if requestID := resp.Header.Get(header); requestID != "" {
+			return requestID
+		}
+	}
+
+	return "none"
+}
+
+// formatHeaders returns sorted headers with values of the sensitive ones redacted.
+func formatHeaders(headers http.Header) string {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		value := strings.Join(headers[name], ", ")
+		if _, ok := redactedHeaders[http.CanonicalHeaderKey(name)]; ok {
+			value = redactedValue
+		}
+		fmt.Fprintf(&b, "%s: %s\n", name, value)
+	}
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func formatBody(serviceType string, body []byte) string {
+	redacted := redactBody(serviceType, body)
+	if len(redacted) > maxLoggedBodySize {
+		return redacted[:maxLoggedBodySize] + "\n... (truncated)"
+	}
+
+	return redacted
+}
+
+// redactBody returns the body of the service API with values of sensitive fields redacted.
+// JSON bodies are re-encoded with indentation, any other bodies such as
+// kubeconfig YAML are redacted line by line.
+func redactBody(serviceType string, body []byte) string {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return ""
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var data interface{}
+	if err := decoder.Decode(&data); err == nil && !decoder.More() {
+		redacted, err := json.MarshalIndent(redactJSON(serviceRedactedFields[serviceType], "", data), "", "  ")
+		if err == nil {
+			return string(redacted)
+		}
+	}
+
+	return yamlFieldRe.ReplaceAllString(string(body), "${1}"+redactedValue)
+}
+
+// redactJSON replaces all non-empty string values of sensitive fields, of the service
+// and parent fields and the id of Keystone token identity with a placeholder. Numbers and booleans
+// are never secrets and are kept as is.
+func redactJSON(serviceFields map[string]struct{}, parent string, data interface{}) interface{} {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			lowerKey := strings.ToLower(key)
+			_, sensitive := redactedFields[lowerKey]
+			if _, ok := serviceFields[lowerKey]; ok {
+				sensitive = true
+			}
+			if _, ok := parentRedactedFields[parent][lowerKey]; ok {
+				sensitive = true
+			}
+			if parent == "token" && lowerKey == "id" {
+				sensitive = true
+			}
+			if s, ok := value.(string); sensitive && ok && s != "" {
+				v[key] = redactedValue
+				continue
+			}
+			v[key] = redactJSON(serviceFields, lowerKey, value)
+		}
+
+		return v
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redactJSON(serviceFields, parent, value)
+		}
+
+		return v
+	case string:
+		// Some APIs return kubeconfig and other documents as JSON strings.
+		if redacted := yamlFieldRe.ReplaceAllString(v, "${1}"+redactedValue); redacted != v {
+			return redacted
+		}
+
+		return v
+	default:
+		return v
+	}
+}
@@ -0,0 +1,212 @@
+package selectel
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/selectel/go-selvpcclient/v4/selvpcclient/clients"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files in testdata")
+
+func assertGolden(t *testing.T, goldenPath, actual string) {
+	t.Helper()
+
+	if *updateGolden {
+		require.NoError(t, os.WriteFile(goldenPath, []byte(actual), 0o600))
+	}
+
+	expected, err := os.ReadFile(goldenPath)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), actual)
+}
+
+func TestRedactBodyGolden(t *testing.T) {
+	inputs, err := filepath.Glob(filepath.Join("testdata", "http_logging", "*.input"))
+	require.NoError(t, err)
+	require.NotEmpty(t, inputs)
+
+	for _, input := range inputs {
+		input := input
+		name := strings.TrimSuffix(filepath.Base(input), ".input")
+		t.Run(name, func(t *testing.T) {
+			body, err := os.ReadFile(input)
+			require.NoError(t, err)
+
+			redacted := redactBody(goldenServiceType(name), body)
+			assertGolden(t, strings.TrimSuffix(input, ".input")+".golden", strings.TrimSuffix(redacted, "\n")+"\n")
+		})
+	}
+}
+
+// goldenServiceType returns the service type of the golden case by the prefix of its name.
+func goldenServiceType(name string) string {
+	serviceTypes := map[string]string{
+		"craas":          CRaaS,
+		"dbaas":          DBaaS,
+		"domains":        Domains,
+		"keystone":       clients.ResellServiceType,
+		"mks":            MKS,
+		"quotamanager":   clients.ResellServiceType,
+		"resell":         clients.ResellServiceType,
+		"s3":             IAM,
+		"secretsmanager": SecretsManager,
+	}
+	prefix, _, _ := strings.Cut(name, "_")
+
+	return serviceTypes[prefix]
+}
+
+func TestRedactBodyEmpty(t *testing.T) {
+	assert.Equal(t, "", redactBody(DBaaS, nil))
+	assert.Equal(t, "", redactBody(DBaaS, []byte("  \n")))
+}
+
+func TestRedactBodyValue(t *testing.T) {
+	body := []byte(`{"version":{"value":"c2VjcmV0"},"config":{"value":"on"}}`)
+
+	assert.NotContains(t, redactBody(SecretsManager, body), "c2VjcmV0")
+	assert.Contains(t, redactBody(DBaaS, body), `"value": "on"`)
+}
+
+func TestFormatHeaders(t *testing.T) {
+	headers := http.Header{}
+	headers.Set("X-Auth-Token", "token")
+	headers.Set("X-Subject-Token", "token")
+	headers.Set("Authorization", "Bearer token")
+	headers.Set("Content-Type", "application/json")
+
+	expected := "Authorization: REDACTED\n" +
+		"Content-Type: application/json\n" +
+		"X-Auth-Token: REDACTED\n" +
+		"X-Subject-Token: REDACTED"
+	assert.Equal(t, expected, formatHeaders(headers))
+}
+
+func TestLoggingTransportTrace(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"user":{"name":"user","password":"db-password"}}`, string(body))
+		assert.Equal(t, "token", r.Header.Get("X-Auth-Token"))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "req-1")
+		w.Header().Set("Date", "Mon, 02 Jan 2006 15:04:05 GMT")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"user":{"id":"u-1","name":"user","password":"db-password"}}`)
+	}))
+	defer server.Close()
+
+	var logs strings.Builder
+	client := &http.Client{
+		Transport: &loggingTransport{
+			serviceType: DBaaS,
+			trace:       true,
+			logf: func(format string, v ...interface{}) {
+				fmt.Fprintf(&logs, format+"\n", v...)
+			},
+			next: http.DefaultTransport,
+		},
+	}
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/users",
+		strings.NewReader(`{"user":{"name":"user","password":"db-password"}}`))
+	require.NoError(t, err)
+	req.Header.Set("X-Auth-Token", "token")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	// The response body is still readable by the service client.
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "db-password")
+
+	actual := strings.ReplaceAll(logs.String(), server.URL, "http://dbaas.local")
+	actual = regexp.MustCompile(`in \d+(\.\d+)?[µnm]?s`).ReplaceAllString(actual, "in <latency>")
+	actual = regexp.MustCompile(`Content-Length: \d+`).ReplaceAllString(actual, "Content-Length: <length>")
+	assert.NotContains(t, actual, "db-password")
+	assert.NotContains(t, actual, ": token")
+
+	assertGolden(t, filepath.Join("testdata", "http_logging", "transport_trace.golden"), actual)
+}
+
+func TestLoggingTransportDebug(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-Openstack-Request-Id", "req-2")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	var logs []string
+	client := &http.Client{
+		Transport: &loggingTransport{
+			serviceType: MKS,
+			logf: func(format string, v ...interface{}) {
+				logs = append(logs, fmt.Sprintf(format, v...))
+			},
+			next: http.DefaultTransport,
+		},
+	}
+
+	resp, err := client.Get(server.URL + "/clusters/4d5e7d32")
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	require.Len(t, logs, 1)
+	assert.Regexp(t, `^\[DEBUG\] managed-kubernetes API request: GET http://127\.0\.0\.1:\d+/clusters/4d5e7d32 -> 404 Not Found in \S+ \(request ID: req-2\)$`, logs[0])
+}
+
+func TestLoggingTransportError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	server.Close()
+
+	var logs []string
+	client := &http.Client{
+		Transport: &loggingTransport{
+			serviceType: IAM,
+			logf: func(format string, v ...interface{}) {
+				logs = append(logs, fmt.Sprintf(format, v...))
+			},
+			next: http.DefaultTransport,
+		},
+	}
+
+	_, err := client.Get(server.URL + "/users")
+	require.Error(t, err)
+
+	require.Len(t, logs, 1)
+	assert.Contains(t, logs[0], "[DEBUG] iam API request: GET "+server.URL+"/users failed in")
+}
+
+func TestNewLoggingTransportDisabled(t *testing.T) {
+	t.Setenv("TF_LOG", "")
+
+	assert.Same(t, http.DefaultTransport, newLoggingTransport(DBaaS, http.DefaultTransport))
+}
+
+func TestNewLoggingTransportTrace(t *testing.T) {
+	t.Setenv("TF_LOG", "TRACE")
+
+	transport, ok := newLoggingTransport(DBaaS, http.DefaultTransport).(*loggingTransport)
+	require.True(t, ok)
+	assert.True(t, transport.trace)
+
+	t.Setenv("TF_LOG", "DEBUG")
+
+	transport, ok = newLoggingTransport(DBaaS, http.DefaultTransport).(*loggingTransport)
+	require.True(t, ok)
+	assert.False(t, transport.trace)
+}
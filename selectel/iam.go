@@ -25,15 +25,24 @@ func getIAMClient(meta interface{}) (*iam.Client, diag.Diagnostics) {
 		return nil, diag.FromErr(fmt.Errorf("can't get selvpc client for iam: %w", err))
 	}
 
-	apiURL, err := getEndpointForIAM(selvpcClient, config.AuthRegion)
+	apiURL, ok := config.endpointOverride(IAM)
+	if !ok {
+		apiURL, err = getEndpointForIAM(selvpcClient, config.AuthRegion)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+	}
+	token, err := config.GetXAuthToken()
 	if err != nil {
-		return nil, diag.FromErr(err)
+		return nil, diag.FromErr(fmt.Errorf("can't get token for iam: %w", err))
 	}
+
 	iamClient, err := iam.New(
 		iam.WithAuthOpts(&iam.AuthOpts{
-			KeystoneToken: selvpcClient.GetXAuthToken(),
+			KeystoneToken: token,
 		}),
 		iam.WithAPIUrl(apiURL),
+		iam.WithCustomHTTPClient(config.newHTTPClient(IAM, "")),
 	)
 	if err != nil {
 		return nil, diag.FromErr(fmt.Errorf("can't create iam client: %w", err))
@@ -13,9 +13,9 @@ func TestAccCRaaSRegistryV1ImportBasic(t *testing.T) {
 	registryName := acctest.RandomWithPrefix("tf-acc-reg")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCRaaSRegistryV1Basic(projectName, registryName),
@@ -14,9 +14,9 @@ func TestAccDBaaSPostgreSQLDatastoreV1ImportBasic(t *testing.T) {
 	nodeCount := 1
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDBaaSPostgreSQLDatastoreV1Basic(projectName, datastoreName, nodeCount),
@@ -13,9 +13,9 @@ func TestAccDBaaSPrometheusMetricTokenV1ImportBasic(t *testing.T) {
 	tokenName := acctest.RandomWithPrefix("tf-acc-token")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDBaaSPrometheusMetricTokenV1Basic(projectName, tokenName),
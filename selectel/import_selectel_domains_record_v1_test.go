@@ -14,9 +14,9 @@ func TestAccDomainsRecordV1ImportBasic(t *testing.T) {
 	testRecordName := fmt.Sprintf("a.%s", testDomainName)
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckDomainsV1DomainDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckDomainsV1DomainDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDomainsRecordV1BasicSingle(testDomainName, testRecordName),
@@ -15,9 +15,9 @@ func TestAccDomainsZoneV2ImportBasic(t *testing.T) {
 	fullResourceName := fmt.Sprintf("selectel_domains_zone_v2.%[1]s", resourceZoneName)
 	testZoneName := fmt.Sprintf("%s.xyz.", acctest.RandomWithPrefix("tf-acc"))
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheckWithProjectID(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckDomainsV2ZoneDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheckWithProjectID(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckDomainsV2ZoneDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDomainsZoneV2WithoutProjectBasic(projectID, resourceZoneName, testZoneName),
@@ -13,9 +13,9 @@ func TestAccIAMV1ServiceUserImportBasic(t *testing.T) {
 	serviceUserPassword := "A" + acctest.RandString(8) + "1"
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckIAMV1ServiceUserDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckIAMV1ServiceUserDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccIAMV1ServiceUserBasic(serviceUserName, serviceUserPassword),
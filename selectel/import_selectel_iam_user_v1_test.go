@@ -12,9 +12,9 @@ func TestAccIAMV1UserImportBasic(t *testing.T) {
 	userEmail := acctest.RandomWithPrefix("tf-acc") + "@example.com"
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckIAMV1UserDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckIAMV1UserDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccIAMV1UserBasic(userEmail),
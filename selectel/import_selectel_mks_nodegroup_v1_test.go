@@ -4,21 +4,22 @@ import (
 	"testing"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
 func TestAccMKSNodegroupV1ImportBasic(t *testing.T) {
+	recording := testAccStartRecording(t)
+
 	resourceName := "selectel_mks_nodegroup_v1.nodegroup_tf_acc_test_1"
-	projectName := acctest.RandomWithPrefix("tf-acc")
-	clusterName := acctest.RandomWithPrefix("tf-acc-cl")
-	kubeVersion := testAccMKSClusterV1GetDefaultKubeVersion(t)
-	maintenanceWindowStart := testAccMKSClusterV1GetMaintenanceWindowStart(12 * time.Hour)
+	projectName := recording.RandomWithPrefix("tf-acc")
+	clusterName := recording.RandomWithPrefix("tf-acc-cl")
+	kubeVersion := testAccMKSClusterV1GetDefaultKubeVersion(t, recording)
+	maintenanceWindowStart := testAccMKSClusterV1GetMaintenanceWindowStart(recording, 12*time.Hour)
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccMKSNodegroupV1Basic(projectName, clusterName, kubeVersion, maintenanceWindowStart),
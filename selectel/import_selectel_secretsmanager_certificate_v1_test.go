@@ -20,9 +20,9 @@ func TestAccSecretsManagerCertificateV1ImportBasic(t *testing.T) {
 	certificateName := acctest.RandomWithPrefix("tf-acc")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckSecretsManagerV1CertificateDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckSecretsManagerV1CertificateDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccSecretsManagerCertificateV1WithoutProjectBasic(certificateName, projectID),
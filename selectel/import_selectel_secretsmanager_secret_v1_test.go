@@ -23,9 +23,9 @@ func TestAccSecretsManagerSecretV1ImportBasic(t *testing.T) {
 	secretDescription := acctest.RandomWithPrefix("tf-acc")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckSecretsManagerV1SecretDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckSecretsManagerV1SecretDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccSecretsManagerSecretV1WithoutProjectBasic(secretKey, secretDescription, secretValue, projectID),
@@ -0,0 +1,30 @@
+package selectel
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccVPCV2CrossRegionSubnetImportBasic(t *testing.T) {
+	resourceName := "selectel_vpc_crossregion_subnet_v2.crossregion_subnet_tf_acc_test_1"
+	projectName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2CrossRegionSubnetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVPCV2CrossRegionSubnetBasic(projectName),
+				Check:  testAccCheckSelectelImportEnv(resourceName),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
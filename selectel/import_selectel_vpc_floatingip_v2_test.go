@@ -12,9 +12,9 @@ func TestAccVPCV2FloatingIPImportBasic(t *testing.T) {
 	projectName := acctest.RandomWithPrefix("tf-acc")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2FloatingIPDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2FloatingIPDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccVPCV2FloatingIPBasic(projectName),
@@ -0,0 +1,43 @@
+/*
+Package crossregionsubnets provides the ability to retrieve and manage cross-region
+subnets through the Resell v2 API. The subnets connect networks of the same project
+in several regions with a single VLAN.
+
+The package follows the layout of the resell/v2 packages of go-selvpcclient,
+which doesn't support cross-region subnets.
+
+Example of creating a cross-region subnet
+
+	createOpts := crossregionsubnets.CrossRegionSubnetOpts{
+	  CrossRegionSubnets: []crossregionsubnets.CrossRegionSubnetOpt{
+	    {
+	      Quantity: 1,
+	      Regions: []crossregionsubnets.CrossRegionOpt{
+	        {Region: "ru-1"},
+	        {Region: "ru-3"},
+	      },
+	      CIDR: "192.168.200.0/24",
+	    },
+	  },
+	}
+	newSubnets, _, err := crossregionsubnets.Create(client, projectID, createOpts)
+	if err != nil {
+	  log.Fatal(err)
+	}
+
+Example of getting a single cross-region subnet referenced by its id
+
+	subnet, _, err := crossregionsubnets.Get(client, subnetID)
+	if err != nil {
+	  log.Fatal(err)
+	}
+	fmt.Println(subnet)
+
+Example of deleting a single cross-region subnet
+
+	_, err = crossregionsubnets.Delete(client, subnetID)
+	if err != nil {
+	  log.Fatal(err)
+	}
+*/
+package crossregionsubnets
@@ -0,0 +1,94 @@
+package crossregionsubnets
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/selectel/go-selvpcclient/v4/selvpcclient"
+	clientservices "github.com/selectel/go-selvpcclient/v4/selvpcclient/clients/services"
+)
+
+const resourceURL = "cross_region_subnets"
+
+// Get returns a single cross-region subnet by its id.
+func Get(client *selvpcclient.Client, id string) (*CrossRegionSubnet, *clientservices.ResponseResult, error) {
+	endpoint, err := client.Resell.GetEndpoint()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get endpoint, err: %w", err)
+	}
+
+	url := strings.Join([]string{endpoint, resourceURL, id}, "/")
+	responseResult, err := client.Resell.Requests.Do(http.MethodGet, url, &clientservices.RequestOptions{
+		OkCodes: []int{http.StatusOK},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if responseResult.Err != nil {
+		return nil, responseResult, responseResult.Err
+	}
+
+	// Extract a cross-region subnet from the response body.
+	var result struct {
+		CrossRegionSubnet *CrossRegionSubnet `json:"cross_region_subnet"`
+	}
+	err = responseResult.ExtractResult(&result)
+	if err != nil {
+		return nil, responseResult, err
+	}
+
+	return result.CrossRegionSubnet, responseResult, nil
+}
+
+// Create requests a creation of the cross-region subnets in the specified project.
+func Create(client *selvpcclient.Client, projectID string, createOpts CrossRegionSubnetOpts) ([]*CrossRegionSubnet, *clientservices.ResponseResult, error) {
+	endpoint, err := client.Resell.GetEndpoint()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get endpoint, err: %w", err)
+	}
+
+	url := strings.Join([]string{endpoint, resourceURL, "projects", projectID}, "/")
+	responseResult, err := client.Resell.Requests.Do(http.MethodPost, url, &clientservices.RequestOptions{
+		JSONBody: &createOpts,
+		OkCodes:  []int{http.StatusOK},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if responseResult.Err != nil {
+		return nil, responseResult, responseResult.Err
+	}
+
+	// Extract cross-region subnets from the response body.
+	var result struct {
+		CrossRegionSubnets []*CrossRegionSubnet `json:"cross_region_subnets"`
+	}
+	err = responseResult.ExtractResult(&result)
+	if err != nil {
+		return nil, responseResult, err
+	}
+
+	return result.CrossRegionSubnets, responseResult, nil
+}
+
+// Delete deletes a single cross-region subnet by its id.
+func Delete(client *selvpcclient.Client, id string) (*clientservices.ResponseResult, error) {
+	endpoint, err := client.Resell.GetEndpoint()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get endpoint, err: %w", err)
+	}
+
+	url := strings.Join([]string{endpoint, resourceURL, id}, "/")
+	responseResult, err := client.Resell.Requests.Do(http.MethodDelete, url, &clientservices.RequestOptions{
+		OkCodes: []int{http.StatusNoContent},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if responseResult.Err != nil {
+		return responseResult, responseResult.Err
+	}
+
+	return responseResult, nil
+}
@@ -0,0 +1,25 @@
+package crossregionsubnets
+
+// CrossRegionSubnetOpts represents options for the cross-region subnets Create request.
+type CrossRegionSubnetOpts struct {
+	// CrossRegionSubnets represents options for all cross-region subnets.
+	CrossRegionSubnets []CrossRegionSubnetOpt `json:"cross_region_subnets"`
+}
+
+// CrossRegionSubnetOpt represents options for the single cross-region subnet.
+type CrossRegionSubnetOpt struct {
+	// Quantity represents how many cross-region subnets do we need to create.
+	Quantity int `json:"quantity"`
+
+	// Regions represents the regions the cross-region subnet connects.
+	Regions []CrossRegionOpt `json:"regions"`
+
+	// CIDR represents a cross-region subnet prefix in CIDR notation.
+	CIDR string `json:"cidr"`
+}
+
+// CrossRegionOpt represents a single region of the cross-region subnet.
+type CrossRegionOpt struct {
+	// Region represents a region of where the regional subnet should reside.
+	Region string `json:"region"`
+}
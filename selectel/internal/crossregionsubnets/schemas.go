@@ -0,0 +1,27 @@
+package crossregionsubnets
+
+import (
+	"github.com/selectel/go-selvpcclient/v4/selvpcclient/resell/v2/servers"
+	"github.com/selectel/go-selvpcclient/v4/selvpcclient/resell/v2/subnets"
+)
+
+// CrossRegionSubnet represents a single Resell cross-region subnet.
+type CrossRegionSubnet struct {
+	// ID is a unique id of a cross-region subnet.
+	ID int `json:"id"`
+
+	// Status shows if the cross-region subnet is used.
+	Status string `json:"status"`
+
+	// Servers contains info about servers to which the cross-region subnet is associated to.
+	Servers []servers.Server `json:"servers"`
+
+	// CIDR is a cross-region subnet prefix in CIDR notation.
+	CIDR string `json:"cidr"`
+
+	// VLANID represents id of the VLAN that connects the regional subnets.
+	VLANID int `json:"vlan_id"`
+
+	// Subnets contains the regional subnets of the cross-region subnet.
+	Subnets []subnets.Subnet `json:"subnets"`
+}
@@ -0,0 +1,134 @@
+package fakeapi
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+)
+
+const (
+	KindCRaaSRegistries = "craas/registries"
+	KindCRaaSTokens     = "craas/tokens"
+)
+
+// craasTokenTTLs are the lifetimes of tokens accepted by the CRaaS API.
+var craasTokenTTLs = map[string]time.Duration{
+	"12h": 12 * time.Hour,
+	"1y":  365 * 24 * time.Hour,
+}
+
+// craasRegistryNameRe is the format of registry names accepted by the CRaaS API.
+var craasRegistryNameRe = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,61}[a-z0-9]$`)
+
+func (s *Server) registerCRaaS(mux *http.ServeMux) {
+	handle := func(pattern string, handler http.HandlerFunc) {
+		mux.HandleFunc(pattern, s.authenticated(writeCRaaSError, handler))
+	}
+
+	handle("GET /craas/v1/registries", s.listCRaaSRegistries)
+	handle("POST /craas/v1/registries", s.createCRaaSRegistry)
+	handle("GET /craas/v1/registries/{id}", s.getCRaaSRegistry)
+	handle("DELETE /craas/v1/registries/{id}", s.deleteCRaaSRegistry)
+	handle("POST /craas/v1/token", s.createCRaaSToken)
+	handle("GET /craas/v1/token/{id}", s.getCRaaSToken)
+	handle("DELETE /craas/v1/token/{id}", s.revokeCRaaSToken)
+}
+
+// listCRaaSRegistries returns registries as a bare array, as the CRaaS API does.
+func (s *Server) listCRaaSRegistries(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, s.list(KindCRaaSRegistries, nil))
+}
+
+func (s *Server) createCRaaSRegistry(w http.ResponseWriter, r *http.Request) {
+	opts, err := readJSON(r, "")
+	if err != nil {
+		writeCRaaSError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+	name, _ := opts["name"].(string)
+	if !craasRegistryNameRe.MatchString(name) {
+		writeCRaaSError(w, http.StatusBadRequest, fmt.Sprintf("invalid value of the field 'name': %q", name))
+
+		return
+	}
+	if len(s.list(KindCRaaSRegistries, func(registry map[string]interface{}) bool {
+		return registry["name"] == name
+	})) > 0 {
+		writeCRaaSError(w, http.StatusConflict, fmt.Sprintf("registry %s already exists", name))
+
+		return
+	}
+
+	registry := s.create(KindCRaaSRegistries, map[string]interface{}{
+		"name":      name,
+		"createdAt": now(),
+		"size":      0,
+		"sizeLimit": 0,
+		"used":      0,
+	}, "CREATING", "ACTIVE")
+	writeJSON(w, http.StatusCreated, registry)
+}
+
+func (s *Server) getCRaaSRegistry(w http.ResponseWriter, r *http.Request) {
+	registry, ok := s.get(KindCRaaSRegistries, r.PathValue("id"))
+	if !ok {
+		writeCRaaSError(w, http.StatusNotFound, "registry not found")
+
+		return
+	}
+	writeJSON(w, http.StatusOK, registry)
+}
+
+func (s *Server) deleteCRaaSRegistry(w http.ResponseWriter, r *http.Request) {
+	if !s.delete(KindCRaaSRegistries, r.PathValue("id")) {
+		writeCRaaSError(w, http.StatusNotFound, "registry not found")
+
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// createCRaaSToken issues a token which is also its ID, as the CRaaS API does.
+func (s *Server) createCRaaSToken(w http.ResponseWriter, r *http.Request) {
+	ttl, ok := craasTokenTTLs[r.URL.Query().Get("ttl")]
+	if !ok {
+		writeCRaaSError(w, http.StatusBadRequest, fmt.Sprintf("invalid token ttl: %q", r.URL.Query().Get("ttl")))
+
+		return
+	}
+
+	token := s.create(KindCRaaSTokens, map[string]interface{}{
+		"expireAt": time.Now().Add(ttl).Unix(),
+		"expireIn": int64(ttl.Seconds()),
+	}, "", "")
+	token["token"] = token["id"]
+	writeJSON(w, http.StatusOK, token)
+}
+
+func (s *Server) getCRaaSToken(w http.ResponseWriter, r *http.Request) {
+	token, ok := s.get(KindCRaaSTokens, r.PathValue("id"))
+	if !ok {
+		writeCRaaSError(w, http.StatusNotFound, "token not found")
+
+		return
+	}
+	token["token"] = token["id"]
+	writeJSON(w, http.StatusOK, token)
+}
+
+func (s *Server) revokeCRaaSToken(w http.ResponseWriter, r *http.Request) {
+	if !s.delete(KindCRaaSTokens, r.PathValue("id")) {
+		writeCRaaSError(w, http.StatusNotFound, "token not found")
+
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeCRaaSError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"error": map[string]interface{}{"message": message},
+	})
+}
@@ -0,0 +1,426 @@
+package fakeapi
+
+import (
+	"fmt"
+	"net/http"
+)
+
+const (
+	KindDBaaSDatastores = "dbaas/datastores"
+	KindDBaaSUsers      = "dbaas/users"
+
+	// DBaaSPostgreSQLTypeID, DBaaSMySQLTypeID and DBaaSRedisTypeID are IDs of the datastore types
+	// of the fake DBaaS API.
+	DBaaSPostgreSQLTypeID = "20d7bcf4-f8d6-4bf6-b8f6-46cb440a87f4"
+	DBaaSMySQLTypeID      = "4ff29e2b-4c88-4cb5-9a4b-6d6f4bd6fb1c"
+	DBaaSRedisTypeID      = "9c1b7a57-0bb5-4f4b-8a86-0e1b4f1bb0a8"
+
+	// DBaaSFlavorID is the ID of the flavor available for all datastore types.
+	DBaaSFlavorID = "3e9e5b4a-7c0c-4f7e-a3c3-b1f8e5b2c9d1"
+)
+
+var dbaasDatastoreTypes = []map[string]interface{}{
+	{"id": DBaaSPostgreSQLTypeID, "engine": "postgresql", "version": "16"},
+	{"id": DBaaSMySQLTypeID, "engine": "mysql_native", "version": "8"},
+	{"id": DBaaSRedisTypeID, "engine": "redis", "version": "7"},
+}
+
+var dbaasFlavors = []map[string]interface{}{
+	{
+		"id":                 DBaaSFlavorID,
+		"name":               "2c-4r-32d",
+		"description":        "2 vCPU, 4 GB RAM, 32 GB disk",
+		"fl_size":            "standard",
+		"vcpus":              2,
+		"ram":                4096,
+		"disk":               32,
+		"datastore_type_ids": []string{DBaaSPostgreSQLTypeID, DBaaSMySQLTypeID, DBaaSRedisTypeID},
+	},
+}
+
+func (s *Server) registerDBaaS(mux *http.ServeMux) {
+	handle := func(pattern string, handler http.HandlerFunc) {
+		mux.HandleFunc(pattern, s.authenticated(writeDBaaSError, handler))
+	}
+
+	handle("GET /dbaas/{region}/v1/datastore-types", s.listDBaaSDatastoreTypes)
+	handle("GET /dbaas/{region}/v1/datastore-types/{id}", s.getDBaaSDatastoreType)
+	handle("GET /dbaas/{region}/v1/flavors", s.listDBaaSFlavors)
+	handle("GET /dbaas/{region}/v1/flavors/{id}", s.getDBaaSFlavor)
+
+	handle("GET /dbaas/{region}/v1/datastores", s.listDBaaSDatastores)
+	handle("POST /dbaas/{region}/v1/datastores", s.createDBaaSDatastore)
+	handle("GET /dbaas/{region}/v1/datastores/{id}", s.getDBaaSDatastore)
+	handle("PUT /dbaas/{region}/v1/datastores/{id}", s.updateDBaaSDatastore("datastore", nil))
+	handle("DELETE /dbaas/{region}/v1/datastores/{id}", s.deleteDBaaSDatastore)
+	handle("POST /dbaas/{region}/v1/datastores/{id}/resize", s.updateDBaaSDatastore("resize", resizeDBaaSDatastore))
+	handle("PUT /dbaas/{region}/v1/datastores/{id}/pooler", s.updateDBaaSDatastore("pooler", nestDBaaSPatch("pooler")))
+	handle("PUT /dbaas/{region}/v1/datastores/{id}/firewall", s.updateDBaaSDatastore("firewall", firewallDBaaSDatastore))
+	handle("PUT /dbaas/{region}/v1/datastores/{id}/config", s.updateDBaaSDatastoreConfig)
+	handle("PUT /dbaas/{region}/v1/datastores/{id}/backups", s.updateDBaaSDatastore("backups", nil))
+	handle("PUT /dbaas/{region}/v1/datastores/{id}/password", s.updateDBaaSDatastore("password", dropDBaaSPatch))
+
+	handle("GET /dbaas/{region}/v1/users", s.listDBaaSUsers)
+	handle("POST /dbaas/{region}/v1/users", s.createDBaaSUser)
+	handle("GET /dbaas/{region}/v1/users/{id}", s.getDBaaSUser)
+	handle("PUT /dbaas/{region}/v1/users/{id}", s.updateDBaaSUser)
+	handle("DELETE /dbaas/{region}/v1/users/{id}", s.deleteDBaaSUser)
+}
+
+func (s *Server) listDBaaSDatastoreTypes(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{"datastore-types": dbaasDatastoreTypes})
+}
+
+func (s *Server) getDBaaSDatastoreType(w http.ResponseWriter, r *http.Request) {
+	datastoreType := findByID(dbaasDatastoreTypes, r.PathValue("id"))
+	if datastoreType == nil {
+		writeDBaaSError(w, http.StatusNotFound, "datastore type not found")
+
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"datastore-type": datastoreType})
+}
+
+func (s *Server) listDBaaSFlavors(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{"flavors": dbaasFlavors})
+}
+
+func (s *Server) getDBaaSFlavor(w http.ResponseWriter, r *http.Request) {
+	flavor := findByID(dbaasFlavors, r.PathValue("id"))
+	if flavor == nil {
+		writeDBaaSError(w, http.StatusNotFound, "flavor not found")
+
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"flavor": flavor})
+}
+
+func (s *Server) listDBaaSDatastores(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{"datastores": s.list(KindDBaaSDatastores, nil)})
+}
+
+func (s *Server) createDBaaSDatastore(w http.ResponseWriter, r *http.Request) {
+	opts, err := readJSON(r, "datastore")
+	if err != nil {
+		writeDBaaSError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	typeID, _ := opts["type_id"].(string)
+	datastoreType := findByID(dbaasDatastoreTypes, typeID)
+	if datastoreType == nil {
+		writeDBaaSError(w, http.StatusBadRequest, fmt.Sprintf("type_id: datastore type '%s' not found", typeID))
+
+		return
+	}
+	if err := setDBaaSFlavor(opts); err != nil {
+		writeDBaaSError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	datastore := map[string]interface{}{
+		"created_at":            now(),
+		"updated_at":            now(),
+		"project_id":            ProjectID,
+		"enabled":               true,
+		"allow_restore":         false,
+		"is_maintenance":        false,
+		"is_protected":          false,
+		"backup_retention_days": 7,
+		"firewall":              []interface{}{},
+		"config":                map[string]interface{}{},
+		"pooler":                map[string]interface{}{},
+	}
+	if datastoreType["engine"] == "postgresql" {
+		datastore["pooler"] = map[string]interface{}{"mode": "transaction", "size": 30}
+	}
+	delete(opts, "restore")
+	delete(opts, "floating_ips")
+	delete(opts, "redis_password")
+	merge(datastore, opts)
+
+	created := s.create(KindDBaaSDatastores, datastore, "PENDING_CREATE", "ACTIVE")
+	created, _ = s.update(KindDBaaSDatastores, created["id"].(string), dbaasDatastoreTopology(created), "")
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"datastore": created})
+}
+
+func (s *Server) getDBaaSDatastore(w http.ResponseWriter, r *http.Request) {
+	datastore, ok := s.get(KindDBaaSDatastores, r.PathValue("id"))
+	if !ok {
+		writeDBaaSError(w, http.StatusNotFound, "datastore not found")
+
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"datastore": datastore})
+}
+
+// updateDBaaSDatastore returns the handler of the updates of datastores with the body under the key.
+// The patch function converts the body to the patch of the datastore.
+func (s *Server) updateDBaaSDatastore(key string, patch func(opts map[string]interface{}) (map[string]interface{}, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		opts, err := readJSON(r, key)
+		if err != nil {
+			writeDBaaSError(w, http.StatusBadRequest, err.Error())
+
+			return
+		}
+		if patch != nil {
+			if opts, err = patch(opts); err != nil {
+				writeDBaaSError(w, http.StatusBadRequest, err.Error())
+
+				return
+			}
+		}
+
+		pendingStatus := "PENDING_UPDATE"
+		if key == "resize" {
+			pendingStatus = "RESIZING"
+		}
+		opts["updated_at"] = now()
+		datastore, ok := s.update(KindDBaaSDatastores, r.PathValue("id"), opts, pendingStatus)
+		if !ok {
+			writeDBaaSError(w, http.StatusNotFound, "datastore not found")
+
+			return
+		}
+		if key == "resize" {
+			datastore, _ = s.update(KindDBaaSDatastores, r.PathValue("id"), dbaasDatastoreTopology(datastore), "")
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"datastore": datastore})
+	}
+}
+
+// updateDBaaSDatastoreConfig replaces the parameters of the configuration. Parameters
+// with null values are reset to their defaults, so they are removed.
+func (s *Server) updateDBaaSDatastoreConfig(w http.ResponseWriter, r *http.Request) {
+	opts, err := readJSON(r, "")
+	if err != nil {
+		writeDBaaSError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+	config, _ := opts["config"].(map[string]interface{})
+
+	datastore, ok := s.modify(KindDBaaSDatastores, r.PathValue("id"), func(data map[string]interface{}) {
+		current, _ := data["config"].(map[string]interface{})
+		if current == nil {
+			current = map[string]interface{}{}
+		}
+		for key, value := range config {
+			if value == nil {
+				delete(current, key)
+			} else {
+				current[key] = value
+			}
+		}
+		data["config"] = current
+		data["updated_at"] = now()
+	}, "PENDING_UPDATE")
+	if !ok {
+		writeDBaaSError(w, http.StatusNotFound, "datastore not found")
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"datastore": datastore})
+}
+
+func (s *Server) deleteDBaaSDatastore(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if !s.delete(KindDBaaSDatastores, id) {
+		writeDBaaSError(w, http.StatusNotFound, "datastore not found")
+
+		return
+	}
+	for _, user := range s.list(KindDBaaSUsers, func(user map[string]interface{}) bool {
+		return user["datastore_id"] == id
+	}) {
+		s.delete(KindDBaaSUsers, user["id"].(string))
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listDBaaSUsers(w http.ResponseWriter, r *http.Request) {
+	datastoreID := r.URL.Query().Get("datastore_id")
+	users := s.list(KindDBaaSUsers, func(user map[string]interface{}) bool {
+		return datastoreID == "" || user["datastore_id"] == datastoreID
+	})
+	writeJSON(w, http.StatusOK, map[string]interface{}{"users": users})
+}
+
+func (s *Server) createDBaaSUser(w http.ResponseWriter, r *http.Request) {
+	opts, err := readJSON(r, "user")
+	if err != nil {
+		writeDBaaSError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+	datastoreID, _ := opts["datastore_id"].(string)
+	if !s.exists(KindDBaaSDatastores, datastoreID) {
+		writeDBaaSError(w, http.StatusBadRequest, fmt.Sprintf("datastore_id: datastore '%s' not found", datastoreID))
+
+		return
+	}
+	name, _ := opts["name"].(string)
+	for _, user := range s.list(KindDBaaSUsers, nil) {
+		if user["datastore_id"] == datastoreID && user["name"] == name {
+			writeDBaaSError(w, http.StatusConflict, fmt.Sprintf("user '%s' already exists", name))
+
+			return
+		}
+	}
+
+	user := s.create(KindDBaaSUsers, map[string]interface{}{
+		"created_at":   now(),
+		"updated_at":   now(),
+		"project_id":   ProjectID,
+		"datastore_id": datastoreID,
+		"name":         name,
+	}, "PENDING_CREATE", "ACTIVE")
+	writeJSON(w, http.StatusOK, map[string]interface{}{"user": user})
+}
+
+func (s *Server) getDBaaSUser(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.get(KindDBaaSUsers, r.PathValue("id"))
+	if !ok {
+		writeDBaaSError(w, http.StatusNotFound, "user not found")
+
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"user": user})
+}
+
+func (s *Server) updateDBaaSUser(w http.ResponseWriter, r *http.Request) {
+	if _, err := readJSON(r, "user"); err != nil {
+		writeDBaaSError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+	user, ok := s.update(KindDBaaSUsers, r.PathValue("id"), map[string]interface{}{"updated_at": now()}, "PENDING_UPDATE")
+	if !ok {
+		writeDBaaSError(w, http.StatusNotFound, "user not found")
+
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"user": user})
+}
+
+func (s *Server) deleteDBaaSUser(w http.ResponseWriter, r *http.Request) {
+	if !s.delete(KindDBaaSUsers, r.PathValue("id")) {
+		writeDBaaSError(w, http.StatusNotFound, "user not found")
+
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// setDBaaSFlavor fills the flavor and the flavor_id of the datastore from one another.
+func setDBaaSFlavor(opts map[string]interface{}) error {
+	if flavorID, ok := opts["flavor_id"].(string); ok && flavorID != "" {
+		flavor := findByID(dbaasFlavors, flavorID)
+		if flavor == nil {
+			return fmt.Errorf("flavor_id: flavor '%s' not found", flavorID)
+		}
+		opts["flavor"] = map[string]interface{}{"vcpus": flavor["vcpus"], "ram": flavor["ram"], "disk": flavor["disk"]}
+
+		return nil
+	}
+
+	flavor, ok := opts["flavor"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	opts["flavor_id"] = ""
+	for _, f := range dbaasFlavors {
+		if fmt.Sprint(f["vcpus"]) == fmt.Sprint(flavor["vcpus"]) && fmt.Sprint(f["ram"]) == fmt.Sprint(flavor["ram"]) &&
+			fmt.Sprint(f["disk"]) == fmt.Sprint(flavor["disk"]) {
+			opts["flavor_id"] = f["id"]
+		}
+	}
+
+	return nil
+}
+
+// dbaasDatastoreTopology returns the instances and connections of the datastore with its node count.
+func dbaasDatastoreTopology(datastore map[string]interface{}) map[string]interface{} {
+	id, _ := datastore["id"].(string)
+	nodeCount := 1
+	if v, ok := datastore["node_count"].(float64); ok && v > 0 {
+		nodeCount = int(v)
+	}
+
+	instances := make([]interface{}, 0, nodeCount)
+	for i := 0; i < nodeCount; i++ {
+		role := "REPLICA"
+		if i == 0 {
+			role = "MASTER"
+		}
+		instances = append(instances, map[string]interface{}{
+			"id":       fmt.Sprintf("%s-%d", id, i),
+			"ip":       fmt.Sprintf("10.0.0.%d", i+10),
+			"role":     role,
+			"status":   "ACTIVE",
+			"hostname": fmt.Sprintf("node-%d.%s", i, id),
+		})
+	}
+
+	return map[string]interface{}{
+		"instances": instances,
+		"connection": map[string]interface{}{
+			"master": fmt.Sprintf("master.%s.c.dbaas.example.com", id),
+		},
+	}
+}
+
+func resizeDBaaSDatastore(opts map[string]interface{}) (map[string]interface{}, error) {
+	if err := setDBaaSFlavor(opts); err != nil {
+		return nil, err
+	}
+
+	return opts, nil
+}
+
+func firewallDBaaSDatastore(opts map[string]interface{}) (map[string]interface{}, error) {
+	ips, _ := opts["ips"].([]interface{})
+	firewall := make([]interface{}, 0, len(ips))
+	for _, ip := range ips {
+		firewall = append(firewall, map[string]interface{}{"ip": ip})
+	}
+
+	return map[string]interface{}{"firewall": firewall}, nil
+}
+
+// nestDBaaSPatch puts the options under the key of the datastore.
+func nestDBaaSPatch(key string) func(opts map[string]interface{}) (map[string]interface{}, error) {
+	return func(opts map[string]interface{}) (map[string]interface{}, error) {
+		return map[string]interface{}{key: opts}, nil
+	}
+}
+
+// dropDBaaSPatch accepts the options which are not stored, for example passwords.
+func dropDBaaSPatch(map[string]interface{}) (map[string]interface{}, error) {
+	return map[string]interface{}{}, nil
+}
+
+func findByID(items []map[string]interface{}, id string) map[string]interface{} {
+	for _, item := range items {
+		if item["id"] == id {
+			return item
+		}
+	}
+
+	return nil
+}
+
+func writeDBaaSError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    status,
+			"title":   http.StatusText(status),
+			"message": message,
+		},
+	})
+}
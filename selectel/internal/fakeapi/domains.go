@@ -0,0 +1,276 @@
+package fakeapi
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	KindDomainsZones  = "domains/zones"
+	KindDomainsRRSets = "domains/rrsets"
+)
+
+func (s *Server) registerDomains(mux *http.ServeMux) {
+	handle := func(pattern string, handler http.HandlerFunc) {
+		mux.HandleFunc(pattern, s.authenticated(writeDomainsError, handler))
+	}
+
+	handle("GET /domains/v2/zones", s.listDomainsZones)
+	handle("POST /domains/v2/zones", s.createDomainsZone)
+	handle("GET /domains/v2/zones/{zone}", s.getDomainsZone)
+	handle("PATCH /domains/v2/zones/{zone}", s.updateDomainsZone("comment"))
+	handle("PATCH /domains/v2/zones/{zone}/state", s.updateDomainsZone("disabled"))
+	handle("DELETE /domains/v2/zones/{zone}", s.deleteDomainsZone)
+
+	handle("GET /domains/v2/zones/{zone}/rrset", s.listDomainsRRSets)
+	handle("POST /domains/v2/zones/{zone}/rrset", s.createDomainsRRSet)
+	handle("GET /domains/v2/zones/{zone}/rrset/{rrset}", s.getDomainsRRSet)
+	handle("PATCH /domains/v2/zones/{zone}/rrset/{rrset}", s.updateDomainsRRSet)
+	handle("DELETE /domains/v2/zones/{zone}/rrset/{rrset}", s.deleteDomainsRRSet)
+}
+
+func (s *Server) listDomainsZones(w http.ResponseWriter, r *http.Request) {
+	filter := r.URL.Query().Get("filter")
+	zones := s.list(KindDomainsZones, func(zone map[string]interface{}) bool {
+		return strings.Contains(zone["name"].(string), filter)
+	})
+	writeDomainsList(w, r, zones)
+}
+
+func (s *Server) createDomainsZone(w http.ResponseWriter, r *http.Request) {
+	opts, err := readJSON(r, "")
+	if err != nil {
+		writeDomainsError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+	name, _ := opts["name"].(string)
+	if name == "" {
+		writeDomainsError(w, http.StatusBadRequest, "name is required")
+
+		return
+	}
+	if len(s.list(KindDomainsZones, func(zone map[string]interface{}) bool {
+		return zone["name"] == name
+	})) > 0 {
+		writeDomainsError(w, http.StatusConflict, fmt.Sprintf("zone %s already exists", name))
+
+		return
+	}
+
+	zone := s.create(KindDomainsZones, map[string]interface{}{
+		"project_id":        ProjectID,
+		"name":              name,
+		"comment":           "",
+		"created_at":        now(),
+		"updated_at":        now(),
+		"disabled":          false,
+		"last_check_status": false,
+	}, "", "")
+	writeJSON(w, http.StatusOK, zone)
+}
+
+func (s *Server) getDomainsZone(w http.ResponseWriter, r *http.Request) {
+	zone, ok := s.get(KindDomainsZones, r.PathValue("zone"))
+	if !ok {
+		writeDomainsError(w, http.StatusNotFound, "zone not found")
+
+		return
+	}
+	writeJSON(w, http.StatusOK, zone)
+}
+
+// updateDomainsZone returns the handler of the updates of the zone attribute.
+func (s *Server) updateDomainsZone(attr string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		opts, err := readJSON(r, "")
+		if err != nil {
+			writeDomainsError(w, http.StatusBadRequest, err.Error())
+
+			return
+		}
+		if _, ok := s.update(KindDomainsZones, r.PathValue("zone"), map[string]interface{}{
+			attr:         opts[attr],
+			"updated_at": now(),
+		}, ""); !ok {
+			writeDomainsError(w, http.StatusNotFound, "zone not found")
+
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) deleteDomainsZone(w http.ResponseWriter, r *http.Request) {
+	zoneID := r.PathValue("zone")
+	if !s.delete(KindDomainsZones, zoneID) {
+		writeDomainsError(w, http.StatusNotFound, "zone not found")
+
+		return
+	}
+	for _, rrset := range s.list(KindDomainsRRSets, func(rrset map[string]interface{}) bool {
+		return rrset["zone_id"] == zoneID
+	}) {
+		s.delete(KindDomainsRRSets, rrset["id"].(string))
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listDomainsRRSets(w http.ResponseWriter, r *http.Request) {
+	zoneID := r.PathValue("zone")
+	if !s.exists(KindDomainsZones, zoneID) {
+		writeDomainsError(w, http.StatusNotFound, "zone not found")
+
+		return
+	}
+
+	query := r.URL.Query()
+	name := query.Get("name")
+	types := query["rrset_types"]
+	rrsets := s.list(KindDomainsRRSets, func(rrset map[string]interface{}) bool {
+		if rrset["zone_id"] != zoneID || !strings.Contains(rrset["name"].(string), name) {
+			return false
+		}
+		if len(types) == 0 {
+			return true
+		}
+		for _, t := range types {
+			if rrset["type"] == t {
+				return true
+			}
+		}
+
+		return false
+	})
+	writeDomainsList(w, r, rrsets)
+}
+
+func (s *Server) createDomainsRRSet(w http.ResponseWriter, r *http.Request) {
+	zoneID := r.PathValue("zone")
+	zone, ok := s.get(KindDomainsZones, zoneID)
+	if !ok {
+		writeDomainsError(w, http.StatusNotFound, "zone not found")
+
+		return
+	}
+	opts, err := readJSON(r, "")
+	if err != nil {
+		writeDomainsError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	name, _ := opts["name"].(string)
+	if !strings.HasSuffix(strings.TrimSuffix(name, "."), strings.TrimSuffix(zone["name"].(string), ".")) {
+		writeDomainsErrorAt(w, http.StatusBadRequest, "rrset name must belong to the zone", "body.name")
+
+		return
+	}
+	if ttl, _ := opts["ttl"].(float64); ttl < 60 || ttl > 604800 {
+		writeDomainsErrorAt(w, http.StatusBadRequest, "ttl must be between 60 and 604800", "body.ttl")
+
+		return
+	}
+	if len(s.list(KindDomainsRRSets, func(rrset map[string]interface{}) bool {
+		return rrset["zone_id"] == zoneID && rrset["name"] == name && rrset["type"] == opts["type"]
+	})) > 0 {
+		writeDomainsError(w, http.StatusConflict, fmt.Sprintf("rrset %s %v already exists", name, opts["type"]))
+
+		return
+	}
+
+	rrset := map[string]interface{}{
+		"zone_id":    zoneID,
+		"comment":    "",
+		"managed_by": "",
+		"records":    []interface{}{},
+	}
+	merge(rrset, opts)
+	writeJSON(w, http.StatusOK, s.create(KindDomainsRRSets, rrset, "", ""))
+}
+
+func (s *Server) getDomainsRRSet(w http.ResponseWriter, r *http.Request) {
+	rrset, ok := s.get(KindDomainsRRSets, r.PathValue("rrset"))
+	if !ok || rrset["zone_id"] != r.PathValue("zone") {
+		writeDomainsError(w, http.StatusNotFound, "rrset not found")
+
+		return
+	}
+	writeJSON(w, http.StatusOK, rrset)
+}
+
+func (s *Server) updateDomainsRRSet(w http.ResponseWriter, r *http.Request) {
+	opts, err := readJSON(r, "")
+	if err != nil {
+		writeDomainsError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+	patch := map[string]interface{}{
+		"ttl":     opts["ttl"],
+		"records": opts["records"],
+		"comment": opts["comment"],
+	}
+	if patch["comment"] == nil {
+		patch["comment"] = ""
+	}
+	if !s.exists(KindDomainsZones, r.PathValue("zone")) {
+		writeDomainsError(w, http.StatusNotFound, "zone not found")
+
+		return
+	}
+	if _, ok := s.update(KindDomainsRRSets, r.PathValue("rrset"), patch, ""); !ok {
+		writeDomainsError(w, http.StatusNotFound, "rrset not found")
+
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) deleteDomainsRRSet(w http.ResponseWriter, r *http.Request) {
+	if !s.delete(KindDomainsRRSets, r.PathValue("rrset")) {
+		writeDomainsError(w, http.StatusNotFound, "rrset not found")
+
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeDomainsList writes the page of the items with the limit and the offset of the request.
+func writeDomainsList(w http.ResponseWriter, r *http.Request, items []map[string]interface{}) {
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 1000
+	}
+
+	offset = min(max(offset, 0), len(items))
+	end := min(offset+limit, len(items))
+	nextOffset := 0
+	if end < len(items) {
+		nextOffset = end
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"count":       len(items),
+		"next_offset": nextOffset,
+		"result":      items[offset:end],
+	})
+}
+
+func writeDomainsError(w http.ResponseWriter, status int, description string) {
+	writeDomainsErrorAt(w, status, description, "")
+}
+
+func writeDomainsErrorAt(w http.ResponseWriter, status int, description, location string) {
+	body := map[string]interface{}{
+		"error":       strings.ToLower(strings.ReplaceAll(http.StatusText(status), " ", "_")),
+		"description": description,
+	}
+	if location != "" {
+		body["location"] = location
+	}
+	writeJSON(w, status, body)
+}
@@ -0,0 +1,127 @@
+package fakeapi
+
+import (
+	"fmt"
+	"net/http"
+)
+
+const (
+	KindIAMUsers        = "iam/users"
+	KindIAMServiceUsers = "iam/service_users"
+)
+
+func (s *Server) registerIAM(mux *http.ServeMux) {
+	handle := func(pattern string, handler http.HandlerFunc) {
+		mux.HandleFunc(pattern, s.authenticated(writeIAMError, handler))
+	}
+
+	for kind, path := range map[string]string{
+		KindIAMUsers:        "/iam/v1/users/{id}",
+		KindIAMServiceUsers: "/iam/v1/service_users/{id}",
+	} {
+		handle("GET "+path, s.getIAMUser(kind))
+		handle("PUT "+path+"/roles", s.manageIAMUserRoles(kind, true))
+		handle("DELETE "+path+"/roles", s.manageIAMUserRoles(kind, false))
+	}
+}
+
+// AddIAMUser stores the user without roles and returns its ID. The kind is KindIAMUsers
+// or KindIAMServiceUsers.
+func (s *Server) AddIAMUser(kind string) string {
+	user := s.create(kind, map[string]interface{}{"roles": []interface{}{}}, "", "")
+
+	return user["id"].(string)
+}
+
+// IAMUserRoles returns the roles of the user as "scope/project_id/role_name" strings,
+// the project ID is empty for the account roles.
+func (s *Server) IAMUserRoles(kind, id string) []string {
+	user, _ := s.get(kind, id)
+	rawRoles, _ := user["roles"].([]interface{})
+	result := make([]string, 0, len(rawRoles))
+	for _, rawRole := range rawRoles {
+		result = append(result, iamRoleKey(rawRole))
+	}
+
+	return result
+}
+
+func (s *Server) getIAMUser(kind string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user, ok := s.get(kind, r.PathValue("id"))
+		if !ok {
+			writeIAMError(w, http.StatusNotFound, "user not found")
+
+			return
+		}
+		user["keystone_id"] = user["id"]
+		user["groups"] = []interface{}{}
+		writeJSON(w, http.StatusOK, user)
+	}
+}
+
+// manageIAMUserRoles assigns or unassigns the roles in the request body. The other
+// roles of the user are left as is.
+func (s *Server) manageIAMUserRoles(kind string, assign bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := readJSON(r, "")
+		if err != nil {
+			writeIAMError(w, http.StatusBadRequest, err.Error())
+
+			return
+		}
+		requested, _ := body["roles"].([]interface{})
+		if len(requested) == 0 {
+			writeIAMError(w, http.StatusBadRequest, "roles are missing in the request body")
+
+			return
+		}
+
+		_, ok := s.modify(kind, r.PathValue("id"), func(user map[string]interface{}) {
+			current, _ := user["roles"].([]interface{})
+			changed := make(map[string]bool, len(requested))
+			for _, role := range requested {
+				changed[iamRoleKey(role)] = true
+			}
+
+			roles := make([]interface{}, 0, len(current)+len(requested))
+			for _, role := range current {
+				if !changed[iamRoleKey(role)] {
+					roles = append(roles, role)
+				}
+			}
+			if assign {
+				roles = append(roles, requested...)
+			}
+			user["roles"] = roles
+		}, "")
+		if !ok {
+			writeIAMError(w, http.StatusNotFound, "user not found")
+
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func iamRoleKey(rawRole interface{}) string {
+	role, _ := rawRole.(map[string]interface{})
+	scope, _ := role["scope"].(string)
+	projectID, _ := role["project_id"].(string)
+	roleName, _ := role["role_name"].(string)
+
+	return fmt.Sprintf("%s/%s/%s", scope, projectID, roleName)
+}
+
+// writeIAMError writes the error in the format of the IAM API. The code of 404 errors
+// is USER_NOT_FOUND, as only users are stored.
+func writeIAMError(w http.ResponseWriter, status int, message string) {
+	code := "UNKNOWN"
+	switch status {
+	case http.StatusNotFound:
+		code = "USER_NOT_FOUND"
+	case http.StatusBadRequest:
+		code = "REQUEST_VALIDATION_FAILED"
+	}
+	writeJSON(w, status, map[string]interface{}{"code": code, "message": message})
+}
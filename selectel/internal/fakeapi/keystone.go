@@ -0,0 +1,177 @@
+package fakeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// tokenTTL is the lifetime of issued tokens.
+const tokenTTL = 24 * time.Hour
+
+// catalogServices maps service types of the catalog to paths of the server.
+var catalogServices = []struct {
+	serviceType string
+	path        string
+
+	// global services have a single endpoint without a region in the path.
+	global bool
+
+	// regions are the regions of the service endpoints, Region if empty.
+	regions []string
+}{
+	{serviceType: "identity", path: "/identity/v3/", global: true},
+	{serviceType: "resell", path: "/resell", global: true, regions: []string{Region, CrossRegion}},
+	{serviceType: "quota-manager", path: "/quota-manager/%s"},
+	{serviceType: "managed-kubernetes", path: "/mks/%s/v1"},
+	{serviceType: "managed-database", path: "/dbaas/%s/v1"},
+	{serviceType: "container-registry", path: "/craas/v1", global: true},
+	{serviceType: "iam", path: "", global: true},
+	{serviceType: "secrets-manager", path: "/secrets-manager/", global: true},
+	{serviceType: "certificate-manager", path: "/certificate-manager/", global: true},
+}
+
+func (s *Server) registerKeystone(mux *http.ServeMux) {
+	mux.HandleFunc("POST /identity/v3/auth/tokens", s.issueToken)
+	mux.HandleFunc("GET /identity/v3/auth/tokens", s.validateToken)
+}
+
+func (s *Server) issueToken(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Auth struct {
+			Identity struct {
+				Password struct {
+					User struct {
+						Name     string `json:"name"`
+						Password string `json:"password"`
+						Domain   struct {
+							Name string `json:"name"`
+						} `json:"domain"`
+					} `json:"user"`
+				} `json:"password"`
+				Token struct {
+					ID string `json:"id"`
+				} `json:"token"`
+			} `json:"identity"`
+		} `json:"auth"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeKeystoneError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	identity := body.Auth.Identity
+	user := identity.Password.User
+	switch {
+	case identity.Token.ID != "" && s.validToken(identity.Token.ID):
+	case user.Name == Username && user.Password == Password && user.Domain.Name == DomainName:
+	default:
+		writeKeystoneError(w, http.StatusUnauthorized, "The request you have made requires authentication.")
+
+		return
+	}
+
+	token := s.issue()
+	w.Header().Set("X-Subject-Token", token)
+	writeJSON(w, http.StatusCreated, s.tokenBody(token))
+}
+
+func (s *Server) validateToken(w http.ResponseWriter, r *http.Request) {
+	if !s.validToken(r.Header.Get("X-Auth-Token")) {
+		writeKeystoneError(w, http.StatusUnauthorized, "The request you have made requires authentication.")
+
+		return
+	}
+
+	token := r.Header.Get("X-Subject-Token")
+	if !s.validToken(token) {
+		writeKeystoneError(w, http.StatusNotFound, "Could not find token.")
+
+		return
+	}
+	w.Header().Set("X-Subject-Token", token)
+	writeJSON(w, http.StatusOK, s.tokenBody(token))
+}
+
+func (s *Server) issue() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.seq++
+	token := fmt.Sprintf("fake-token-%d", s.seq)
+	s.tokens[token] = time.Now().Add(tokenTTL)
+
+	return token
+}
+
+func (s *Server) validToken(token string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expiresAt, ok := s.tokens[token]
+
+	return ok && time.Now().Before(expiresAt)
+}
+
+func (s *Server) tokenBody(token string) map[string]interface{} {
+	s.mu.Lock()
+	expiresAt := s.tokens[token]
+	s.mu.Unlock()
+
+	catalog := make([]map[string]interface{}, 0, len(catalogServices))
+	for _, service := range catalogServices {
+		regions := service.regions
+		if len(regions) == 0 {
+			regions = []string{Region}
+		}
+
+		endpoints := make([]map[string]interface{}, 0, len(regions))
+		for _, region := range regions {
+			path := service.path
+			if !service.global {
+				path = fmt.Sprintf(service.path, region)
+			}
+			endpoints = append(endpoints, map[string]interface{}{
+				"interface": "public",
+				"region":    region,
+				"region_id": region,
+				"url":       s.URL + path,
+			})
+		}
+
+		catalog = append(catalog, map[string]interface{}{
+			"type":      service.serviceType,
+			"name":      service.serviceType,
+			"endpoints": endpoints,
+		})
+	}
+
+	return map[string]interface{}{
+		"token": map[string]interface{}{
+			"expires_at": expiresAt.UTC().Format(time.RFC3339Nano),
+			"issued_at":  time.Now().UTC().Format(time.RFC3339Nano),
+			"methods":    []string{"password"},
+			"user": map[string]interface{}{
+				"name":   Username,
+				"domain": map[string]interface{}{"name": DomainName},
+			},
+			"project": map[string]interface{}{
+				"id":     ProjectID,
+				"domain": map[string]interface{}{"name": DomainName},
+			},
+			"catalog": catalog,
+		},
+	}
+}
+
+func writeKeystoneError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    status,
+			"title":   http.StatusText(status),
+			"message": message,
+		},
+	})
+}
@@ -0,0 +1,170 @@
+package fakeapi
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	KindMKSClusters = "mks/clusters"
+
+	// MKSKubeVersion is the default Kubernetes version of the fake MKS API.
+	MKSKubeVersion = "1.29.2"
+
+	// maintenanceWindowLength is the difference between the start and the end of the maintenance window.
+	maintenanceWindowLength = 4 * time.Hour
+)
+
+// mksKubeconfigTemplate is the kubeconfig of a cluster with fake base64 certificates.
+const mksKubeconfigTemplate = `apiVersion: v1
+clusters:
+- cluster:
+    certificate-authority-data: ZmFrZS1jYQ==
+    server: https://%s:6443
+  name: %s
+contexts: []
+current-context: ""
+kind: Config
+users:
+- name: admin
+  user:
+    client-certificate-data: ZmFrZS1jZXJ0
+    client-key-data: ZmFrZS1rZXk=
+`
+
+// mksKubeVersions are the Kubernetes versions supported by the fake MKS API.
+var mksKubeVersions = []map[string]interface{}{
+	{"version": "1.28.7", "is_default": false},
+	{"version": MKSKubeVersion, "is_default": true},
+}
+
+func (s *Server) registerMKS(mux *http.ServeMux) {
+	handle := func(pattern string, handler http.HandlerFunc) {
+		mux.HandleFunc(pattern, s.authenticated(writeMKSError, handler))
+	}
+
+	handle("GET /mks/{region}/v1/kubeversions", s.listMKSKubeVersions)
+	handle("GET /mks/{region}/v1/clusters", s.listMKSClusters)
+	handle("POST /mks/{region}/v1/clusters", s.createMKSCluster)
+	handle("GET /mks/{region}/v1/clusters/{id}", s.getMKSCluster)
+	handle("PUT /mks/{region}/v1/clusters/{id}", s.updateMKSCluster)
+	handle("DELETE /mks/{region}/v1/clusters/{id}", s.deleteMKSCluster)
+	handle("GET /mks/{region}/v1/clusters/{id}/kubeconfig", s.getMKSKubeconfig)
+}
+
+func (s *Server) listMKSKubeVersions(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{"kube_versions": mksKubeVersions})
+}
+
+func (s *Server) listMKSClusters(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{"clusters": s.list(KindMKSClusters, nil)})
+}
+
+func (s *Server) createMKSCluster(w http.ResponseWriter, r *http.Request) {
+	opts, err := readJSON(r, "cluster")
+	if err != nil {
+		writeMKSError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+	if opts["name"] == nil || opts["kube_version"] == nil || opts["region"] == nil {
+		writeMKSError(w, http.StatusBadRequest, "name, kube_version and region are required")
+
+		return
+	}
+
+	cluster := map[string]interface{}{
+		"created_at":                        now(),
+		"project_id":                        ProjectID,
+		"network_id":                        "",
+		"subnet_id":                         "",
+		"kube_api_ip":                       "192.0.2.10",
+		"maintenance_window_start":          "03:00:00",
+		"enable_autorepair":                 true,
+		"enable_patch_version_auto_upgrade": true,
+		"zonal":                             false,
+		"private_kube_api":                  false,
+		"kubernetes_options": map[string]interface{}{
+			"enable_pod_security_policy": false,
+			"feature_gates":              []string{},
+			"admission_controllers":      []string{},
+			"audit_logs":                 map[string]interface{}{"enabled": false, "secret_name": ""},
+			"oidc":                       map[string]interface{}{"enabled": false},
+		},
+	}
+	delete(opts, "nodegroups")
+	merge(cluster, opts)
+	setMaintenanceWindowEnd(cluster)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"cluster": s.create(KindMKSClusters, cluster, "PENDING_CREATE", "ACTIVE"),
+	})
+}
+
+func (s *Server) getMKSCluster(w http.ResponseWriter, r *http.Request) {
+	cluster, ok := s.get(KindMKSClusters, r.PathValue("id"))
+	if !ok {
+		writeMKSError(w, http.StatusNotFound, "cluster not found")
+
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"cluster": cluster})
+}
+
+func (s *Server) updateMKSCluster(w http.ResponseWriter, r *http.Request) {
+	opts, err := readJSON(r, "cluster")
+	if err != nil {
+		writeMKSError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+	setMaintenanceWindowEnd(opts)
+
+	cluster, ok := s.update(KindMKSClusters, r.PathValue("id"), opts, "PENDING_UPDATE")
+	if !ok {
+		writeMKSError(w, http.StatusNotFound, "cluster not found")
+
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"cluster": cluster})
+}
+
+func (s *Server) deleteMKSCluster(w http.ResponseWriter, r *http.Request) {
+	if !s.delete(KindMKSClusters, r.PathValue("id")) {
+		writeMKSError(w, http.StatusNotFound, "cluster not found")
+
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getMKSKubeconfig returns a kubeconfig with the fields which the MKS client parses.
+func (s *Server) getMKSKubeconfig(w http.ResponseWriter, r *http.Request) {
+	cluster, ok := s.get(KindMKSClusters, r.PathValue("id"))
+	if !ok {
+		writeMKSError(w, http.StatusNotFound, "cluster not found")
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-yaml")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, mksKubeconfigTemplate, cluster["kube_api_ip"], cluster["name"])
+}
+
+// setMaintenanceWindowEnd sets the end of the maintenance window from its start.
+func setMaintenanceWindowEnd(cluster map[string]interface{}) {
+	start, _ := cluster["maintenance_window_start"].(string)
+	startTime, err := time.Parse(time.TimeOnly, start)
+	if err != nil {
+		return
+	}
+	cluster["maintenance_window_end"] = startTime.Add(maintenanceWindowLength).Format(time.TimeOnly)
+}
+
+func writeMKSError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"error": map[string]interface{}{"message": message},
+	})
+}
@@ -0,0 +1,171 @@
+package fakeapi
+
+import (
+	"fmt"
+	"net/http"
+)
+
+const (
+	KindQuotaManagerQuotas = "quota-manager/quotas"
+
+	// clusterQuota is the limit of regional and zonal clusters of the project.
+	clusterQuota = 5
+)
+
+// quotaEntity is a quota of a resource in the region or in a zone of the region.
+type quotaEntity struct {
+	zone  string
+	value int
+	used  int
+}
+
+func (s *Server) registerQuotaManager(mux *http.ServeMux) {
+	mux.HandleFunc("GET /quota-manager/{region}/projects/{project}/quotas",
+		s.authenticated(writeKeystoneError, s.getProjectQuotas))
+	mux.HandleFunc("PATCH /quota-manager/{region}/projects/{project}/quotas",
+		s.authenticated(writeKeystoneError, s.updateProjectQuotas))
+}
+
+// getProjectQuotas returns the quotas of the project with the usage of MKS clusters
+// counted from the stored clusters.
+func (s *Server) getProjectQuotas(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"quotas": renderQuotas(s.projectQuotas(r.PathValue("project"), r.PathValue("region"))),
+	})
+}
+
+// updateProjectQuotas sets the values of the quotas in the request body. Quotas of the
+// resources and zones which are missing in the request are left as is.
+func (s *Server) updateProjectQuotas(w http.ResponseWriter, r *http.Request) {
+	body, err := readJSON(r, "quotas")
+	if err != nil {
+		writeKeystoneError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	projectID, region := r.PathValue("project"), r.PathValue("region")
+	current := s.projectQuotas(projectID, region)
+	values := map[string]interface{}{}
+	for name, rawEntities := range body {
+		entities, ok := current[name]
+		if !ok {
+			writeKeystoneError(w, http.StatusBadRequest, fmt.Sprintf("unknown resource %q", name))
+
+			return
+		}
+		rawList, _ := rawEntities.([]interface{})
+		for _, rawEntity := range rawList {
+			rawOpts, _ := rawEntity.(map[string]interface{})
+			zone, _ := rawOpts["zone"].(string)
+			value, ok := rawOpts["value"].(float64)
+			if !ok || value < 0 {
+				writeKeystoneError(w, http.StatusBadRequest, fmt.Sprintf("invalid value of %s quota", name))
+
+				return
+			}
+			entity, ok := findQuotaZone(entities, zone)
+			if !ok {
+				writeKeystoneError(w, http.StatusBadRequest, fmt.Sprintf("unknown zone %q of %s quota", zone, name))
+
+				return
+			}
+			if int(value) < entity.used {
+				writeKeystoneError(w, http.StatusBadRequest,
+					fmt.Sprintf("value of %s quota is lower than the used %d", name, entity.used))
+
+				return
+			}
+			values[quotaValueKey(name, zone)] = int(value)
+		}
+	}
+
+	id := projectID + "/" + region
+	if _, ok := s.update(KindQuotaManagerQuotas, id, values, ""); !ok {
+		s.createWithID(KindQuotaManagerQuotas, id, values, "", "")
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"quotas": renderQuotas(s.projectQuotas(projectID, region)),
+	})
+}
+
+// SetQuotaUsage sets the usage of the quota of the resource in the zone, the zone is empty
+// for the regional resources. Quotas can't be set lower than the usage.
+func (s *Server) SetQuotaUsage(projectID, region, name, zone string, used int) {
+	id := projectID + "/" + region
+	usage := map[string]interface{}{quotaUsedKey(name, zone): used}
+	if _, ok := s.update(KindQuotaManagerQuotas, id, usage, ""); !ok {
+		s.createWithID(KindQuotaManagerQuotas, id, usage, "", "")
+	}
+}
+
+// projectQuotas returns the quotas of the project in the region with the values
+// set by the update requests and the usage set by SetQuotaUsage.
+func (s *Server) projectQuotas(projectID, region string) map[string][]quotaEntity {
+	var regional, zonal int
+	for _, c := range s.list(KindMKSClusters, nil) {
+		if c["zonal"] == true {
+			zonal++
+		} else {
+			regional++
+		}
+	}
+
+	zones := []string{region + "a", region + "b"}
+	result := map[string][]quotaEntity{
+		"mks_cluster_regional": {{value: clusterQuota, used: regional}},
+		"mks_cluster_zonal":    {{value: clusterQuota, used: zonal}},
+		"compute_cores":        {{zone: zones[0]}, {zone: zones[1]}},
+		"compute_ram":          {{zone: zones[0]}, {zone: zones[1]}},
+	}
+
+	values, _ := s.get(KindQuotaManagerQuotas, projectID+"/"+region)
+	for name, entities := range result {
+		for i, entity := range entities {
+			if value, ok := values[quotaValueKey(name, entity.zone)].(float64); ok {
+				entities[i].value = int(value)
+			}
+			if used, ok := values[quotaUsedKey(name, entity.zone)].(float64); ok {
+				entities[i].used = int(used)
+			}
+		}
+	}
+
+	return result
+}
+
+func renderQuotas(quotas map[string][]quotaEntity) map[string]interface{} {
+	result := make(map[string]interface{}, len(quotas))
+	for name, quota := range quotas {
+		entities := make([]map[string]interface{}, 0, len(quota))
+		for _, entity := range quota {
+			rendered := map[string]interface{}{"value": entity.value, "used": entity.used}
+			if entity.zone != "" {
+				rendered["zone"] = entity.zone
+			}
+			entities = append(entities, rendered)
+		}
+		result[name] = entities
+	}
+
+	return result
+}
+
+func findQuotaZone(entities []quotaEntity, zone string) (quotaEntity, bool) {
+	for _, entity := range entities {
+		if entity.zone == zone {
+			return entity, true
+		}
+	}
+
+	return quotaEntity{}, false
+}
+
+func quotaValueKey(name, zone string) string {
+	return name + "/" + zone
+}
+
+func quotaUsedKey(name, zone string) string {
+	return "used/" + quotaValueKey(name, zone)
+}
@@ -0,0 +1,166 @@
+package fakeapi
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+)
+
+const (
+	KindResellCrossRegionSubnets = "resell/cross_region_subnets"
+
+	// resellVLANBase is the first VLAN ID of cross-region subnets.
+	resellVLANBase = 1000
+)
+
+func (s *Server) registerResell(mux *http.ServeMux) {
+	handle := func(pattern string, handler http.HandlerFunc) {
+		mux.HandleFunc(pattern, s.authenticated(writeResellError, handler))
+	}
+
+	handle("POST /resell/v2/cross_region_subnets/projects/{project}", s.createResellCrossRegionSubnets)
+	handle("GET /resell/v2/cross_region_subnets/{id}", s.getResellCrossRegionSubnet)
+	handle("DELETE /resell/v2/cross_region_subnets/{id}", s.deleteResellCrossRegionSubnet)
+	handle("GET /resell/v2/projects", s.listResellProjects)
+	handle("GET /resell/v2/projects/{id}", s.getResellProject)
+}
+
+// createResellCrossRegionSubnets creates the cross-region subnets with a regional subnet
+// in every requested region. All subnets share the CIDR and the VLAN.
+func (s *Server) createResellCrossRegionSubnets(w http.ResponseWriter, r *http.Request) {
+	body, err := readJSON(r, "")
+	if err != nil {
+		writeResellError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+	rawOpts, _ := body["cross_region_subnets"].([]interface{})
+	if len(rawOpts) == 0 {
+		writeResellError(w, http.StatusBadRequest, "cross_region_subnets are missing in the request body")
+
+		return
+	}
+
+	projectID := r.PathValue("project")
+	created := make([]map[string]interface{}, 0, len(rawOpts))
+	for _, rawOpt := range rawOpts {
+		opt, _ := rawOpt.(map[string]interface{})
+		cidr, _ := opt["cidr"].(string)
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			writeResellError(w, http.StatusBadRequest, fmt.Sprintf("invalid cidr: %q", cidr))
+
+			return
+		}
+		rawRegions, _ := opt["regions"].([]interface{})
+		if len(rawRegions) < 2 {
+			writeResellError(w, http.StatusBadRequest, "cross-region subnet must have at least 2 regions")
+
+			return
+		}
+
+		id := s.nextNumber()
+		vlanID := resellVLANBase + id
+		subnets := make([]map[string]interface{}, 0, len(rawRegions))
+		for i, rawRegion := range rawRegions {
+			region, _ := rawRegion.(map[string]interface{})["region"].(string)
+			subnetID := s.nextNumber()
+			subnets = append(subnets, map[string]interface{}{
+				"id":              subnetID,
+				"status":          "DOWN",
+				"servers":         []interface{}{},
+				"region":          region,
+				"cidr":            cidr,
+				"network_id":      fmt.Sprintf("%08x-0000-4000-8000-%012x", subnetID, 1),
+				"subnet_id":       fmt.Sprintf("%08x-0000-4000-8000-%012x", subnetID, 2),
+				"project_id":      projectID,
+				"vlan_id":         vlanID,
+				"vtep_ip_address": fmt.Sprintf("10.10.%d.%d", id%256, i+1),
+			})
+		}
+
+		subnet := s.createWithID(KindResellCrossRegionSubnets, strconv.Itoa(id), map[string]interface{}{
+			"status":  "DOWN",
+			"servers": []interface{}{},
+			"cidr":    cidr,
+			"vlan_id": vlanID,
+			"subnets": subnets,
+		}, "", "")
+		created = append(created, resellObject(subnet))
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"cross_region_subnets": created})
+}
+
+func (s *Server) getResellCrossRegionSubnet(w http.ResponseWriter, r *http.Request) {
+	subnet, ok := s.get(KindResellCrossRegionSubnets, r.PathValue("id"))
+	if !ok {
+		writeResellError(w, http.StatusNotFound, "cross-region subnet not found")
+
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"cross_region_subnet": resellObject(subnet)})
+}
+
+func (s *Server) deleteResellCrossRegionSubnet(w http.ResponseWriter, r *http.Request) {
+	if !s.delete(KindResellCrossRegionSubnets, r.PathValue("id")) {
+		writeResellError(w, http.StatusNotFound, "cross-region subnet not found")
+
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// listResellProjects returns the only project of the domain, the one the provider is
+// configured with.
+func (s *Server) listResellProjects(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"projects": []interface{}{s.resellProject()},
+	})
+}
+
+func (s *Server) getResellProject(w http.ResponseWriter, r *http.Request) {
+	if r.PathValue("id") != ProjectID {
+		writeResellError(w, http.StatusNotFound, "project not found")
+
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"project": s.resellProject()})
+}
+
+// resellProject returns the project with the quotas of the Quota Manager in both regions
+// of the Resell API.
+func (s *Server) resellProject() map[string]interface{} {
+	projectQuotas := map[string][]map[string]interface{}{}
+	for _, region := range []string{Region, CrossRegion} {
+		for name, entities := range renderQuotas(s.projectQuotas(ProjectID, region)) {
+			for _, entity := range entities.([]map[string]interface{}) {
+				entity["region"] = region
+				projectQuotas[name] = append(projectQuotas[name], entity)
+			}
+		}
+	}
+
+	return map[string]interface{}{
+		"id":         ProjectID,
+		"name":       ProjectName,
+		"url":        "https://" + ProjectName + ".selvpc.ru",
+		"enabled":    true,
+		"custom_url": "",
+		"theme":      map[string]interface{}{"color": ProjectThemeColor, "logo": ""},
+		"quotas":     projectQuotas,
+	}
+}
+
+// resellObject returns the object with the numeric ID, as the Resell API does.
+func resellObject(data map[string]interface{}) map[string]interface{} {
+	if id, err := strconv.Atoi(fmt.Sprint(data["id"])); err == nil {
+		data["id"] = id
+	}
+
+	return data
+}
+
+func writeResellError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{"error": message})
+}
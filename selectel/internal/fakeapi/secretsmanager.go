@@ -0,0 +1,125 @@
+package fakeapi
+
+import (
+	"fmt"
+	"net/http"
+)
+
+const KindSecretsManagerSecrets = "secrets-manager/secrets"
+
+func (s *Server) registerSecretsManager(mux *http.ServeMux) {
+	handle := func(pattern string, handler http.HandlerFunc) {
+		mux.HandleFunc(pattern, s.authenticated(writeSecretsManagerError, handler))
+	}
+
+	handle("POST /secrets-manager/v1/{key}", s.createSecretsManagerSecret)
+	handle("GET /secrets-manager/v1/{key}", s.getSecretsManagerSecret)
+	handle("PUT /secrets-manager/v1/{key}", s.updateSecretsManagerSecret)
+	handle("DELETE /secrets-manager/v1/{key}", s.deleteSecretsManagerSecret)
+}
+
+// AddSecretsManagerSecret stores the secret with the base64 value as its first version.
+func (s *Server) AddSecretsManagerSecret(key, value string) {
+	s.createWithID(KindSecretsManagerSecrets, key, map[string]interface{}{
+		"name":        key,
+		"description": "",
+		"versions":    []interface{}{secretsManagerVersion(1, value)},
+	}, "", "")
+}
+
+// SecretsManagerSecretVersions returns the base64 values of the versions of the secret
+// in the order of creation.
+func (s *Server) SecretsManagerSecretVersions(key string) []string {
+	secret, _ := s.get(KindSecretsManagerSecrets, key)
+	rawVersions, _ := secret["versions"].([]interface{})
+	result := make([]string, 0, len(rawVersions))
+	for _, rawVersion := range rawVersions {
+		version, _ := rawVersion.(map[string]interface{})
+		value, _ := version["value"].(string)
+		result = append(result, value)
+	}
+
+	return result
+}
+
+func (s *Server) createSecretsManagerSecret(w http.ResponseWriter, r *http.Request) {
+	opts, err := readJSON(r, "")
+	if err != nil {
+		writeSecretsManagerError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+	key := r.PathValue("key")
+	if s.exists(KindSecretsManagerSecrets, key) {
+		writeSecretsManagerError(w, http.StatusConflict, fmt.Sprintf("secret %s already exists", key))
+
+		return
+	}
+	description, _ := opts["description"].(string)
+	s.createWithID(KindSecretsManagerSecrets, key, map[string]interface{}{
+		"name":        key,
+		"description": description,
+		"versions":    []interface{}{secretsManagerVersion(1, opts["value"])},
+	}, "", "")
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) getSecretsManagerSecret(w http.ResponseWriter, r *http.Request) {
+	secret, ok := s.get(KindSecretsManagerSecrets, r.PathValue("key"))
+	if !ok {
+		writeSecretsManagerError(w, http.StatusNotFound, "secret not found")
+
+		return
+	}
+	versions := secret["versions"].([]interface{})
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"name":        secret["name"],
+		"description": secret["description"],
+		"version":     versions[len(versions)-1],
+	})
+}
+
+// updateSecretsManagerSecret sets the description and adds a new version
+// when the value is not empty.
+func (s *Server) updateSecretsManagerSecret(w http.ResponseWriter, r *http.Request) {
+	opts, err := readJSON(r, "")
+	if err != nil {
+		writeSecretsManagerError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+	if _, ok := s.modify(KindSecretsManagerSecrets, r.PathValue("key"), func(data map[string]interface{}) {
+		description, _ := opts["description"].(string)
+		data["description"] = description
+		if value, _ := opts["value"].(string); value != "" {
+			versions := data["versions"].([]interface{})
+			data["versions"] = append(versions, secretsManagerVersion(len(versions)+1, value))
+		}
+	}, ""); !ok {
+		writeSecretsManagerError(w, http.StatusNotFound, "secret not found")
+
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) deleteSecretsManagerSecret(w http.ResponseWriter, r *http.Request) {
+	if !s.delete(KindSecretsManagerSecrets, r.PathValue("key")) {
+		writeSecretsManagerError(w, http.StatusNotFound, "secret not found")
+
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func secretsManagerVersion(id int, value interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"version_id": id,
+		"value":      value,
+		"created_at": now(),
+	}
+}
+
+func writeSecretsManagerError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{"error": message})
+}
@@ -0,0 +1,322 @@
+// Package fakeapi is an in-process fake of Selectel APIs for unit tests of the provider.
+//
+// The server issues Keystone tokens with a service catalog that points to itself
+// and keeps objects of the MKS, DBaaS, CRaaS, Domains v2, Resell, Quota Manager, IAM
+// and Secrets Manager APIs in memory, so resources can be created, read, updated and deleted
+// by resource.UnitTest without credentials and network access.
+package fakeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	// DomainName, Username and Password are the credentials accepted by the fake Keystone.
+	DomainName = "fake-domain"
+	Username   = "fake-user"
+	Password   = "fake-password"
+
+	// ProjectID is the project the provider is configured with.
+	ProjectID = "4b7e4f2c69b74c0d8b9e1c7f5a3d2e10"
+
+	// ProjectName and ProjectThemeColor are the name and the theme color of the project
+	// in the Resell API.
+	ProjectName       = "fake-project"
+	ProjectThemeColor = "2753E9"
+
+	// Region is the region of the services in the catalog.
+	Region = "ru-1"
+
+	// CrossRegion is the other region of the Resell API in the catalog,
+	// cross-region subnets connect it with Region.
+	CrossRegion = "ru-3"
+)
+
+// Server is the fake API server.
+type Server struct {
+	*httptest.Server
+
+	// PendingPolls is the number of reads which return the pending status of
+	// a created or updated object before it becomes active.
+	PendingPolls int
+
+	mu      sync.Mutex
+	seq     int
+	tokens  map[string]time.Time
+	objects map[string]map[string]*object
+	order   map[string][]string
+}
+
+// object is a stored object of one of the APIs.
+type object struct {
+	data map[string]interface{}
+
+	// pendingPolls is the number of reads left before the object becomes active.
+	pendingPolls int
+	activeStatus string
+}
+
+// New starts the fake API server which is closed when the test finishes.
+func New(t testing.TB) *Server {
+	t.Helper()
+
+	s := &Server{
+		tokens:  map[string]time.Time{},
+		objects: map[string]map[string]*object{},
+		order:   map[string][]string{},
+	}
+
+	mux := http.NewServeMux()
+	s.registerKeystone(mux)
+	s.registerQuotaManager(mux)
+	s.registerMKS(mux)
+	s.registerDBaaS(mux)
+	s.registerCRaaS(mux)
+	s.registerDomains(mux)
+	s.registerResell(mux)
+	s.registerIAM(mux)
+	s.registerSecretsManager(mux)
+
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// AuthURL returns the Keystone v3 URL.
+func (s *Server) AuthURL() string {
+	return s.URL + "/identity/v3/"
+}
+
+// DomainsURL returns the URL of the Domains v2 API, which is not in the Keystone catalog.
+func (s *Server) DomainsURL() string {
+	return s.URL + "/domains/v2"
+}
+
+// ProviderConfig returns the provider block that points the provider to the server.
+func (s *Server) ProviderConfig() string {
+	return fmt.Sprintf(`
+provider "selectel" {
+  auth_url    = %q
+  auth_region = %q
+  domain_name = %q
+  username    = %q
+  password    = %q
+  project_id  = %q
+  region      = %q
+
+  endpoints {
+    domains = %q
+  }
+}
+`, s.AuthURL(), Region, DomainName, Username, Password, ProjectID, Region, s.DomainsURL())
+}
+
+// Len returns the number of stored objects of the kind, for example "mks/clusters".
+func (s *Server) Len(kind string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.objects[kind])
+}
+
+func (s *Server) newID() string {
+	s.seq++
+
+	return fmt.Sprintf("%08x-0000-4000-8000-%012x", s.seq, s.seq)
+}
+
+// nextNumber returns a new numeric ID for the APIs that don't use UUIDs.
+func (s *Server) nextNumber() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.seq++
+
+	return s.seq
+}
+
+// create stores a copy of the object with a new ID. The object has the pending status
+// until it has been read PendingPolls times.
+func (s *Server) create(kind string, data map[string]interface{}, pendingStatus, activeStatus string) map[string]interface{} {
+	s.mu.Lock()
+	id := s.newID()
+	s.mu.Unlock()
+
+	return s.createWithID(kind, id, data, pendingStatus, activeStatus)
+}
+
+// createWithID stores a copy of the object with the given ID.
+func (s *Server) createWithID(kind, id string, data map[string]interface{}, pendingStatus, activeStatus string) map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data["id"] = id
+	if s.objects[kind] == nil {
+		s.objects[kind] = map[string]*object{}
+	}
+	obj := &object{data: copyObject(data), activeStatus: activeStatus}
+	s.objects[kind][id] = obj
+	s.order[kind] = append(s.order[kind], id)
+	s.setPending(obj, pendingStatus)
+
+	return copyObject(obj.data)
+}
+
+// get returns the object and settles its status.
+func (s *Server) get(kind, id string) (map[string]interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obj, ok := s.objects[kind][id]
+	if !ok {
+		return nil, false
+	}
+	if obj.pendingPolls > 0 {
+		obj.pendingPolls--
+	} else if obj.activeStatus != "" {
+		obj.data["status"] = obj.activeStatus
+	}
+
+	return copyObject(obj.data), true
+}
+
+// list returns the objects of the kind in the order of creation.
+func (s *Server) list(kind string, filter func(map[string]interface{}) bool) []map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]map[string]interface{}, 0)
+	for _, id := range s.order[kind] {
+		obj, ok := s.objects[kind][id]
+		if !ok {
+			continue
+		}
+		if filter == nil || filter(obj.data) {
+			result = append(result, copyObject(obj.data))
+		}
+	}
+
+	return result
+}
+
+// update merges the patch into the object and sets the pending status.
+func (s *Server) update(kind, id string, patch map[string]interface{}, pendingStatus string) (map[string]interface{}, bool) {
+	return s.modify(kind, id, func(data map[string]interface{}) {
+		merge(data, patch)
+	}, pendingStatus)
+}
+
+// modify changes the object with the function and sets the pending status.
+func (s *Server) modify(kind, id string, change func(data map[string]interface{}), pendingStatus string) (map[string]interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obj, ok := s.objects[kind][id]
+	if !ok {
+		return nil, false
+	}
+	change(obj.data)
+	s.setPending(obj, pendingStatus)
+
+	return copyObject(obj.data), true
+}
+
+// exists reports whether the object is stored without settling its status.
+func (s *Server) exists(kind, id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.objects[kind][id]
+
+	return ok
+}
+
+func (s *Server) delete(kind, id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.objects[kind][id]; !ok {
+		return false
+	}
+	delete(s.objects[kind], id)
+
+	return true
+}
+
+func (s *Server) setPending(obj *object, pendingStatus string) {
+	if pendingStatus == "" {
+		return
+	}
+	obj.data["status"] = pendingStatus
+	obj.pendingPolls = s.PendingPolls
+}
+
+// merge sets the non-null values of the patch to the object. Nested objects are merged recursively.
+func merge(dst, patch map[string]interface{}) {
+	for key, value := range patch {
+		if value == nil {
+			continue
+		}
+		nestedPatch, ok := value.(map[string]interface{})
+		if nested, isMap := dst[key].(map[string]interface{}); ok && isMap {
+			merge(nested, nestedPatch)
+
+			continue
+		}
+		dst[key] = value
+	}
+}
+
+func copyObject(data map[string]interface{}) map[string]interface{} {
+	b, _ := json.Marshal(data)
+	var result map[string]interface{}
+	_ = json.Unmarshal(b, &result)
+
+	return result
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// readJSON decodes the request body. If key is set, the object under the key is returned.
+func readJSON(r *http.Request, key string) (map[string]interface{}, error) {
+	var body map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+	if key == "" {
+		return body, nil
+	}
+	nested, ok := body[key].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%q is missing in the request body", key)
+	}
+
+	return nested, nil
+}
+
+// authenticated rejects the requests without a valid token issued by the fake Keystone.
+func (s *Server) authenticated(onError func(w http.ResponseWriter, status int, message string), next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.validToken(r.Header.Get("X-Auth-Token")) {
+			onError(w, http.StatusUnauthorized, "the token is invalid or expired")
+
+			return
+		}
+		next(w, r)
+	}
+}
@@ -0,0 +1,545 @@
+package fakeapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	craasV1 "github.com/selectel/craas-go/pkg"
+	"github.com/selectel/craas-go/pkg/v1/registry"
+	"github.com/selectel/craas-go/pkg/v1/token"
+	"github.com/selectel/dbaas-go"
+	domainsV2 "github.com/selectel/domains-go/pkg/v2"
+	mksV1 "github.com/selectel/mks-go/pkg/v1"
+	"github.com/selectel/mks-go/pkg/v1/cluster"
+)
+
+func issueTestToken(t *testing.T, s *Server, password string) (string, map[string]interface{}, int) {
+	t.Helper()
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"auth": map[string]interface{}{
+			"identity": map[string]interface{}{
+				"methods": []string{"password"},
+				"password": map[string]interface{}{
+					"user": map[string]interface{}{
+						"name":     Username,
+						"password": password,
+						"domain":   map[string]interface{}{"name": DomainName},
+					},
+				},
+			},
+		},
+	})
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, s.AuthURL()+"auth/tokens", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var token map[string]interface{}
+	_ = json.NewDecoder(resp.Body).Decode(&token)
+
+	return resp.Header.Get("X-Subject-Token"), token, resp.StatusCode
+}
+
+func testToken(t *testing.T, s *Server) string {
+	t.Helper()
+
+	token, _, status := issueTestToken(t, s, Password)
+	if status != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d", http.StatusCreated, status)
+	}
+
+	return token
+}
+
+func TestKeystone(t *testing.T) {
+	s := New(t)
+
+	if _, _, status := issueTestToken(t, s, "wrong"); status != http.StatusUnauthorized {
+		t.Fatalf("expected status %d for wrong password, got %d", http.StatusUnauthorized, status)
+	}
+
+	token, body, status := issueTestToken(t, s, Password)
+	if status != http.StatusCreated || token == "" {
+		t.Fatalf("expected a token with status %d, got %q with status %d", http.StatusCreated, token, status)
+	}
+
+	catalog := body["token"].(map[string]interface{})["catalog"].([]interface{})
+	endpoints := map[string]string{}
+	for _, entry := range catalog {
+		service := entry.(map[string]interface{})
+		endpoint := service["endpoints"].([]interface{})[0].(map[string]interface{})
+		endpoints[service["type"].(string)] = endpoint["url"].(string)
+	}
+	if expected := s.URL + "/mks/ru-1/v1"; endpoints["managed-kubernetes"] != expected {
+		t.Fatalf("expected managed-kubernetes endpoint %q, got %q", expected, endpoints["managed-kubernetes"])
+	}
+	if expected := s.AuthURL(); endpoints["identity"] != expected {
+		t.Fatalf("expected identity endpoint %q, got %q", expected, endpoints["identity"])
+	}
+}
+
+func TestUnauthenticatedRequest(t *testing.T) {
+	s := New(t)
+
+	client := mksV1.NewMKSClientV1("unknown-token", s.URL+"/mks/ru-1/v1")
+	_, resp, err := cluster.List(context.Background(), client)
+	if err == nil || resp == nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected the 401 error, got %v", err)
+	}
+}
+
+func TestMKSCluster(t *testing.T) {
+	ctx := context.Background()
+	s := New(t)
+	s.PendingPolls = 1
+	client := mksV1.NewMKSClientV1(testToken(t, s), s.URL+"/mks/ru-1/v1")
+
+	created, _, err := cluster.Create(ctx, client, &cluster.CreateOpts{
+		Name:                   "cluster",
+		KubeVersion:            MKSKubeVersion,
+		Region:                 Region,
+		MaintenanceWindowStart: "01:00:00",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Status != cluster.StatusPendingCreate {
+		t.Fatalf("expected status %s, got %s", cluster.StatusPendingCreate, created.Status)
+	}
+
+	for _, expected := range []cluster.Status{cluster.StatusPendingCreate, cluster.StatusActive} {
+		c, _, err := cluster.Get(ctx, client, created.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.Status != expected {
+			t.Fatalf("expected status %s, got %s", expected, c.Status)
+		}
+	}
+
+	updated, _, err := cluster.Update(ctx, client, created.ID, &cluster.UpdateOpts{MaintenanceWindowStart: "22:00:00"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Status != cluster.StatusPendingUpdate || updated.MaintenanceWindowEnd != "02:00:00" {
+		t.Fatalf("unexpected cluster after update: %+v", updated)
+	}
+
+	kubeconfig, _, err := cluster.GetParsedKubeconfig(ctx, client, created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kubeconfig.Server != "https://192.0.2.10:6443" || kubeconfig.ClientKey == "" {
+		t.Fatalf("unexpected kubeconfig: %+v", kubeconfig)
+	}
+
+	if _, err := cluster.Delete(ctx, client, created.ID); err != nil {
+		t.Fatal(err)
+	}
+	_, resp, err := cluster.Get(ctx, client, created.ID)
+	if err == nil || resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected the 404 error, got %v", err)
+	}
+}
+
+func TestDBaaSDatastoreAndUser(t *testing.T) {
+	ctx := context.Background()
+	s := New(t)
+	client, err := dbaas.NewDBAASClientV1WithCustomHTTP(nil, testToken(t, s), s.URL+"/dbaas/ru-1/v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.CreateDatastore(ctx, dbaas.DatastoreCreateOpts{
+		Name: "datastore", TypeID: DBaaSPostgreSQLTypeID, FlavorID: "unknown", NodeCount: 1,
+	})
+	var apiErr *dbaas.DBaaSAPIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode() != http.StatusBadRequest {
+		t.Fatalf("expected the 400 API error, got %v", err)
+	}
+
+	datastore, err := client.CreateDatastore(ctx, dbaas.DatastoreCreateOpts{
+		Name: "datastore", TypeID: DBaaSPostgreSQLTypeID, FlavorID: DBaaSFlavorID, NodeCount: 2,
+		Config: map[string]interface{}{"work_mem": "4096"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if datastore.Flavor.Vcpus != 2 || len(datastore.Instances) != 2 || datastore.Pooler.Mode != "transaction" {
+		t.Fatalf("unexpected datastore: %+v", datastore)
+	}
+
+	if _, err := client.ConfigDatastore(ctx, datastore.ID, dbaas.DatastoreConfigOpts{
+		Config: map[string]interface{}{"work_mem": nil, "max_connections": 200},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	datastore, err = client.Datastore(ctx, datastore.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if datastore.Status != dbaas.StatusActive || len(datastore.Config) != 1 || datastore.Config["max_connections"] != 200.0 {
+		t.Fatalf("unexpected datastore after config update: %+v", datastore)
+	}
+
+	user, err := client.CreateUser(ctx, dbaas.UserCreateOpts{Name: "user", Password: "secret", DatastoreID: datastore.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.CreateUser(ctx, dbaas.UserCreateOpts{Name: "user", Password: "secret", DatastoreID: datastore.ID}); err == nil {
+		t.Fatal("expected an error for the duplicate user")
+	}
+
+	if err := client.DeleteDatastore(ctx, datastore.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.User(ctx, user.ID); !errors.As(err, &apiErr) || apiErr.StatusCode() != http.StatusNotFound {
+		t.Fatalf("expected the user to be deleted with the datastore, got %v", err)
+	}
+}
+
+func TestCRaaSRegistry(t *testing.T) {
+	ctx := context.Background()
+	s := New(t)
+	client := craasV1.NewCRaaSClientV1(testToken(t, s), s.URL+"/craas/v1")
+
+	created, _, err := registry.Create(ctx, client, "registry")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, resp, err := registry.Create(ctx, client, "registry"); err == nil || resp.StatusCode != http.StatusConflict {
+		t.Fatalf("expected the 409 error, got %v", err)
+	}
+
+	registries, _, err := registry.List(ctx, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(registries) != 1 || registries[0].ID != created.ID {
+		t.Fatalf("unexpected registries: %+v", registries)
+	}
+
+	found, _, err := registry.Get(ctx, client, created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.Status != registry.StatusActive || found.CreatedAt.IsZero() {
+		t.Fatalf("unexpected registry: %+v", found)
+	}
+
+	if _, err := registry.Delete(ctx, client, created.ID); err != nil {
+		t.Fatal(err)
+	}
+	if s.Len(KindCRaaSRegistries) != 0 {
+		t.Fatal("expected the registry to be deleted")
+	}
+}
+
+func TestCRaaSToken(t *testing.T) {
+	ctx := context.Background()
+	s := New(t)
+	client := craasV1.NewCRaaSClientV1(testToken(t, s), s.URL+"/craas/v1")
+
+	created, _, err := token.Create(ctx, client, &token.CreateOpts{TokenTTL: token.TTL12Hours})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Token == "" || created.ExpiresIn != 12*60*60 {
+		t.Fatalf("unexpected token: %+v", created)
+	}
+
+	found, _, err := token.Get(ctx, client, created.Token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.Token != created.Token {
+		t.Fatalf("expected token %s, got %s", created.Token, found.Token)
+	}
+
+	if _, err := token.Revoke(ctx, client, created.Token); err != nil {
+		t.Fatal(err)
+	}
+	if resp, err := token.Revoke(ctx, client, created.Token); err == nil || resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected the 404 error, got %v", err)
+	}
+}
+
+func TestDomainsZoneAndRRSet(t *testing.T) {
+	ctx := context.Background()
+	s := New(t)
+	headers := http.Header{}
+	headers.Add("X-Auth-Token", testToken(t, s))
+	client := domainsV2.NewClient(s.DomainsURL(), http.DefaultClient, headers)
+
+	zone, err := client.CreateZone(ctx, &domainsV2.Zone{Name: "example.com."})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.CreateZone(ctx, &domainsV2.Zone{Name: "other.org."}); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.UpdateZoneComment(ctx, zone.ID, "comment"); err != nil {
+		t.Fatal(err)
+	}
+
+	zones, err := client.ListZones(ctx, &map[string]string{"filter": "example"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if zones.GetCount() != 1 || zones.GetItems()[0].Comment != "comment" {
+		t.Fatalf("unexpected zones: %+v", zones.GetItems())
+	}
+
+	_, err = client.CreateRRSet(ctx, zone.ID, &domainsV2.RRSet{Name: "www.example.com.", Type: domainsV2.A, TTL: 1})
+	var badResponse *domainsV2.BadResponseError
+	if !errors.As(err, &badResponse) || badResponse.Location != "body.ttl" {
+		t.Fatalf("expected the error of the ttl, got %v", err)
+	}
+
+	rrset, err := client.CreateRRSet(ctx, zone.ID, &domainsV2.RRSet{
+		Name: "www.example.com.", Type: domainsV2.A, TTL: 60,
+		Records: []domainsV2.RecordItem{{Content: "192.0.2.1"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.UpdateRRSet(ctx, zone.ID, rrset.ID, &domainsV2.RRSet{
+		TTL: 120, Records: []domainsV2.RecordItem{{Content: "192.0.2.2"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	rrsets, err := client.ListRRSets(ctx, zone.ID, &map[string]string{"name": "www", "rrset_types": "A,AAAA"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rrsets.GetCount() != 1 || rrsets.GetItems()[0].TTL != 120 || rrsets.GetItems()[0].Records[0].Content != "192.0.2.2" {
+		t.Fatalf("unexpected rrsets: %+v", rrsets.GetItems())
+	}
+
+	if err := client.DeleteZone(ctx, zone.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetRRSet(ctx, zone.ID, rrset.ID); !errors.Is(err, domainsV2.ErrNotFound) {
+		t.Fatalf("expected the rrset to be deleted with the zone, got %v", err)
+	}
+}
+
+// doTestRequest sends the request with the JSON body and returns the decoded response body.
+func doTestRequest(t *testing.T, token, method, url string, body interface{}) (map[string]interface{}, int) {
+	t.Helper()
+
+	var reqBody bytes.Buffer
+	if body != nil {
+		_ = json.NewEncoder(&reqBody).Encode(body)
+	}
+	req, err := http.NewRequestWithContext(context.Background(), method, url, &reqBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("X-Auth-Token", token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var result map[string]interface{}
+	_ = json.NewDecoder(resp.Body).Decode(&result)
+
+	return result, resp.StatusCode
+}
+
+func TestResellCrossRegionSubnet(t *testing.T) {
+	s := New(t)
+	token := testToken(t, s)
+
+	do := func(method, path string, body interface{}) (map[string]interface{}, int) {
+		t.Helper()
+
+		return doTestRequest(t, token, method, s.URL+"/resell/v2/"+path, body)
+	}
+
+	_, status := do(http.MethodPost, "cross_region_subnets/projects/"+ProjectID, map[string]interface{}{
+		"cross_region_subnets": []interface{}{
+			map[string]interface{}{"quantity": 1, "cidr": "192.168.200.0/24", "regions": []interface{}{
+				map[string]interface{}{"region": "ru-1"},
+			}},
+		},
+	})
+	if status != http.StatusBadRequest {
+		t.Fatalf("expected status %d for a single region, got %d", http.StatusBadRequest, status)
+	}
+
+	created, status := do(http.MethodPost, "cross_region_subnets/projects/"+ProjectID, map[string]interface{}{
+		"cross_region_subnets": []interface{}{
+			map[string]interface{}{"quantity": 1, "cidr": "192.168.200.0/24", "regions": []interface{}{
+				map[string]interface{}{"region": "ru-1"},
+				map[string]interface{}{"region": CrossRegion},
+			}},
+		},
+	})
+	if status != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, status)
+	}
+	subnet := created["cross_region_subnets"].([]interface{})[0].(map[string]interface{})
+	id, ok := subnet["id"].(float64)
+	if !ok {
+		t.Fatalf("expected a numeric id, got %v", subnet["id"])
+	}
+	regional := subnet["subnets"].([]interface{})
+	if len(regional) != 2 || regional[1].(map[string]interface{})["region"] != CrossRegion ||
+		regional[1].(map[string]interface{})["vlan_id"] != subnet["vlan_id"] {
+		t.Fatalf("unexpected regional subnets: %v", regional)
+	}
+
+	path := fmt.Sprintf("cross_region_subnets/%d", int(id))
+	got, status := do(http.MethodGet, path, nil)
+	if status != http.StatusOK || got["cross_region_subnet"].(map[string]interface{})["cidr"] != "192.168.200.0/24" {
+		t.Fatalf("unexpected cross-region subnet %v with status %d", got, status)
+	}
+
+	if _, status := do(http.MethodDelete, path, nil); status != http.StatusNoContent {
+		t.Fatalf("expected status %d, got %d", http.StatusNoContent, status)
+	}
+	if _, status := do(http.MethodGet, path, nil); status != http.StatusNotFound {
+		t.Fatalf("expected status %d after delete, got %d", http.StatusNotFound, status)
+	}
+	if n := s.Len(KindResellCrossRegionSubnets); n != 0 {
+		t.Fatalf("expected no cross-region subnets, got %d", n)
+	}
+}
+
+func TestQuotaManagerProjectQuotas(t *testing.T) {
+	s := New(t)
+	token := testToken(t, s)
+	url := s.URL + "/quota-manager/ru-1/projects/" + ProjectID + "/quotas"
+
+	_, status := doTestRequest(t, token, http.MethodPatch, url, map[string]interface{}{
+		"quotas": map[string]interface{}{
+			"unknown": []interface{}{map[string]interface{}{"value": 1}},
+		},
+	})
+	if status != http.StatusBadRequest {
+		t.Fatalf("expected status %d for an unknown resource, got %d", http.StatusBadRequest, status)
+	}
+
+	_, status = doTestRequest(t, token, http.MethodPatch, url, map[string]interface{}{
+		"quotas": map[string]interface{}{
+			"compute_cores": []interface{}{map[string]interface{}{"zone": "ru-1a", "value": 4}},
+		},
+	})
+	if status != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, status)
+	}
+
+	got, status := doTestRequest(t, token, http.MethodGet, url, nil)
+	if status != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, status)
+	}
+	cores := got["quotas"].(map[string]interface{})["compute_cores"].([]interface{})
+	for _, rawEntity := range cores {
+		entity := rawEntity.(map[string]interface{})
+		expected := 0.0
+		if entity["zone"] == "ru-1a" {
+			expected = 4
+		}
+		if entity["value"] != expected {
+			t.Fatalf("unexpected compute_cores quota: %v", entity)
+		}
+	}
+
+	s.SetQuotaUsage(ProjectID, "ru-1", "compute_cores", "ru-1a", 6)
+	_, status = doTestRequest(t, token, http.MethodPatch, url, map[string]interface{}{
+		"quotas": map[string]interface{}{
+			"compute_cores": []interface{}{map[string]interface{}{"zone": "ru-1a", "value": 5}},
+		},
+	})
+	if status != http.StatusBadRequest {
+		t.Fatalf("expected status %d for a value lower than the usage, got %d", http.StatusBadRequest, status)
+	}
+
+	// Quotas are stored per project and region.
+	got, _ = doTestRequest(t, token, http.MethodGet, s.URL+"/quota-manager/ru-1/projects/other/quotas", nil)
+	for _, rawEntity := range got["quotas"].(map[string]interface{})["compute_cores"].([]interface{}) {
+		if rawEntity.(map[string]interface{})["value"] != 0.0 {
+			t.Fatalf("unexpected compute_cores quota of another project: %v", rawEntity)
+		}
+	}
+}
+
+func TestResellProject(t *testing.T) {
+	s := New(t)
+	token := testToken(t, s)
+	s.SetQuotaUsage(ProjectID, CrossRegion, "compute_cores", CrossRegion+"a", 2)
+
+	got, status := doTestRequest(t, token, http.MethodGet, s.URL+"/resell/v2/projects", nil)
+	if status != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, status)
+	}
+	projects := got["projects"].([]interface{})
+	if len(projects) != 1 || projects[0].(map[string]interface{})["name"] != ProjectName {
+		t.Fatalf("unexpected projects: %v", projects)
+	}
+
+	got, status = doTestRequest(t, token, http.MethodGet, s.URL+"/resell/v2/projects/"+ProjectID, nil)
+	if status != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, status)
+	}
+	cores := got["project"].(map[string]interface{})["quotas"].(map[string]interface{})["compute_cores"].([]interface{})
+	if len(cores) != 4 {
+		t.Fatalf("expected compute_cores quotas in 4 zones of 2 regions, got %v", cores)
+	}
+	for _, rawEntity := range cores {
+		entity := rawEntity.(map[string]interface{})
+		if entity["zone"] == CrossRegion+"a" && (entity["region"] != CrossRegion || entity["used"] != 2.0) {
+			t.Fatalf("unexpected compute_cores quota: %v", entity)
+		}
+	}
+
+	if _, status := doTestRequest(t, token, http.MethodGet, s.URL+"/resell/v2/projects/other", nil); status != http.StatusNotFound {
+		t.Fatalf("expected status %d for an unknown project, got %d", http.StatusNotFound, status)
+	}
+}
+
+func TestIAMUserRoles(t *testing.T) {
+	s := New(t)
+	token := testToken(t, s)
+	userID := s.AddIAMUser(KindIAMServiceUsers)
+	url := s.URL + "/iam/v1/service_users/" + userID + "/roles"
+	projectRole := map[string]interface{}{"role_name": "member", "scope": "project", "project_id": ProjectID}
+
+	for _, role := range []interface{}{map[string]interface{}{"role_name": "reader", "scope": "account"}, projectRole} {
+		_, status := doTestRequest(t, token, http.MethodPut, url, map[string]interface{}{"roles": []interface{}{role}})
+		if status != http.StatusNoContent {
+			t.Fatalf("expected status %d, got %d", http.StatusNoContent, status)
+		}
+	}
+	if roles := s.IAMUserRoles(KindIAMServiceUsers, userID); len(roles) != 2 {
+		t.Fatalf("expected 2 roles, got %v", roles)
+	}
+
+	_, status := doTestRequest(t, token, http.MethodDelete, url, map[string]interface{}{"roles": []interface{}{projectRole}})
+	if status != http.StatusNoContent {
+		t.Fatalf("expected status %d, got %d", http.StatusNoContent, status)
+	}
+	if roles := s.IAMUserRoles(KindIAMServiceUsers, userID); len(roles) != 1 || roles[0] != "account//reader" {
+		t.Fatalf("unexpected roles: %v", roles)
+	}
+
+	got, status := doTestRequest(t, token, http.MethodGet, s.URL+"/iam/v1/users/"+userID, nil)
+	if status != http.StatusNotFound || got["code"] != "USER_NOT_FOUND" {
+		t.Fatalf("expected USER_NOT_FOUND for a service user in users, got %v with status %d", got, status)
+	}
+}
@@ -0,0 +1,254 @@
+// Package recorder records HTTP interactions with Selectel APIs into cassettes
+// and replays them, so acceptance tests can run offline and deterministically.
+//
+// A cassette also keeps the seed of random names and the time of the recording,
+// because requests must be the same on replay as they were on recording.
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Mode is the mode of the recorder.
+type Mode string
+
+const (
+	// ModeRecord sends requests to APIs and records the interactions.
+	ModeRecord Mode = "record"
+
+	// ModeReplay responds with the recorded interactions without sending requests.
+	ModeReplay Mode = "replay"
+)
+
+// ErrNoInteraction is returned on replay when the request has not been recorded.
+var ErrNoInteraction = errors.New("no recorded interaction")
+
+// Cassette is the recording of a test.
+type Cassette struct {
+	// Seed is the seed of random names of the test.
+	Seed int64 `json:"seed"`
+
+	// RecordedAt is the time the recording started at.
+	RecordedAt time.Time `json:"recorded_at"`
+
+	// Env contains environment variables the test was recorded with.
+	Env map[string]string `json:"env,omitempty"`
+
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a request and the response to it.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded HTTP request.
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Response is a recorded HTTP response.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// key identifies the requests which are answered with the same interactions.
+func (r *Request) key() string {
+	return r.Method + " " + r.URL + "\n" + r.Body
+}
+
+// Recorder is a transport that records or replays the cassette.
+type Recorder struct {
+	mode     Mode
+	path     string
+	next     http.RoundTripper
+	scrub    func(*Interaction)
+	cassette *Cassette
+
+	mu sync.Mutex
+	// replayed is the number of replayed interactions of every request key.
+	replayed map[string]int
+	byKey    map[string][]*Interaction
+}
+
+// New returns the recorder of the cassette at the path. On recording, requests are sent
+// with the next transport and the cassette is written by Save. On replay, the cassette
+// must exist. Every interaction is passed to scrub before it is stored or matched, so
+// secrets don't get into cassettes, and requests with scrubbed values still match.
+func New(path string, mode Mode, next http.RoundTripper, scrub func(*Interaction)) (*Recorder, error) {
+	if scrub == nil {
+		scrub = func(*Interaction) {}
+	}
+	r := &Recorder{
+		mode:     mode,
+		path:     path,
+		next:     next,
+		scrub:    scrub,
+		replayed: map[string]int{},
+		byKey:    map[string][]*Interaction{},
+	}
+
+	switch mode {
+	case ModeRecord:
+		r.cassette = &Cassette{
+			Seed:       time.Now().UnixNano(),
+			RecordedAt: time.Now().UTC().Truncate(time.Second),
+			Env:        map[string]string{},
+		}
+	case ModeReplay:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("can't read cassette: %w", err)
+		}
+		r.cassette = &Cassette{}
+		if err := json.Unmarshal(data, r.cassette); err != nil {
+			return nil, fmt.Errorf("can't parse cassette %s: %w", path, err)
+		}
+		for _, interaction := range r.cassette.Interactions {
+			key := interaction.Request.key()
+			r.byKey[key] = append(r.byKey[key], interaction)
+		}
+	default:
+		return nil, fmt.Errorf("unknown recorder mode %q, expected %q or %q", mode, ModeRecord, ModeReplay)
+	}
+
+	return r, nil
+}
+
+// Mode returns the mode of the recorder.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Cassette returns the cassette being recorded or replayed.
+func (r *Recorder) Cassette() *Cassette {
+	return r.cassette
+}
+
+// Save writes the recorded cassette. It does nothing on replay.
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.path, append(data, '\n'), 0o600)
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	if reqBody != nil {
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+	interaction := &Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Header: req.Header.Clone(),
+			Body:   string(reqBody),
+		},
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, interaction)
+	}
+
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := readBody(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	interaction.Response = Response{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header.Clone(),
+		Body:       string(respBody),
+	}
+	r.scrub(interaction)
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// replay responds with the next recorded interaction of the request. When the recorded
+// interactions run out, the last one is replayed again: for example, a token may be
+// re-issued more times than on recording, because the recorded token has expired.
+func (r *Recorder) replay(req *http.Request, interaction *Interaction) (*http.Response, error) {
+	r.scrub(interaction)
+	key := interaction.Request.key()
+
+	r.mu.Lock()
+	recorded := r.byKey[key]
+	i := min(r.replayed[key], len(recorded)-1)
+	r.replayed[key]++
+	r.mu.Unlock()
+
+	if len(recorded) == 0 {
+		return nil, fmt.Errorf("%w for %s %s in %s", ErrNoInteraction, req.Method, req.URL.Redacted(), r.path)
+	}
+
+	response := recorded[i].Response
+	header := response.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set("Content-Length", strconv.Itoa(len(response.Body)))
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", response.StatusCode, http.StatusText(response.StatusCode)),
+		StatusCode:    response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader([]byte(response.Body))),
+		ContentLength: int64(len(response.Body)),
+		Request:       req,
+	}, nil
+}
+
+// readBody reads and closes the body.
+func readBody(body io.ReadCloser) ([]byte, error) {
+	if body == nil || body == http.NoBody {
+		return nil, nil
+	}
+	defer body.Close()
+
+	return io.ReadAll(body)
+}
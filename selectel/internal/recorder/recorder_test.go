@@ -0,0 +1,118 @@
+package recorder
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testScrub(interaction *Interaction) {
+	if interaction.Request.Header.Get("X-Auth-Token") != "" {
+		interaction.Request.Header.Set("X-Auth-Token", "REDACTED")
+	}
+	interaction.Request.Body = strings.ReplaceAll(interaction.Request.Body, "secret", "REDACTED")
+}
+
+func testPost(t *testing.T, client *http.Client, url, body string) (int, string) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("X-Auth-Token", "token-"+body)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return resp.StatusCode, string(data)
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, r.URL.Path+" "+strings.Repeat("x", calls))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassettes", "test.json")
+	rec, err := New(path, ModeRecord, http.DefaultTransport, testScrub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.Cassette().Env["OS_REGION_NAME"] = "ru-1"
+	client := &http.Client{Transport: rec}
+
+	for _, expected := range []string{"/a x", "/a xx", "/b xxx"} {
+		path := strings.Fields(expected)[0]
+		if status, body := testPost(t, client, server.URL+path, "secret"); status != http.StatusCreated || body != expected {
+			t.Fatalf("expected %q, got %d %q", expected, status, body)
+		}
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "secret") {
+		t.Fatalf("expected the secrets to be scrubbed from the cassette:\n%s", data)
+	}
+
+	replay, err := New(path, ModeReplay, nil, testScrub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replay.Cassette().Seed != rec.Cassette().Seed || replay.Cassette().Env["OS_REGION_NAME"] != "ru-1" {
+		t.Fatalf("unexpected replayed cassette: %+v", replay.Cassette())
+	}
+	client = &http.Client{Transport: replay}
+
+	// The last interaction of the request is replayed again when the recorded ones run out.
+	for _, expected := range []string{"/a x", "/a xx", "/a xx", "/b xxx"} {
+		path := strings.Fields(expected)[0]
+		if status, body := testPost(t, client, server.URL+path, "secret"); status != http.StatusCreated || body != expected {
+			t.Fatalf("expected %q, got %d %q", expected, status, body)
+		}
+	}
+	if calls != 3 {
+		t.Fatalf("expected no requests to the server on replay, got %d requests in total", calls)
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL+"/a", strings.NewReader("other"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Do(req); !errors.Is(err, ErrNoInteraction) { //nolint:bodyclose
+		t.Fatalf("expected ErrNoInteraction for the request with another body, got %v", err)
+	}
+}
+
+func TestReplayWithoutCassette(t *testing.T) {
+	if _, err := New(filepath.Join(t.TempDir(), "missing.json"), ModeReplay, nil, nil); err == nil {
+		t.Fatal("expected an error for the missing cassette")
+	}
+}
+
+func TestUnknownMode(t *testing.T) {
+	if _, err := New("cassette.json", Mode("unknown"), nil, nil); err == nil {
+		t.Fatal("expected an error for the unknown mode")
+	}
+}
@@ -0,0 +1,229 @@
+// Package waiter waits for objects of Selectel APIs to reach the target state.
+//
+// It replaces resource.StateChangeConf of the Terraform SDK with typed states,
+// exponential backoff between polls, early failure on terminal states and
+// logging of the progress.
+package waiter
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultMinInterval is the interval before the second poll if MinInterval is not set.
+	DefaultMinInterval = 3 * time.Second
+
+	// DefaultMaxInterval is the maximum interval between polls if MaxInterval is not set.
+	DefaultMaxInterval = 30 * time.Second
+)
+
+// Clock provides the time to the waiter, so it can be faked in tests.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// DefaultClock is the clock of waiters that don't set Clock. Tests that replay recorded
+// API responses replace it with InstantClock to skip the intervals between polls.
+var DefaultClock Clock = realClock{}
+
+// InstantClock is a clock that doesn't sleep: every wait fires at once and moves the time forward.
+type InstantClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewInstantClock returns InstantClock starting at the given time.
+func NewInstantClock(start time.Time) *InstantClock {
+	return &InstantClock{now: start}
+}
+
+func (c *InstantClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *InstantClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+
+	return ch
+}
+
+// RefreshFunc returns the actual object and its state.
+type RefreshFunc[T any, S ~string] func(ctx context.Context) (T, S, error)
+
+// StateConf is the configuration of waiting for an object of type T with states of type S.
+type StateConf[T any, S ~string] struct {
+	// Object describes the object in logs and errors, for example "datastore 0a2b3c".
+	Object string
+
+	// Pending states are the states in which the waiter keeps polling the object.
+	Pending []S
+
+	// Target states finish waiting successfully.
+	Target []S
+
+	// Failure states are terminal error states that finish waiting with FailureStateError
+	// without waiting for the timeout.
+	Failure []S
+
+	Refresh RefreshFunc[T, S]
+
+	// Timeout is the maximum time of waiting. Zero means no timeout.
+	Timeout time.Duration
+
+	// Delay is the time to wait before the first poll.
+	Delay time.Duration
+
+	// MinInterval is the interval before the second poll. Every next interval is
+	// twice as long as the previous one, but not longer than MaxInterval.
+	MinInterval time.Duration
+	MaxInterval time.Duration
+
+	// Clock is DefaultClock if not set.
+	Clock Clock
+}
+
+// FailureStateError is returned when the object reaches one of the failure states.
+type FailureStateError struct {
+	Object string
+	State  string
+}
+
+func (e *FailureStateError) Error() string {
+	return fmt.Sprintf("%s reached the failure state %s", e.Object, e.State)
+}
+
+// UnexpectedStateError is returned when the object reaches a state that is not
+// in the pending, target or failure states.
+type UnexpectedStateError struct {
+	Object   string
+	State    string
+	Expected []string
+}
+
+func (e *UnexpectedStateError) Error() string {
+	return fmt.Sprintf("%s reached the unexpected state %q, wanted one of: %q", e.Object, e.State, e.Expected)
+}
+
+// TimeoutError is returned when the object doesn't reach the target state within the timeout.
+type TimeoutError struct {
+	Object    string
+	LastState string
+	Timeout   time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("timeout while waiting for %s to leave the state %s (timeout: %s)", e.Object, e.LastState, e.Timeout)
+}
+
+// WaitForState polls the object until it reaches one of the target states and returns it.
+func (c *StateConf[T, S]) WaitForState(ctx context.Context) (T, error) {
+	var zero T
+
+	clock := c.Clock
+	if clock == nil {
+		clock = DefaultClock
+	}
+	interval := c.MinInterval
+	if interval <= 0 {
+		interval = DefaultMinInterval
+	}
+	maxInterval := c.MaxInterval
+	if maxInterval <= 0 {
+		maxInterval = DefaultMaxInterval
+	}
+	if maxInterval < interval {
+		maxInterval = interval
+	}
+
+	start := clock.Now()
+	deadline := start.Add(c.Timeout)
+
+	if err := c.sleep(ctx, clock, c.Delay); err != nil {
+		return zero, err
+	}
+
+	var (
+		lastState  S
+		stateSince time.Time
+	)
+	for {
+		object, state, err := c.Refresh(ctx)
+		if err != nil {
+			return object, err
+		}
+
+		now := clock.Now()
+		if stateSince.IsZero() || state != lastState {
+			lastState = state
+			stateSince = now
+		}
+
+		switch {
+		case slices.Contains(c.Target, state):
+			log.Printf("[DEBUG] %s reached the state %s in %s", c.Object, state, now.Sub(start).Round(time.Second))
+
+			return object, nil
+		case slices.Contains(c.Failure, state):
+			return object, &FailureStateError{Object: c.Object, State: string(state)}
+		case !slices.Contains(c.Pending, state):
+			return object, &UnexpectedStateError{Object: c.Object, State: string(state), Expected: c.expected()}
+		}
+
+		log.Printf("[INFO] %s %s for %s", c.Object, state, now.Sub(stateSince).Round(time.Second))
+
+		wait := interval
+		if c.Timeout > 0 {
+			remaining := deadline.Sub(now)
+			if remaining <= 0 {
+				return object, &TimeoutError{Object: c.Object, LastState: string(state), Timeout: c.Timeout}
+			}
+			wait = min(wait, remaining)
+		}
+		if err := c.sleep(ctx, clock, wait); err != nil {
+			return object, err
+		}
+		interval = min(interval*2, maxInterval)
+	}
+}
+
+func (c *StateConf[T, S]) sleep(ctx context.Context, clock Clock, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("stopped waiting for %s: %w", c.Object, ctx.Err())
+	case <-clock.After(d):
+		return nil
+	}
+}
+
+func (c *StateConf[T, S]) expected() []string {
+	expected := make([]string, 0, len(c.Pending)+len(c.Target))
+	for _, state := range append(slices.Clone(c.Pending), c.Target...) {
+		expected = append(expected, string(state))
+	}
+
+	return expected
+}
@@ -0,0 +1,247 @@
+package waiter
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"os"
+	"testing"
+	"time"
+)
+
+type testStatus string
+
+const (
+	testStatusPending  testStatus = "PENDING_CREATE"
+	testStatusResizing testStatus = "RESIZING"
+	testStatusActive   testStatus = "ACTIVE"
+	testStatusError    testStatus = "ERROR"
+)
+
+// fakeClock advances the time instantly on every wait and records the waits.
+type fakeClock struct {
+	now   time.Time
+	waits []time.Duration
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.waits = append(c.waits, d)
+	c.now = c.now.Add(d)
+
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+
+	return ch
+}
+
+// testRefresh returns the states one by one and repeats the last one.
+func testRefresh(states ...testStatus) (RefreshFunc[string, testStatus], *int) {
+	calls := 0
+
+	return func(context.Context) (string, testStatus, error) {
+		state := states[min(calls, len(states)-1)]
+		calls++
+
+		return "object", state, nil
+	}, &calls
+}
+
+func testStateConf(clock Clock, refresh RefreshFunc[string, testStatus]) *StateConf[string, testStatus] {
+	return &StateConf[string, testStatus]{
+		Object:      "datastore 123",
+		Pending:     []testStatus{testStatusPending, testStatusResizing},
+		Target:      []testStatus{testStatusActive},
+		Failure:     []testStatus{testStatusError},
+		Refresh:     refresh,
+		Timeout:     time.Hour,
+		Delay:       10 * time.Second,
+		MinInterval: time.Second,
+		MaxInterval: 4 * time.Second,
+		Clock:       clock,
+	}
+}
+
+func assertWaits(t *testing.T, expected, actual []time.Duration) {
+	t.Helper()
+
+	if len(expected) != len(actual) {
+		t.Fatalf("expected waits %v, got %v", expected, actual)
+	}
+	for i := range expected {
+		if expected[i] != actual[i] {
+			t.Fatalf("expected waits %v, got %v", expected, actual)
+		}
+	}
+}
+
+func TestWaitForStateReachesTarget(t *testing.T) {
+	clock := newFakeClock()
+	refresh, calls := testRefresh(
+		testStatusPending, testStatusPending, testStatusResizing, testStatusResizing, testStatusResizing, testStatusActive,
+	)
+
+	object, err := testStateConf(clock, refresh).WaitForState(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if object != "object" {
+		t.Fatalf("expected the refreshed object, got %q", object)
+	}
+	if *calls != 6 {
+		t.Fatalf("expected 6 refresh calls, got %d", *calls)
+	}
+
+	// The delay and then exponential backoff limited by MaxInterval.
+	assertWaits(t, []time.Duration{
+		10 * time.Second, time.Second, 2 * time.Second, 4 * time.Second, 4 * time.Second, 4 * time.Second,
+	}, clock.waits)
+}
+
+func TestWaitForStateFailsEarlyOnFailureState(t *testing.T) {
+	clock := newFakeClock()
+	refresh, calls := testRefresh(testStatusPending, testStatusError, testStatusActive)
+
+	_, err := testStateConf(clock, refresh).WaitForState(context.Background())
+
+	var failureErr *FailureStateError
+	if !errors.As(err, &failureErr) {
+		t.Fatalf("expected FailureStateError, got %v", err)
+	}
+	if failureErr.State != string(testStatusError) {
+		t.Fatalf("expected failure state %s, got %s", testStatusError, failureErr.State)
+	}
+	if err.Error() != "datastore 123 reached the failure state ERROR" {
+		t.Fatalf("unexpected error message: %s", err)
+	}
+	if *calls != 2 {
+		t.Fatalf("expected 2 refresh calls, got %d", *calls)
+	}
+}
+
+func TestWaitForStateUnexpectedState(t *testing.T) {
+	refresh, _ := testRefresh("DELETED")
+
+	_, err := testStateConf(newFakeClock(), refresh).WaitForState(context.Background())
+
+	var unexpectedErr *UnexpectedStateError
+	if !errors.As(err, &unexpectedErr) {
+		t.Fatalf("expected UnexpectedStateError, got %v", err)
+	}
+	expected := `datastore 123 reached the unexpected state "DELETED", wanted one of: ["PENDING_CREATE" "RESIZING" "ACTIVE"]`
+	if err.Error() != expected {
+		t.Fatalf("expected error %q, got %q", expected, err)
+	}
+}
+
+func TestWaitForStateTimeout(t *testing.T) {
+	clock := newFakeClock()
+	refresh, calls := testRefresh(testStatusResizing)
+
+	stateConf := testStateConf(clock, refresh)
+	stateConf.Delay = 0
+	stateConf.Timeout = 10 * time.Second
+	stateConf.MinInterval = 3 * time.Second
+	stateConf.MaxInterval = time.Minute
+
+	_, err := stateConf.WaitForState(context.Background())
+
+	var timeoutErr *TimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("expected TimeoutError, got %v", err)
+	}
+	if timeoutErr.LastState != string(testStatusResizing) {
+		t.Fatalf("expected last state %s, got %s", testStatusResizing, timeoutErr.LastState)
+	}
+	// The last wait is cut to the deadline and the object is polled once more.
+	assertWaits(t, []time.Duration{3 * time.Second, 6 * time.Second, time.Second}, clock.waits)
+	if *calls != 4 {
+		t.Fatalf("expected 4 refresh calls, got %d", *calls)
+	}
+}
+
+func TestWaitForStateRefreshError(t *testing.T) {
+	refreshErr := errors.New("connection refused")
+	stateConf := testStateConf(newFakeClock(), func(context.Context) (string, testStatus, error) {
+		return "", "", refreshErr
+	})
+
+	_, err := stateConf.WaitForState(context.Background())
+	if !errors.Is(err, refreshErr) {
+		t.Fatalf("expected refresh error, got %v", err)
+	}
+}
+
+func TestWaitForStateContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	refresh, calls := testRefresh(testStatusPending)
+	stateConf := testStateConf(newFakeClock(), refresh)
+	// The real clock makes the canceled context win the select.
+	stateConf.Clock = nil
+	stateConf.Delay = time.Hour
+
+	_, err := stateConf.WaitForState(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if *calls != 0 {
+		t.Fatalf("expected no refresh calls, got %d", *calls)
+	}
+}
+
+func TestWaitForStateLogsProgress(t *testing.T) {
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	log.SetFlags(0)
+	t.Cleanup(func() {
+		log.SetOutput(os.Stderr)
+		log.SetFlags(log.LstdFlags)
+	})
+
+	clock := newFakeClock()
+	refresh, _ := testRefresh(testStatusPending, testStatusResizing, testStatusResizing, testStatusResizing, testStatusActive)
+
+	_, err := testStateConf(clock, refresh).WaitForState(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := "[INFO] datastore 123 PENDING_CREATE for 0s\n" +
+		"[INFO] datastore 123 RESIZING for 0s\n" +
+		"[INFO] datastore 123 RESIZING for 2s\n" +
+		"[INFO] datastore 123 RESIZING for 6s\n" +
+		"[DEBUG] datastore 123 reached the state ACTIVE in 21s\n"
+	if logs.String() != expected {
+		t.Fatalf("expected logs:\n%s\ngot:\n%s", expected, logs.String())
+	}
+}
+
+func TestWaitForStateDefaultClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewInstantClock(start)
+	DefaultClock = clock
+	t.Cleanup(func() { DefaultClock = realClock{} })
+
+	refresh, calls := testRefresh(testStatusPending, testStatusActive)
+	stateConf := testStateConf(nil, refresh)
+	stateConf.Delay = time.Hour
+	stateConf.Timeout = 2 * time.Hour
+
+	if _, err := stateConf.WaitForState(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if *calls != 2 {
+		t.Fatalf("expected 2 refresh calls, got %d", *calls)
+	}
+	if elapsed := clock.Now().Sub(start); elapsed != time.Hour+stateConf.MinInterval {
+		t.Fatalf("expected the clock to move forward by %s, got %s", time.Hour+stateConf.MinInterval, elapsed)
+	}
+}
@@ -5,12 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/selectel/go-selvpcclient/v4/selvpcclient/quotamanager/quotas"
 	v1 "github.com/selectel/mks-go/pkg/v1"
@@ -19,101 +19,132 @@ import (
 	"github.com/selectel/mks-go/pkg/v1/kubeversion"
 	"github.com/selectel/mks-go/pkg/v1/node"
 	"github.com/selectel/mks-go/pkg/v1/nodegroup"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/waiter"
 )
 
 func waitForMKSClusterV1ActiveState(
 	ctx context.Context, client *v1.ServiceClient, clusterID string, timeout time.Duration,
 ) error {
-	pending := []string{
-		string(cluster.StatusPendingCreate),
-		string(cluster.StatusPendingUpdate),
-		string(cluster.StatusPendingUpgradePatchVersion),
-		string(cluster.StatusPendingUpgradeMinorVersion),
-		string(cluster.StatusPendingUpgradeClusterConfiguration),
-		string(cluster.StatusPendingResize),
-	}
-	target := []string{
-		string(cluster.StatusActive),
-	}
+	stateConf := &waiter.StateConf[*cluster.View, cluster.Status]{
+		Object: "cluster " + clusterID,
+		Pending: []cluster.Status{
+			cluster.StatusPendingCreate,
+			cluster.StatusPendingUpdate,
+			cluster.StatusPendingUpgradePatchVersion,
+			cluster.StatusPendingUpgradeMinorVersion,
+			cluster.StatusPendingUpgradeClusterConfiguration,
+			cluster.StatusPendingResize,
+		},
+		Target:  []cluster.Status{cluster.StatusActive},
+		Failure: []cluster.Status{cluster.StatusError},
+		Refresh: func(ctx context.Context) (*cluster.View, cluster.Status, error) {
+			c, _, err := cluster.Get(ctx, client, clusterID)
+			if err != nil {
+				return nil, "", err
+			}
 
-	stateConf := &resource.StateChangeConf{
-		Pending:    pending,
-		Target:     target,
-		Refresh:    mksClusterV1StateRefreshFunc(ctx, client, clusterID),
-		Timeout:    timeout,
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
+			return c, c.Status, nil
+		},
+		Timeout: timeout,
+		Delay:   10 * time.Second,
 	}
 
-	_, err := stateConf.WaitForState()
+	_, err := stateConf.WaitForState(ctx)
 	if err != nil {
 		return fmt.Errorf(
-			"error waiting for the cluster %s to become 'ACTIVE': %s",
+			"error waiting for the cluster %s to become 'ACTIVE': %w",
 			clusterID, err)
 	}
 
 	return nil
 }
 
-func waitForMKSNodegroupV1ActiveState(
-	ctx context.Context, client *v1.ServiceClient, clusterID string, nodegroupID string, timeout time.Duration,
-) error {
-	pending := []string{
-		string(nodegroup.StatusPendingCreate),
-		string(nodegroup.StatusPendingUpdate),
-		string(nodegroup.StatusPendingDelete),
-		string(nodegroup.StatusPendingScaleUp),
-		string(nodegroup.StatusPendingScaleDown),
-		string(nodegroup.StatusPendingNodeReinstall),
-	}
+// mksClusterV1StatusDeleted is the status of the cluster that is not found.
+const mksClusterV1StatusDeleted cluster.Status = "DELETED"
 
-	target := []string{
-		string(nodegroup.StatusActive),
-	}
+func waitForMKSClusterV1Deleted(
+	ctx context.Context, client *v1.ServiceClient, clusterID string, timeout time.Duration,
+) error {
+	stateConf := &waiter.StateConf[*cluster.View, cluster.Status]{
+		Object: "cluster " + clusterID,
+		Pending: []cluster.Status{
+			cluster.StatusActive,
+			cluster.StatusPendingCreate,
+			cluster.StatusPendingUpdate,
+			cluster.StatusPendingUpgrade,
+			cluster.StatusPendingRotateCerts,
+			cluster.StatusPendingDelete,
+			cluster.StatusPendingResize,
+			cluster.StatusPendingNodeReinstall,
+			cluster.StatusPendingUpgradePatchVersion,
+			cluster.StatusPendingUpgradeMinorVersion,
+			cluster.StatusPendingUpdateNodegroup,
+			cluster.StatusPendingUpgradeMastersConfiguration,
+			cluster.StatusPendingUpgradeClusterConfiguration,
+			cluster.StatusMaintenance,
+		},
+		Target:  []cluster.Status{mksClusterV1StatusDeleted},
+		Failure: []cluster.Status{cluster.StatusError},
+		Refresh: func(ctx context.Context) (*cluster.View, cluster.Status, error) {
+			c, response, err := cluster.Get(ctx, client, clusterID)
+			if err != nil {
+				if response != nil && response.StatusCode == http.StatusNotFound {
+					return nil, mksClusterV1StatusDeleted, nil
+				}
+
+				return nil, "", err
+			}
 
-	stateConf := &resource.StateChangeConf{
-		Pending:    pending,
-		Target:     target,
-		Refresh:    mksNodegroupV1StateRefreshFunc(ctx, client, clusterID, nodegroupID),
-		Timeout:    timeout,
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
+			return c, c.Status, nil
+		},
+		Timeout:     timeout,
+		Delay:       10 * time.Second,
+		MinInterval: 3 * time.Second,
 	}
 
-	_, err := stateConf.WaitForStateContext(ctx)
+	_, err := stateConf.WaitForState(ctx)
 	if err != nil {
-		return fmt.Errorf(
-			"error waiting for the nodegroup %s to become 'ACTIVE': %s",
-			nodegroupID, err)
+		return fmt.Errorf("error waiting for the cluster %s to become deleted: %w", clusterID, err)
 	}
 
 	return nil
 }
 
-func mksNodegroupV1StateRefreshFunc(
-	ctx context.Context, client *v1.ServiceClient, clusterID, nodegroupID string,
-) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		ng, _, err := nodegroup.Get(ctx, client, clusterID, nodegroupID)
-		if err != nil {
-			return nil, "", err
-		}
+func waitForMKSNodegroupV1ActiveState(
+	ctx context.Context, client *v1.ServiceClient, clusterID string, nodegroupID string, timeout time.Duration,
+) error {
+	stateConf := &waiter.StateConf[*nodegroup.GetView, nodegroup.Status]{
+		Object: "nodegroup " + nodegroupID,
+		Pending: []nodegroup.Status{
+			nodegroup.StatusPendingCreate,
+			nodegroup.StatusPendingUpdate,
+			nodegroup.StatusPendingDelete,
+			nodegroup.StatusPendingScaleUp,
+			nodegroup.StatusPendingScaleDown,
+			nodegroup.StatusPendingNodeReinstall,
+		},
+		Target:  []nodegroup.Status{nodegroup.StatusActive},
+		Failure: []nodegroup.Status{nodegroup.StatusError},
+		Refresh: func(ctx context.Context) (*nodegroup.GetView, nodegroup.Status, error) {
+			ng, _, err := nodegroup.Get(ctx, client, clusterID, nodegroupID)
+			if err != nil {
+				return nil, "", err
+			}
 
-		return ng, string(ng.Status), nil
+			return ng, ng.Status, nil
+		},
+		Timeout: timeout,
+		Delay:   10 * time.Second,
 	}
-}
-
-func mksClusterV1StateRefreshFunc(
-	ctx context.Context, client *v1.ServiceClient, clusterID string,
-) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		c, _, err := cluster.Get(ctx, client, clusterID)
-		if err != nil {
-			return nil, "", err
-		}
 
-		return c, string(c.Status), nil
+	_, err := stateConf.WaitForState(ctx)
+	if err != nil {
+		return fmt.Errorf(
+			"error waiting for the nodegroup %s to become 'ACTIVE': %w",
+			nodegroupID, err)
 	}
+
+	return nil
 }
 
 func mksClusterV1KubeVersionDiffSuppressFunc(_, oldVersion, newVersion string, d *schema.ResourceData) bool {
@@ -639,23 +670,32 @@ func getMKSClient(d *schema.ResourceData, meta interface{}) (*v1.ServiceClient,
 	projectID := d.Get("project_id").(string)
 	region := d.Get("region").(string)
 
+	mksClient, err := newMKSClient(config, projectID, region)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	return mksClient, nil
+}
+
+// newMKSClient creates the MKS client for the project and region. It is used by
+// the resources of both the SDK and the framework providers.
+func newMKSClient(config *Config, projectID, region string) (*v1.ServiceClient, error) {
 	selvpcClient, err := config.GetSelVPCClientWithProjectScope(projectID)
 	if err != nil {
-		return nil, diag.FromErr(fmt.Errorf("can't get project-scope selvpc client for mks: %w", err))
+		return nil, fmt.Errorf("can't get project-scope selvpc client for mks: %w", err)
 	}
-	err = validateRegion(selvpcClient, MKS, region)
+	endpoint, err := config.getEndpoint(selvpcClient, MKS, region)
 	if err != nil {
-		return nil, diag.FromErr(fmt.Errorf("can't validate region: %w", err))
+		return nil, fmt.Errorf("can't get endpoint to init mks client: %w", err)
 	}
 
-	endpoint, err := selvpcClient.Catalog.GetEndpoint(MKS, region)
+	token, err := config.GetXAuthTokenWithProjectScope(projectID)
 	if err != nil {
-		return nil, diag.FromErr(fmt.Errorf("can't get endpoint to init mks client: %w", err))
+		return nil, fmt.Errorf("can't get project-scope token for mks: %w", err)
 	}
 
-	mksClient := v1.NewMKSClientV1(selvpcClient.GetXAuthToken(), endpoint.URL)
-
-	return mksClient, nil
+	return v1.NewMKSClientV1WithCustomHTTP(config.newHTTPClient(MKS, projectID), token, endpoint), nil
 }
 
 func interfaceListChecksum(items []interface{}) (string, error) {
@@ -37,7 +37,12 @@ func newTestMKSClient(rs *terraform.ResourceState, testAccProvider *schema.Provi
 		endpoint = mksEndpoint.URL
 	}
 
-	mksClient := v1.NewMKSClientV1(selvpcClient.GetXAuthToken(), endpoint)
+	token, err := config.GetXAuthTokenWithProjectScope(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("can't get token for mks acc tests: %w", err)
+	}
+
+	mksClient := v1.NewMKSClientV1(token, endpoint)
 
 	return mksClient, nil
 }
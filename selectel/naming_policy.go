@@ -0,0 +1,206 @@
+package selectel
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// namingPolicyDescriptions are descriptions of the naming_policy block and its attributes.
+var namingPolicyDescriptions = map[string]string{
+	"naming_policy": "Pattern that names of the resources must match. Checked when a resource is created or renamed.",
+	"pattern":       "Regular expression in the RE2 syntax that names must match, anchor it with ^ and $ to match the whole name.",
+	"resources":     "Resource types the pattern applies to, for example selectel_mks_cluster_v1. All resources with a name if empty. Unknown types are rejected.",
+}
+
+func namingPolicySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: namingPolicyDescriptions["naming_policy"],
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"pattern": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsValidRegExp,
+					Description:  namingPolicyDescriptions["pattern"],
+				},
+				"resources": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: namingPolicyDescriptions["resources"],
+				},
+			},
+		},
+	}
+}
+
+// namingPolicyOptions are the settings of the naming_policy block.
+type namingPolicyOptions struct {
+	Pattern   string
+	Resources []string
+}
+
+func flattenNamingPolicyBlock(d *schema.ResourceData) *namingPolicyOptions {
+	list := d.Get("naming_policy").([]interface{})
+	if len(list) == 0 || list[0] == nil {
+		return nil
+	}
+	policy := list[0].(map[string]interface{})
+
+	opts := &namingPolicyOptions{
+		Pattern: policy["pattern"].(string),
+	}
+	for _, v := range policy["resources"].([]interface{}) {
+		if v, ok := v.(string); ok {
+			opts.Resources = append(opts.Resources, v)
+		}
+	}
+
+	return opts
+}
+
+// namingPolicy is the compiled naming_policy block of a provider instance.
+type namingPolicy struct {
+	pattern *regexp.Regexp
+	// resources are the resource types the policy applies to, the policy applies to all of them if it is empty.
+	resources map[string]struct{}
+}
+
+func newNamingPolicy(opts *namingPolicyOptions) (*namingPolicy, error) {
+	if opts == nil {
+		return nil, nil
+	}
+
+	pattern, err := regexp.Compile(opts.Pattern)
+	if err != nil {
+		return nil, fmt.Errorf("naming_policy pattern is invalid: %w", err)
+	}
+
+	policy := &namingPolicy{
+		pattern:   pattern,
+		resources: make(map[string]struct{}, len(opts.Resources)),
+	}
+	knownResourceTypes := providerResourceTypes()
+	for _, resourceType := range opts.Resources {
+		if _, ok := knownResourceTypes[resourceType]; !ok {
+			return nil, fmt.Errorf("naming_policy resources contain an unknown resource type %q", resourceType)
+		}
+		policy.resources[resourceType] = struct{}{}
+	}
+
+	return policy, nil
+}
+
+var (
+	providerResourceTypesOnce sync.Once
+	providerResourceTypesMap  map[string]struct{}
+)
+
+// providerResourceTypes returns the resource types of the SDK and framework providers.
+func providerResourceTypes() map[string]struct{} {
+	providerResourceTypesOnce.Do(func() {
+		providerResourceTypesMap = make(map[string]struct{})
+		for resourceType := range newProvider("", &sharedConfig{}).ResourcesMap {
+			providerResourceTypesMap[resourceType] = struct{}{}
+		}
+
+		ctx := context.Background()
+		for _, newResource := range newFrameworkProvider("", &sharedConfig{}).Resources(ctx) {
+			var resp resource.MetadataResponse
+			newResource().Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "selectel"}, &resp)
+			providerResourceTypesMap[resp.TypeName] = struct{}{}
+		}
+	})
+
+	return providerResourceTypesMap
+}
+
+// check returns an error if the name of the resource of the given type doesn't match the pattern.
+func (p *namingPolicy) check(resourceType, attr, name string) error {
+	if p == nil || name == "" {
+		return nil
+	}
+	if _, ok := p.resources[resourceType]; len(p.resources) > 0 && !ok {
+		return nil
+	}
+	if p.pattern.MatchString(name) {
+		return nil
+	}
+
+	return fmt.Errorf("%s '%s' of %s doesn't match the naming_policy pattern '%s'",
+		attr, name, resourceType, p.pattern.String())
+}
+
+// setNamingPolicy makes resources of the provider that have a name check it against
+// the naming_policy block.
+func setNamingPolicy(p *schema.Provider) {
+	for resourceType, r := range p.ResourcesMap {
+		s, ok := r.Schema["name"]
+		if !ok || s.Type != schema.TypeString || (!s.Required && !s.Optional) {
+			continue
+		}
+		if r.CustomizeDiff != nil {
+			r.CustomizeDiff = customdiff.All(r.CustomizeDiff, customizeDiffNamingPolicy(resourceType))
+		} else {
+			r.CustomizeDiff = customizeDiffNamingPolicy(resourceType)
+		}
+	}
+}
+
+// customizeDiffNamingPolicy checks names of new and renamed resources, so the resources
+// created before the policy was set can still be updated.
+func customizeDiffNamingPolicy(resourceType string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		config, ok := meta.(*Config)
+		if !ok || config.namingPolicy == nil {
+			return nil
+		}
+		if d.Id() != "" && !d.HasChange("name") {
+			return nil
+		}
+		if !d.NewValueKnown("name") {
+			return nil
+		}
+
+		return config.namingPolicy.check(resourceType, "name", d.Get("name").(string))
+	}
+}
+
+// modifyPlanNamingPolicy checks the name of a framework resource against the naming_policy
+// block the same way customizeDiffNamingPolicy does for the SDK resources.
+func modifyPlanNamingPolicy(ctx context.Context, config *Config, resourceType, attr string, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if config == nil || config.namingPolicy == nil || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	attrPath := path.Root(attr)
+
+	var name types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, attrPath, &name)...)
+	if resp.Diagnostics.HasError() || name.IsUnknown() {
+		return
+	}
+	if !req.State.Raw.IsNull() {
+		var stateName types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, attrPath, &stateName)...)
+		if resp.Diagnostics.HasError() || stateName.Equal(name) {
+			return
+		}
+	}
+
+	if err := config.namingPolicy.check(resourceType, attr, name.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(attrPath, "Name doesn't follow the naming policy", err.Error())
+	}
+}
@@ -0,0 +1,191 @@
+package selectel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testNamingPolicy(t *testing.T, pattern string, resources ...string) *namingPolicy {
+	t.Helper()
+
+	policy, err := newNamingPolicy(&namingPolicyOptions{
+		Pattern:   pattern,
+		Resources: resources,
+	})
+	require.NoError(t, err)
+
+	return policy
+}
+
+func TestNewNamingPolicy(t *testing.T) {
+	policy, err := newNamingPolicy(nil)
+	require.NoError(t, err)
+	assert.Nil(t, policy)
+
+	_, err = newNamingPolicy(&namingPolicyOptions{Pattern: "^prod-("})
+	assert.ErrorContains(t, err, "naming_policy pattern is invalid")
+
+	policy, err = newNamingPolicy(&namingPolicyOptions{
+		Pattern:   "^prod-",
+		Resources: []string{"selectel_mks_cluster_v1", "selectel_secretsmanager_secret_v1"},
+	})
+	require.NoError(t, err)
+	assert.Len(t, policy.resources, 2)
+
+	_, err = newNamingPolicy(&namingPolicyOptions{
+		Pattern:   "^prod-",
+		Resources: []string{"selectel_mks_cluster_v1", "selectel_mks_cluster_v2"},
+	})
+	assert.EqualError(t, err, `naming_policy resources contain an unknown resource type "selectel_mks_cluster_v2"`)
+}
+
+func TestNamingPolicyCheck(t *testing.T) {
+	testCases := []struct {
+		name          string
+		policy        *namingPolicy
+		resourceType  string
+		value         string
+		expectedError string
+	}{
+		{
+			name:         "without a policy",
+			resourceType: "selectel_mks_cluster_v1",
+			value:        "Cluster",
+		},
+		{
+			name:         "matching name",
+			policy:       testNamingPolicy(t, "^prod-[a-z0-9-]+$"),
+			resourceType: "selectel_mks_cluster_v1",
+			value:        "prod-cluster-1",
+		},
+		{
+			name:          "not matching name",
+			policy:        testNamingPolicy(t, "^prod-[a-z0-9-]+$"),
+			resourceType:  "selectel_mks_cluster_v1",
+			value:         "Prod-Cluster",
+			expectedError: "name 'Prod-Cluster' of selectel_mks_cluster_v1 doesn't match the naming_policy pattern '^prod-[a-z0-9-]+$'",
+		},
+		{
+			name:         "resource type without the policy",
+			policy:       testNamingPolicy(t, "^prod-[a-z0-9-]+$", "selectel_craas_registry_v1"),
+			resourceType: "selectel_mks_cluster_v1",
+			value:        "Cluster",
+		},
+		{
+			name:          "resource type with the policy",
+			policy:        testNamingPolicy(t, "^prod-[a-z0-9-]+$", "selectel_craas_registry_v1"),
+			resourceType:  "selectel_craas_registry_v1",
+			value:         "registry",
+			expectedError: "name 'registry' of selectel_craas_registry_v1 doesn't match the naming_policy pattern '^prod-[a-z0-9-]+$'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.policy.check(tc.resourceType, "name", tc.value)
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestSetNamingPolicy(t *testing.T) {
+	p := Provider()
+
+	for _, resourceType := range []string{
+		"selectel_craas_registry_v1",
+		"selectel_dbaas_postgresql_datastore_v1",
+		"selectel_iam_serviceuser_v1",
+		"selectel_mks_cluster_v1",
+		"selectel_vpc_project_v2",
+	} {
+		assert.NotNil(t, p.ResourcesMap[resourceType].CustomizeDiff, resourceType)
+	}
+}
+
+func TestCustomizeDiffNamingPolicy(t *testing.T) {
+	r := Provider().ResourcesMap["selectel_vpc_project_v2"]
+	config := &Config{namingPolicy: testNamingPolicy(t, "^prod-")}
+
+	_, err := testResourceDiff(t, r, nil, map[string]string{"name": "dev-project"}, config)
+	assert.ErrorContains(t, err, "name 'dev-project' of selectel_vpc_project_v2 doesn't match the naming_policy pattern '^prod-'")
+
+	_, err = testResourceDiff(t, r, nil, map[string]string{"name": "prod-project"}, config)
+	assert.NoError(t, err)
+
+	// Resources created before the policy was set are checked only when they are renamed.
+	state := &terraform.InstanceState{
+		ID:         "id",
+		Attributes: map[string]string{"id": "id", "name": "dev-project"},
+	}
+	_, err = testResourceDiff(t, r, state, map[string]string{"name": "dev-project"}, config)
+	assert.NoError(t, err)
+
+	state = &terraform.InstanceState{
+		ID:         "id",
+		Attributes: map[string]string{"id": "id", "name": "prod-project"},
+	}
+	_, err = testResourceDiff(t, r, state, map[string]string{"name": "dev-project"}, config)
+	assert.ErrorContains(t, err, "doesn't match the naming_policy pattern")
+}
+
+func TestModifyPlanNamingPolicy(t *testing.T) {
+	ctx := context.Background()
+
+	policyType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"pattern":   tftypes.String,
+		"resources": tftypes.List{ElementType: tftypes.String},
+	}}
+	server, _, shared := testFakeAPIProviderServerWithConfig(t, map[string]tftypes.Value{
+		"naming_policy": tftypes.NewValue(tftypes.List{ElementType: policyType}, []tftypes.Value{
+			tftypes.NewValue(policyType, map[string]tftypes.Value{
+				"pattern": tftypes.NewValue(tftypes.String, "^prod-"),
+				"resources": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+					tftypes.NewValue(tftypes.String, "selectel_secretsmanager_secret_v1"),
+				}),
+			}),
+		}),
+	})
+	require.NotNil(t, shared.config.namingPolicy)
+
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	require.NoError(t, err)
+	secretSchema := schemaResp.ResourceSchemas["selectel_secretsmanager_secret_v1"]
+
+	priorState, err := tfprotov5.NewDynamicValue(secretSchema.ValueType(), tftypes.NewValue(secretSchema.ValueType(), nil))
+	require.NoError(t, err)
+
+	plan := func(key string) *tfprotov5.PlanResourceChangeResponse {
+		config := testProviderServerConfig(t, secretSchema, map[string]tftypes.Value{
+			"key":   tftypes.NewValue(tftypes.String, key),
+			"value": tftypes.NewValue(tftypes.String, "secret"),
+		})
+		resp, err := server.PlanResourceChange(ctx, &tfprotov5.PlanResourceChangeRequest{
+			TypeName:         "selectel_secretsmanager_secret_v1",
+			PriorState:       &priorState,
+			ProposedNewState: config,
+			Config:           config,
+		})
+		require.NoError(t, err)
+
+		return resp
+	}
+
+	resp := plan("dev-secret")
+	require.Len(t, resp.Diagnostics, 1)
+	assert.Equal(t, tfprotov5.DiagnosticSeverityError, resp.Diagnostics[0].Severity)
+	assert.Equal(t, "key 'dev-secret' of selectel_secretsmanager_secret_v1 doesn't match the naming_policy pattern '^prod-'", resp.Diagnostics[0].Detail)
+
+	resp = plan("prod-secret")
+	assert.Empty(t, resp.Diagnostics)
+}
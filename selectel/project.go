@@ -4,9 +4,13 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"log"
 	"net/url"
+	"sort"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/selectel/go-selvpcclient/v4/selvpcclient"
 	"github.com/selectel/go-selvpcclient/v4/selvpcclient/quotamanager/quotas"
 	"github.com/selectel/go-selvpcclient/v4/selvpcclient/resell/v2/projects"
 	resellQuotas "github.com/selectel/go-selvpcclient/v4/selvpcclient/resell/v2/quotas"
@@ -212,3 +216,240 @@ func flattenVPCProjectV2Theme(theme projects.Theme) map[string]string {
 
 	return m
 }
+
+// projectQuotaKey identifies a quota of the resource in the region or in a zone of the region.
+type projectQuotaKey struct {
+	resourceName string
+	zone         string
+}
+
+// projectQuotasV2Keys returns the keys of the quotas in the "quotas" set of the
+// selectel_vpc_project_quotas_v2 resource.
+func projectQuotasV2Keys(quotaSet *schema.Set) map[projectQuotaKey]struct{} {
+	keys := map[projectQuotaKey]struct{}{}
+	for _, quotaRaw := range quotaSet.List() {
+		quota := quotaRaw.(map[string]interface{})
+		resourceName := quota["resource_name"].(string)
+		for _, resourceQuotaRaw := range quota["resource_quotas"].(*schema.Set).List() {
+			resourceQuota := resourceQuotaRaw.(map[string]interface{})
+			keys[projectQuotaKey{resourceName: resourceName, zone: resourceQuota["zone"].(string)}] = struct{}{}
+		}
+	}
+
+	return keys
+}
+
+// resourceVPCProjectQuotasV2OptsFromSet converts the "quotas" set of the
+// selectel_vpc_project_quotas_v2 resource to the options of the update request.
+func resourceVPCProjectQuotasV2OptsFromSet(quotaSet *schema.Set) quotas.UpdateProjectQuotasOpts {
+	var opts quotas.UpdateProjectQuotasOpts
+	for _, quotaRaw := range quotaSet.List() {
+		quota := quotaRaw.(map[string]interface{})
+		quotaOpts := quotas.QuotaOpts{Name: quota["resource_name"].(string)}
+		for _, resourceQuotaRaw := range quota["resource_quotas"].(*schema.Set).List() {
+			resourceQuota := resourceQuotaRaw.(map[string]interface{})
+			zone := resourceQuota["zone"].(string)
+			value := resourceQuota["value"].(int)
+			quotaOpts.ResourceQuotasOpts = append(quotaOpts.ResourceQuotasOpts, quotas.ResourceQuotaOpts{
+				Zone:  &zone,
+				Value: &value,
+			})
+		}
+		opts.QuotasOpts = append(opts.QuotasOpts, quotaOpts)
+	}
+
+	return opts
+}
+
+// resourceVPCProjectQuotasV2ResetOpts returns the options that reset the non-zero quotas
+// which are not in the keys to zero.
+func resourceVPCProjectQuotasV2ResetOpts(projectQuotas []*quotas.Quota, keys map[projectQuotaKey]struct{}) []quotas.QuotaOpts {
+	var resetOpts []quotas.QuotaOpts
+	for _, quota := range projectQuotas {
+		quotaOpts := quotas.QuotaOpts{Name: quota.Name}
+		for _, entity := range quota.ResourceQuotasEntities {
+			if _, ok := keys[projectQuotaKey{resourceName: quota.Name, zone: entity.Zone}]; ok || entity.Value == 0 {
+				continue
+			}
+			zone := entity.Zone
+			value := 0
+			quotaOpts.ResourceQuotasOpts = append(quotaOpts.ResourceQuotasOpts, quotas.ResourceQuotaOpts{
+				Zone:  &zone,
+				Value: &value,
+			})
+		}
+		if len(quotaOpts.ResourceQuotasOpts) > 0 {
+			resetOpts = append(resetOpts, quotaOpts)
+		}
+	}
+
+	return resetOpts
+}
+
+// resourceVPCProjectQuotasV2ToSet converts the quotas of the region to the "quotas" set
+// of the selectel_vpc_project_quotas_v2 resource. Only the quotas the filter accepts are added.
+func resourceVPCProjectQuotasV2ToSet(projectQuotas []*quotas.Quota, filter func(key projectQuotaKey, value int) bool) *schema.Set {
+	quotaSchema := resourceVPCProjectQuotasV2().Schema["quotas"].Elem.(*schema.Resource)
+	quotaSet := schema.NewSet(schema.HashResource(quotaSchema), nil)
+
+	for _, quota := range projectQuotas {
+		resourceQuotasSet := schema.NewSet(hashProjectQuotasV2ResourceQuotas, nil)
+		for _, entity := range quota.ResourceQuotasEntities {
+			if !filter(projectQuotaKey{resourceName: quota.Name, zone: entity.Zone}, entity.Value) {
+				continue
+			}
+			resourceQuotasSet.Add(map[string]interface{}{
+				"zone":  entity.Zone,
+				"value": entity.Value,
+			})
+		}
+		if resourceQuotasSet.Len() == 0 {
+			continue
+		}
+
+		quotaSet.Add(map[string]interface{}{
+			"resource_name":   quota.Name,
+			"resource_quotas": resourceQuotasSet,
+		})
+	}
+
+	return quotaSet
+}
+
+// hashProjectQuotasV2ResourceQuotas is a hash function to use with the "resource_quotas" set
+// of the selectel_vpc_project_quotas_v2 resource.
+func hashProjectQuotasV2ResourceQuotas(v interface{}) int {
+	m := v.(map[string]interface{})
+	zone, _ := m["zone"].(string)
+
+	return hashcode.String(zone + "-")
+}
+
+// allowQuotasBelowUsageDescription is the description of the allow_quotas_below_usage attribute of the provider.
+const allowQuotasBelowUsageDescription = "Allow quotas of projects that are set lower than the current usage. " +
+	"The plan doesn't fail, and a warning is shown when the quotas are applied."
+
+// projectQuotaBelowUsage is a quota value that is lower than the current usage of the resource.
+type projectQuotaBelowUsage struct {
+	resourceName string
+	region       string
+	zone         string
+	value        int
+	used         int
+}
+
+// projectQuotasBelowUsage returns the quota values of the update options that are lower
+// than the usage in the quotas of the region.
+func projectQuotasBelowUsage(region string, projectQuotas []*quotas.Quota, opts quotas.UpdateProjectQuotasOpts) []projectQuotaBelowUsage {
+	used := map[projectQuotaKey]int{}
+	for _, quota := range projectQuotas {
+		for _, entity := range quota.ResourceQuotasEntities {
+			used[projectQuotaKey{resourceName: quota.Name, zone: entity.Zone}] = entity.Used
+		}
+	}
+
+	var result []projectQuotaBelowUsage
+	for _, quotaOpts := range opts.QuotasOpts {
+		for _, resourceQuotaOpts := range quotaOpts.ResourceQuotasOpts {
+			var zone string
+			if resourceQuotaOpts.Zone != nil {
+				zone = *resourceQuotaOpts.Zone
+			}
+			if resourceQuotaOpts.Value == nil {
+				continue
+			}
+			entityUsed := used[projectQuotaKey{resourceName: quotaOpts.Name, zone: zone}]
+			if *resourceQuotaOpts.Value < entityUsed {
+				result = append(result, projectQuotaBelowUsage{
+					resourceName: quotaOpts.Name,
+					region:       region,
+					zone:         zone,
+					value:        *resourceQuotaOpts.Value,
+					used:         entityUsed,
+				})
+			}
+		}
+	}
+
+	return result
+}
+
+// checkProjectQuotasUsage fetches the current usage of the project in the regions of the
+// update options and returns the quota values that are lower than the usage.
+// If reset is set, the options of the region are extended with the quotas it returns.
+func checkProjectQuotasUsage(
+	selvpcClient *selvpcclient.Client, projectID string, opts map[string]quotas.UpdateProjectQuotasOpts,
+	reset func(projectQuotas []*quotas.Quota) []quotas.QuotaOpts,
+) ([]projectQuotaBelowUsage, error) {
+	regions := make([]string, 0, len(opts))
+	for region := range opts {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+
+	var result []projectQuotaBelowUsage
+	for _, region := range regions {
+		projectQuotas, _, err := quotas.GetProjectQuotas(selvpcClient, projectID, region)
+		if err != nil {
+			return nil, fmt.Errorf("can't get usage of quotas of project %s in region %s: %w", projectID, region, err)
+		}
+
+		regionOpts := opts[region]
+		if reset != nil {
+			regionOpts.QuotasOpts = append(regionOpts.QuotasOpts, reset(projectQuotas)...)
+		}
+		result = append(result, projectQuotasBelowUsage(region, projectQuotas, regionOpts)...)
+	}
+
+	return result, nil
+}
+
+// diagProjectQuotasBelowUsage returns the warning about the quota values that are lower
+// than the usage, if allow_quotas_below_usage is set in the provider block.
+func diagProjectQuotasBelowUsage(config *Config, selvpcClient *selvpcclient.Client, projectID string, opts map[string]quotas.UpdateProjectQuotasOpts) diag.Diagnostics {
+	if !config.allowQuotasBelowUsage {
+		return nil
+	}
+
+	belowUsage, err := checkProjectQuotasUsage(selvpcClient, projectID, opts, nil)
+	if err != nil {
+		log.Printf("[DEBUG] %s", err)
+
+		return nil
+	}
+	if len(belowUsage) == 0 {
+		return nil
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  "Quotas are lower than the current usage",
+		Detail:   errProjectQuotasBelowUsage(belowUsage).Error(),
+	}}
+}
+
+// customizeDiffProjectQuotasUsage fails the plans that set quotas of the project lower than
+// the current usage. If allow_quotas_below_usage is set in the provider block, the plan succeeds
+// and the quotas are only logged, because CustomizeDiff can't return warnings. The warning
+// is returned on apply by diagProjectQuotasBelowUsage.
+func customizeDiffProjectQuotasUsage(
+	config *Config, selvpcClient *selvpcclient.Client, projectID string, opts map[string]quotas.UpdateProjectQuotasOpts,
+	reset func(projectQuotas []*quotas.Quota) []quotas.QuotaOpts,
+) error {
+	belowUsage, err := checkProjectQuotasUsage(selvpcClient, projectID, opts, reset)
+	if err != nil {
+		return err
+	}
+	if len(belowUsage) == 0 {
+		return nil
+	}
+
+	err = errProjectQuotasBelowUsage(belowUsage)
+	if config.allowQuotasBelowUsage {
+		log.Printf("[WARN] %s", err)
+
+		return nil
+	}
+
+	return err
+}
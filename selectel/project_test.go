@@ -3,6 +3,7 @@ package selectel
 import (
 	"testing"
 
+	"github.com/selectel/go-selvpcclient/v4/selvpcclient/quotamanager/quotas"
 	"github.com/selectel/go-selvpcclient/v4/selvpcclient/resell/v2/projects"
 	"github.com/stretchr/testify/assert"
 )
@@ -48,3 +49,53 @@ func TestFlattenVPCProjectV2Theme(t *testing.T) {
 		assert.Equal(t, testCase.want, flattenVPCProjectV2Theme(testCase.have))
 	}
 }
+
+func TestProjectQuotasBelowUsage(t *testing.T) {
+	projectQuotas := []*quotas.Quota{
+		{
+			Name: "compute_cores",
+			ResourceQuotasEntities: []quotas.ResourceQuotaEntity{
+				{Zone: "ru-3a", Value: 10, Used: 4},
+				{Zone: "ru-3b", Value: 10, Used: 0},
+			},
+		},
+		{
+			Name: "image_gigabytes",
+			ResourceQuotasEntities: []quotas.ResourceQuotaEntity{
+				{Value: 10, Used: 5},
+			},
+		},
+	}
+
+	zoneA, zoneB, noZone := "ru-3a", "ru-3b", ""
+	belowA, aboveB, belowRegional, equalA := 2, 1, 4, 4
+	opts := quotas.UpdateProjectQuotasOpts{
+		QuotasOpts: []quotas.QuotaOpts{
+			{
+				Name: "compute_cores",
+				ResourceQuotasOpts: []quotas.ResourceQuotaOpts{
+					{Zone: &zoneA, Value: &belowA},
+					{Zone: &zoneB, Value: &aboveB},
+				},
+			},
+			{
+				Name:               "image_gigabytes",
+				ResourceQuotasOpts: []quotas.ResourceQuotaOpts{{Zone: &noZone, Value: &belowRegional}},
+			},
+		},
+	}
+
+	expected := []projectQuotaBelowUsage{
+		{resourceName: "compute_cores", region: "ru-3", zone: "ru-3a", value: 2, used: 4},
+		{resourceName: "image_gigabytes", region: "ru-3", value: 4, used: 5},
+	}
+	assert.Equal(t, expected, projectQuotasBelowUsage("ru-3", projectQuotas, opts))
+
+	opts.QuotasOpts = []quotas.QuotaOpts{
+		{
+			Name:               "compute_cores",
+			ResourceQuotasOpts: []quotas.ResourceQuotaOpts{{Zone: &zoneA, Value: &equalA}},
+		},
+	}
+	assert.Empty(t, projectQuotasBelowUsage("ru-3", projectQuotas, opts))
+}
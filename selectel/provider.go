@@ -5,6 +5,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/mutexkv"
 )
 
@@ -17,6 +18,7 @@ const (
 	objectProjectQuotas             = "quotas for project"
 	objectRole                      = "role"
 	objectSubnet                    = "subnet"
+	objectCrossRegionSubnet         = "cross-region subnet"
 	objectToken                     = "token"
 	objectTopic                     = "topic"
 	objectUser                      = "user"
@@ -55,57 +57,150 @@ const (
 // This is a global MutexKV for use within this plugin.
 var selMutexKV = mutexkv.NewMutexKV()
 
-// Provider returns the Selectel terraform provider.
+// userAgentProductName is the product name of the provider in the User-Agent of API requests.
+const userAgentProductName = "terraform-provider-selectel"
+
+const (
+	defaultMaxRetries = 5
+	defaultMaxBackoff = 30
+)
+
+// providerEnvVars are the environment variables which set attributes of the provider block
+// when they are not set in the configuration. The first set variable wins.
+var providerEnvVars = map[string][]string{
+	"project_id":                    {"INFRA_PROJECT_ID"},
+	"region":                        {"INFRA_REGION"},
+	"auth_url":                      {"OS_AUTH_URL"},
+	"auth_region":                   {"OS_REGION_NAME"},
+	"domain_name":                   {"OS_DOMAIN_NAME"},
+	"username":                      {"OS_USERNAME"},
+	"user_domain_name":              {"OS_USER_DOMAIN_NAME"},
+	"password":                      {"OS_PASSWORD"},
+	"auth_token":                    {"OS_TOKEN", "OS_AUTH_TOKEN"},
+	"application_credential_id":     {"OS_APPLICATION_CREDENTIAL_ID"},
+	"application_credential_secret": {"OS_APPLICATION_CREDENTIAL_SECRET"},
+	"max_retries":                   {"SEL_MAX_RETRIES"},
+	"max_backoff":                   {"SEL_MAX_BACKOFF"},
+	"user_agent_suffix":             {"SEL_USER_AGENT_SUFFIX"},
+}
+
+// Provider returns the Selectel terraform provider of the development version.
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	return New("dev")()
+}
+
+// New returns a function that creates the Selectel terraform provider
+// which reports the given version in the User-Agent of API requests.
+func New(version string) func() *schema.Provider {
+	return func() *schema.Provider {
+		return newProvider(version, nil)
+	}
+}
+
+// newProvider returns the SDK provider. The providers served through the same mux server
+// get the same shared Config, it is nil for the provider served alone.
+func newProvider(version string, shared *sharedConfig) *schema.Provider {
+	provider := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"project_id": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("INFRA_PROJECT_ID", nil),
-				Description: "VPC project ID to import resources that need the project scope auth token.",
+				DefaultFunc: schema.MultiEnvDefaultFunc(providerEnvVars["project_id"], nil),
+				Description: "Default project ID of resources and data sources. Also used to import resources that need the project scope auth token.",
 			},
 			"region": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("INFRA_REGION", nil),
-				Description: "VPC region to import resources associated with the specific region.",
+				DefaultFunc: schema.MultiEnvDefaultFunc(providerEnvVars["region"], nil),
+				Description: "Default region of resources and data sources. Also used to import resources associated with the specific region.",
 			},
 			"auth_url": {
 				Type:        schema.TypeString,
-				Required:    true,
-				DefaultFunc: schema.EnvDefaultFunc("OS_AUTH_URL", nil),
+				Optional:    true,
+				DefaultFunc: schema.MultiEnvDefaultFunc(providerEnvVars["auth_url"], nil),
 				Description: "Base url to work with auth API (Keystone URL).",
 			},
 			"auth_region": {
 				Type:        schema.TypeString,
-				Required:    true,
-				DefaultFunc: schema.EnvDefaultFunc("OS_REGION_NAME", nil),
+				Optional:    true,
+				DefaultFunc: schema.MultiEnvDefaultFunc(providerEnvVars["auth_region"], nil),
 				Description: "Region for Keystone and Resell API URLs.",
 			},
 			"domain_name": {
 				Type:        schema.TypeString,
-				Required:    true,
-				DefaultFunc: schema.EnvDefaultFunc("OS_DOMAIN_NAME", nil),
+				Optional:    true,
+				DefaultFunc: schema.MultiEnvDefaultFunc(providerEnvVars["domain_name"], nil),
 				Description: "Your domain name i.e. your account id",
 			},
 			"username": {
 				Type:        schema.TypeString,
-				Required:    true,
-				DefaultFunc: schema.EnvDefaultFunc("OS_USERNAME", nil),
-				Description: "Service user username",
+				Optional:    true,
+				DefaultFunc: schema.MultiEnvDefaultFunc(providerEnvVars["username"], nil),
+				Description: "Service user username. Required if neither auth_token nor application credentials are set.",
 			},
 			"user_domain_name": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("OS_USER_DOMAIN_NAME", nil),
+				DefaultFunc: schema.MultiEnvDefaultFunc(providerEnvVars["user_domain_name"], nil),
 				Description: "Used for service accounts in other domain. If you don't know exactly what this field means then don't use it",
 			},
 			"password": {
 				Type:        schema.TypeString,
-				Required:    true,
-				DefaultFunc: schema.EnvDefaultFunc("OS_PASSWORD", nil),
-				Description: "Service user password",
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.MultiEnvDefaultFunc(providerEnvVars["password"], nil),
+				Description: "Service user password. Required if neither auth_token nor application credentials are set.",
+			},
+			"auth_token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.MultiEnvDefaultFunc(providerEnvVars["auth_token"], nil),
+				Description: "Pre-issued Keystone token. Takes precedence over application credentials and username/password.",
+			},
+			"application_credential_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.MultiEnvDefaultFunc(providerEnvVars["application_credential_id"], nil),
+				RequiredWith: []string{"application_credential_secret"},
+				Description:  "ID of the OpenStack application credential. Takes precedence over username/password.",
+			},
+			"application_credential_secret": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				DefaultFunc:  schema.MultiEnvDefaultFunc(providerEnvVars["application_credential_secret"], nil),
+				RequiredWith: []string{"application_credential_id"},
+				Description:  "Secret of the OpenStack application credential.",
+			},
+			"endpoints":     endpointsSchema(),
+			"rate_limit":    rateLimitSchema(),
+			"naming_policy": namingPolicySchema(),
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.MultiEnvDefaultFunc(providerEnvVars["max_retries"], defaultMaxRetries),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description: "Maximum number of retries of API requests that got 429, 5xx or a connection error. " +
+					"POST and PATCH requests are retried only after 429 or a failed connection.",
+			},
+			"max_backoff": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.MultiEnvDefaultFunc(providerEnvVars["max_backoff"], defaultMaxBackoff),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Maximum time in seconds to wait between retries of API requests.",
+			},
+			"user_agent_suffix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.MultiEnvDefaultFunc(providerEnvVars["user_agent_suffix"], nil),
+				Description: "String appended to the User-Agent of API requests to tag the traffic.",
+			},
+			"allow_quotas_below_usage": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: allowQuotasBelowUsageDescription,
 			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
@@ -121,13 +216,17 @@ func Provider() *schema.Provider {
 			"selectel_mks_kube_versions_v1":             dataSourceMKSKubeVersionsV1(),
 			"selectel_mks_feature_gates_v1":             dataSourceMKSFeatureGatesV1(),
 			"selectel_mks_admission_controllers_v1":     dataSourceMKSAdmissionControllersV1(),
+			"selectel_vpc_project_v2":                   dataSourceVPCProjectV2(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"selectel_vpc_floatingip_v2":                            resourceVPCFloatingIPV2(),
 			"selectel_vpc_keypair_v2":                               resourceVPCKeypairV2(),
 			"selectel_vpc_license_v2":                               resourceVPCLicenseV2(),
 			"selectel_vpc_project_v2":                               resourceVPCProjectV2(),
+			"selectel_vpc_project_quotas_v2":                        resourceVPCProjectQuotasV2(),
+			"selectel_vpc_project_role_v2":                          resourceVPCProjectRoleV2(),
 			"selectel_vpc_subnet_v2":                                resourceVPCSubnetV2(),
+			"selectel_vpc_crossregion_subnet_v2":                    resourceVPCCrossRegionSubnetV2(),
 			"selectel_iam_serviceuser_v1":                           resourceIAMServiceUserV1(),
 			"selectel_iam_user_v1":                                  resourceIAMUserV1(),
 			"selectel_iam_s3_credentials_v1":                        resourceIAMS3CredentialsV1(),
@@ -160,17 +259,25 @@ func Provider() *schema.Provider {
 			"selectel_dbaas_firewall_v1":                            resourceDBaaSFirewallV1(),
 			"selectel_craas_registry_v1":                            resourceCRaaSRegistryV1(),
 			"selectel_craas_token_v1":                               resourceCRaaSTokenV1(),
-			"selectel_secretsmanager_secret_v1":                     resourceSecretsManagerSecretV1(),
-			"selectel_secretsmanager_certificate_v1":                resourceSecretsManagerCertificateV1(),
 		},
-		ConfigureContextFunc: configureProvider,
 	}
+	provider.ConfigureContextFunc = func(_ context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		// TerraformVersion is known only when the provider is being configured.
+		return configureProvider(d, provider.UserAgent(userAgentProductName, version), shared)
+	}
+	// setProviderDefaults puts its CustomizeDiff in front of the region validation,
+	// so the region is validated with the values inherited from the provider.
+	setRegionValidation(provider)
+	setProviderDefaults(provider)
+	setNamingPolicy(provider)
+
+	return provider
 }
 
-func configureProvider(_ context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-	config, diagError := getConfig(d)
-	if diagError != nil {
-		return nil, diagError
+func configureProvider(d *schema.ResourceData, userAgent string, shared *sharedConfig) (interface{}, diag.Diagnostics) {
+	config, err := shared.get(expandProviderOptions(d), userAgent)
+	if err != nil {
+		return nil, diag.FromErr(err)
 	}
 
 	return config, nil
@@ -0,0 +1,180 @@
+package selectel
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// providerDefaultAttributes contains attributes of resources and data sources
+// that fall back to the values from the provider block when they are not set.
+var providerDefaultAttributes = []string{"project_id", "region"}
+
+// providerDefault returns the value of the provider block for the attribute.
+func (c *Config) providerDefault(attr string) string {
+	switch attr {
+	case "project_id":
+		return c.ProjectID
+	case "region":
+		return c.Region
+	default:
+		return ""
+	}
+}
+
+func errMissingProviderDefault(attr string) error {
+	return fmt.Errorf("%s must be set either in the resource or data source, or in the provider block", attr)
+}
+
+// inheritedAttributes returns top-level optional computed attributes of the resource
+// which values can be inherited from the provider block.
+func inheritedAttributes(r *schema.Resource) []string {
+	var attrs []string
+	for _, attr := range providerDefaultAttributes {
+		s, ok := r.Schema[attr]
+		if ok && s.Optional && s.Computed {
+			attrs = append(attrs, attr)
+		}
+	}
+
+	return attrs
+}
+
+// setProviderDefaults makes resources and data sources of the provider
+// inherit project_id and region from the provider block.
+func setProviderDefaults(p *schema.Provider) {
+	for _, r := range p.ResourcesMap {
+		attrs := inheritedAttributes(r)
+		if len(attrs) == 0 {
+			continue
+		}
+		funcs := []schema.CustomizeDiffFunc{customizeDiffProviderDefaults(attrs)}
+		if r.CustomizeDiff != nil {
+			funcs = append(funcs, r.CustomizeDiff)
+		}
+		r.CustomizeDiff = customdiff.Sequence(funcs...)
+	}
+
+	for _, r := range p.DataSourcesMap {
+		attrs := inheritedAttributes(r)
+		if len(attrs) == 0 || r.ReadContext == nil {
+			continue
+		}
+		r.ReadContext = readWithProviderDefaults(attrs, r.ReadContext)
+	}
+}
+
+// customizeDiffProviderDefaults plans the values from the provider block for the attributes
+// that are not set in the resource. All inherited attributes are ForceNew, so a change
+// of the value in the provider block replaces the resource.
+func customizeDiffProviderDefaults(attrs []string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		config := meta.(*Config)
+
+		rawConfig := d.GetRawConfig()
+		if rawConfig.IsNull() || !rawConfig.IsKnown() {
+			return nil
+		}
+
+		for _, attr := range attrs {
+			if !rawConfig.GetAttr(attr).IsNull() {
+				continue
+			}
+
+			value := config.providerDefault(attr)
+			if value == "" {
+				return errMissingProviderDefault(attr)
+			}
+			if value == d.Get(attr).(string) {
+				continue
+			}
+			if err := d.SetNew(attr, value); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// readWithProviderDefaults sets the values from the provider block for the attributes
+// that are not set in the data source before reading it.
+func readWithProviderDefaults(attrs []string, read schema.ReadContextFunc) schema.ReadContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		config := meta.(*Config)
+
+		for _, attr := range attrs {
+			if d.Get(attr).(string) != "" {
+				continue
+			}
+
+			value := config.providerDefault(attr)
+			if value == "" {
+				return diag.FromErr(errMissingProviderDefault(attr))
+			}
+			if err := d.Set(attr, value); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+
+		return read(ctx, d, meta)
+	}
+}
+
+// modifyPlanProviderDefaults plans the values from the provider block for the attributes
+// of a framework resource that are not set in the configuration, the same way
+// customizeDiffProviderDefaults does for the SDK resources.
+func modifyPlanProviderDefaults(ctx context.Context, config *Config, attrs []string, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// The provider is not configured yet on validation, and there is nothing to plan on destroy.
+	if config == nil || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	for _, attr := range attrs {
+		attrPath := path.Root(attr)
+
+		var configValue types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, attrPath, &configValue)...)
+		if resp.Diagnostics.HasError() || !configValue.IsNull() {
+			continue
+		}
+
+		value := config.providerDefault(attr)
+		if value == "" {
+			resp.Diagnostics.AddAttributeError(attrPath, errMissingProviderDefault(attr).Error(), "")
+
+			continue
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, attrPath, value)...)
+
+		if req.State.Raw.IsNull() {
+			continue
+		}
+		var stateValue types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, attrPath, &stateValue)...)
+		if stateValue.ValueString() != value {
+			resp.RequiresReplace = append(resp.RequiresReplace, attrPath)
+		}
+	}
+}
+
+// providerDefaultValue returns the value of the attribute of a framework ephemeral resource,
+// or the value from the provider block when the attribute is not set in the configuration.
+func providerDefaultValue(config *Config, attr string, value types.String) (types.String, error) {
+	if value.ValueString() != "" {
+		return value, nil
+	}
+
+	defaultValue := config.providerDefault(attr)
+	if defaultValue == "" {
+		return value, errMissingProviderDefault(attr)
+	}
+
+	return types.StringValue(defaultValue), nil
+}
@@ -0,0 +1,259 @@
+package selectel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testResourceDiff plans the resource with the given string attributes in the configuration
+// the same way Terraform core does it.
+func testResourceDiff(t *testing.T, r *schema.Resource, state *terraform.InstanceState, raw map[string]string, config *Config) (*terraform.InstanceDiff, error) {
+	t.Helper()
+
+	values := make(map[string]cty.Value, len(raw))
+	for name, v := range raw {
+		values[name] = cty.StringVal(v)
+	}
+
+	return testResourceDiffValues(t, r, state, values, config)
+}
+
+// testResourceDiffValues is testResourceDiff with attributes of any type in the configuration.
+func testResourceDiffValues(t *testing.T, r *schema.Resource, state *terraform.InstanceState, values map[string]cty.Value, config *Config) (*terraform.InstanceDiff, error) {
+	t.Helper()
+
+	coreSchema := r.CoreConfigSchema()
+	attrs := make(map[string]cty.Value)
+	for name, attrType := range coreSchema.ImpliedType().AttributeTypes() {
+		if v, ok := values[name]; ok {
+			attrs[name] = v
+		} else {
+			attrs[name] = cty.NullVal(attrType)
+		}
+	}
+	configVal := cty.ObjectVal(attrs)
+
+	if state == nil {
+		state = &terraform.InstanceState{}
+	}
+	state.RawConfig = configVal
+
+	return r.Diff(context.Background(), state, terraform.NewResourceConfigShimmed(configVal, coreSchema), config)
+}
+
+// testProviderDefaultsConfig configures the provider against the test keystone
+// with the given provider-level project_id and region.
+func testProviderDefaultsConfig(t *testing.T, projectID, region string) *Config {
+	t.Helper()
+
+	keystone := newTestKeystone(t)
+
+	return testConfigureProvider(t, map[string]interface{}{
+		"auth_url":    keystone.AuthURL(),
+		"auth_region": testKeystoneAuthRegion,
+		"domain_name": testKeystoneDomainName,
+		"username":    "alice",
+		"password":    "secret-alice",
+		"project_id":  projectID,
+		"region":      region,
+	})
+}
+
+func TestInheritedAttributes(t *testing.T) {
+	p := Provider()
+
+	assert.Equal(t, []string{"project_id", "region"}, inheritedAttributes(p.ResourcesMap["selectel_mks_cluster_v1"]))
+	assert.Equal(t, []string{"project_id"}, inheritedAttributes(p.ResourcesMap["selectel_craas_registry_v1"]))
+	assert.Empty(t, inheritedAttributes(p.ResourcesMap["selectel_vpc_project_v2"]))
+	assert.Equal(t, []string{"project_id", "region"}, inheritedAttributes(p.DataSourcesMap["selectel_dbaas_flavor_v1"]))
+}
+
+func TestCustomizeDiffProviderDefaultsInheritsValues(t *testing.T) {
+	r := Provider().ResourcesMap["selectel_vpc_floatingip_v2"]
+
+	diff, err := testResourceDiff(t, r, nil, nil, testProviderDefaultsConfig(t, "project-a", "ru-1"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "project-a", diff.Attributes["project_id"].New)
+	assert.Equal(t, "ru-1", diff.Attributes["region"].New)
+}
+
+func TestCustomizeDiffProviderDefaultsPrefersResourceValues(t *testing.T) {
+	r := Provider().ResourcesMap["selectel_vpc_floatingip_v2"]
+
+	diff, err := testResourceDiff(t, r, nil, map[string]string{
+		"project_id": "project-b",
+		"region":     "ru-3",
+	}, testProviderDefaultsConfig(t, "project-a", "ru-1"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "project-b", diff.Attributes["project_id"].New)
+	assert.Equal(t, "ru-3", diff.Attributes["region"].New)
+}
+
+func TestCustomizeDiffProviderDefaultsMissingValue(t *testing.T) {
+	r := Provider().ResourcesMap["selectel_vpc_floatingip_v2"]
+
+	_, err := testResourceDiff(t, r, nil, map[string]string{
+		"project_id": "project-a",
+	}, &Config{})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "region must be set either in the resource or data source, or in the provider block")
+}
+
+func TestCustomizeDiffProviderDefaultsReplacesResource(t *testing.T) {
+	r := Provider().ResourcesMap["selectel_vpc_floatingip_v2"]
+	state := func() *terraform.InstanceState {
+		return &terraform.InstanceState{
+			ID: "floatingip-1",
+			Attributes: map[string]string{
+				"id":         "floatingip-1",
+				"project_id": "project-a",
+				"region":     "ru-1",
+			},
+		}
+	}
+
+	diff, err := testResourceDiff(t, r, state(), nil, testProviderDefaultsConfig(t, "project-a", "ru-1"))
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	assert.NotContains(t, diff.Attributes, "project_id")
+	assert.NotContains(t, diff.Attributes, "region")
+	assert.False(t, diff.RequiresNew())
+
+	diff, err = testResourceDiff(t, r, state(), nil, testProviderDefaultsConfig(t, "project-a", "ru-3"))
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	assert.Equal(t, "ru-3", diff.Attributes["region"].New)
+	assert.True(t, diff.RequiresNew())
+}
+
+func TestReadWithProviderDefaults(t *testing.T) {
+	r := Provider().DataSourcesMap["selectel_dbaas_flavor_v1"]
+
+	var projectID, region string
+	read := readWithProviderDefaults([]string{"project_id", "region"},
+		func(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+			projectID = d.Get("project_id").(string)
+			region = d.Get("region").(string)
+
+			return nil
+		})
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"region": "ru-3",
+	})
+	diags := read(context.Background(), d, testProviderDefaultsConfig(t, "project-a", "ru-1"))
+	require.False(t, diags.HasError())
+	assert.Equal(t, "project-a", projectID)
+	assert.Equal(t, "ru-3", region)
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	diags = read(context.Background(), d, &Config{Region: "ru-1"})
+	require.True(t, diags.HasError())
+	assert.Equal(t, "project_id must be set either in the resource or data source, or in the provider block", diags[0].Summary)
+}
+
+// testFrameworkModifyPlan plans the framework resource with the given string attributes in the
+// configuration. Attributes missing in the configuration are planned from the state, or are unknown.
+func testFrameworkModifyPlan(t *testing.T, r fwresource.ResourceWithModifyPlan, state, raw map[string]string, config *Config) *fwresource.ModifyPlanResponse {
+	t.Helper()
+
+	ctx := context.Background()
+	if r, ok := r.(fwresource.ResourceWithConfigure); ok {
+		r.Configure(ctx, fwresource.ConfigureRequest{ProviderData: config}, &fwresource.ConfigureResponse{})
+	}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	object := func(values map[string]string, missing interface{}) tftypes.Value {
+		attrs := make(map[string]tftypes.Value)
+		for name, attrType := range objectType.AttributeTypes {
+			if v, ok := values[name]; ok {
+				attrs[name] = tftypes.NewValue(attrType, v)
+			} else if v, ok := state[name]; ok && missing == tftypes.UnknownValue {
+				attrs[name] = tftypes.NewValue(attrType, v)
+			} else {
+				attrs[name] = tftypes.NewValue(attrType, missing)
+			}
+		}
+
+		return tftypes.NewValue(objectType, attrs)
+	}
+
+	stateRaw := tftypes.NewValue(objectType, nil)
+	if state != nil {
+		stateRaw = object(state, nil)
+	}
+	req := fwresource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: object(raw, nil)},
+		Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: object(raw, tftypes.UnknownValue)},
+		State:  tfsdk.State{Schema: schemaResp.Schema, Raw: stateRaw},
+	}
+	resp := &fwresource.ModifyPlanResponse{Plan: req.Plan}
+	r.ModifyPlan(ctx, req, resp)
+
+	return resp
+}
+
+func TestModifyPlanProviderDefaultsInheritsValues(t *testing.T) {
+	r := newSecretsManagerSecretV1Resource().(fwresource.ResourceWithModifyPlan)
+
+	resp := testFrameworkModifyPlan(t, r, nil, map[string]string{"key": "key"}, testProviderDefaultsConfig(t, "project-a", "ru-1"))
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %+v", resp.Diagnostics)
+
+	var projectID string
+	require.False(t, resp.Plan.GetAttribute(context.Background(), path.Root("project_id"), &projectID).HasError())
+	assert.Equal(t, "project-a", projectID)
+}
+
+func TestModifyPlanProviderDefaultsPrefersResourceValues(t *testing.T) {
+	r := newSecretsManagerSecretV1Resource().(fwresource.ResourceWithModifyPlan)
+
+	resp := testFrameworkModifyPlan(t, r, nil, map[string]string{
+		"key":        "key",
+		"project_id": "project-b",
+	}, testProviderDefaultsConfig(t, "project-a", "ru-1"))
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %+v", resp.Diagnostics)
+
+	var projectID string
+	require.False(t, resp.Plan.GetAttribute(context.Background(), path.Root("project_id"), &projectID).HasError())
+	assert.Equal(t, "project-b", projectID)
+}
+
+func TestModifyPlanProviderDefaultsMissingValue(t *testing.T) {
+	r := newSecretsManagerSecretV1Resource().(fwresource.ResourceWithModifyPlan)
+
+	resp := testFrameworkModifyPlan(t, r, nil, map[string]string{"key": "key"}, &Config{})
+
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "project_id must be set either in the resource or data source, or in the provider block",
+		resp.Diagnostics.Errors()[0].Summary())
+}
+
+func TestModifyPlanProviderDefaultsReplacesResource(t *testing.T) {
+	r := newSecretsManagerSecretV1Resource().(fwresource.ResourceWithModifyPlan)
+	state := map[string]string{"id": "project-a/key", "key": "key", "project_id": "project-a"}
+
+	resp := testFrameworkModifyPlan(t, r, state, map[string]string{"key": "key"}, testProviderDefaultsConfig(t, "project-a", "ru-1"))
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %+v", resp.Diagnostics)
+	assert.Empty(t, resp.RequiresReplace)
+
+	resp = testFrameworkModifyPlan(t, r, state, map[string]string{"key": "key"}, testProviderDefaultsConfig(t, "project-b", "ru-1"))
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %+v", resp.Diagnostics)
+	assert.Equal(t, path.Paths{path.Root("project_id")}, resp.RequiresReplace)
+}
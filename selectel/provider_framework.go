@@ -0,0 +1,416 @@
+package selectel
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	fwschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// NewFrameworkProvider returns a function that creates the plugin framework provider
+// with the resources which have been migrated from the SDK provider.
+// It is served together with the SDK provider by the server of ProviderServerFactory.
+func NewFrameworkProvider(version string) func() provider.Provider {
+	return func() provider.Provider {
+		return newFrameworkProvider(version, nil)
+	}
+}
+
+func newFrameworkProvider(version string, shared *sharedConfig) *frameworkProvider {
+	return &frameworkProvider{
+		version: version,
+		shared:  shared,
+	}
+}
+
+// frameworkProvider is the plugin framework provider. Its schema must be the same as the schema
+// of the SDK provider, otherwise the mux server refuses to serve them together.
+type frameworkProvider struct {
+	version string
+	shared  *sharedConfig
+}
+
+var (
+	_ provider.Provider                       = &frameworkProvider{}
+	_ provider.ProviderWithEphemeralResources = &frameworkProvider{}
+)
+
+type frameworkProviderModel struct {
+	ProjectID                   types.String                 `tfsdk:"project_id"`
+	Region                      types.String                 `tfsdk:"region"`
+	AuthURL                     types.String                 `tfsdk:"auth_url"`
+	AuthRegion                  types.String                 `tfsdk:"auth_region"`
+	DomainName                  types.String                 `tfsdk:"domain_name"`
+	Username                    types.String                 `tfsdk:"username"`
+	UserDomainName              types.String                 `tfsdk:"user_domain_name"`
+	Password                    types.String                 `tfsdk:"password"`
+	AuthToken                   types.String                 `tfsdk:"auth_token"`
+	ApplicationCredentialID     types.String                 `tfsdk:"application_credential_id"`
+	ApplicationCredentialSecret types.String                 `tfsdk:"application_credential_secret"`
+	Endpoints                   types.List                   `tfsdk:"endpoints"`
+	RateLimits                  []frameworkRateLimitModel    `tfsdk:"rate_limit"`
+	NamingPolicy                []frameworkNamingPolicyModel `tfsdk:"naming_policy"`
+	MaxRetries                  types.Int64                  `tfsdk:"max_retries"`
+	MaxBackoff                  types.Int64                  `tfsdk:"max_backoff"`
+	UserAgentSuffix             types.String                 `tfsdk:"user_agent_suffix"`
+	AllowQuotasBelowUsage       types.Bool                   `tfsdk:"allow_quotas_below_usage"`
+}
+
+type frameworkRateLimitModel struct {
+	Service           types.String  `tfsdk:"service"`
+	RequestsPerSecond types.Float64 `tfsdk:"requests_per_second"`
+	Burst             types.Int64   `tfsdk:"burst"`
+	MaxInFlight       types.Int64   `tfsdk:"max_in_flight"`
+}
+
+type frameworkNamingPolicyModel struct {
+	Pattern   types.String `tfsdk:"pattern"`
+	Resources types.List   `tfsdk:"resources"`
+}
+
+func (p *frameworkProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "selectel"
+	resp.Version = p.version
+}
+
+func (p *frameworkProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
+	endpoints := make(map[string]fwschema.Attribute, len(endpointsServiceTypes))
+	for attr := range endpointsServiceTypes {
+		endpoints[attr] = fwschema.StringAttribute{
+			Optional:    true,
+			Description: endpointDescription(attr),
+		}
+	}
+
+	resp.Schema = fwschema.Schema{
+		Attributes: map[string]fwschema.Attribute{
+			"project_id": fwschema.StringAttribute{
+				Optional:    true,
+				Description: "Default project ID of resources and data sources. Also used to import resources that need the project scope auth token.",
+			},
+			"region": fwschema.StringAttribute{
+				Optional:    true,
+				Description: "Default region of resources and data sources. Also used to import resources associated with the specific region.",
+			},
+			"auth_url": fwschema.StringAttribute{
+				Optional:    true,
+				Description: "Base url to work with auth API (Keystone URL).",
+			},
+			"auth_region": fwschema.StringAttribute{
+				Optional:    true,
+				Description: "Region for Keystone and Resell API URLs.",
+			},
+			"domain_name": fwschema.StringAttribute{
+				Optional:    true,
+				Description: "Your domain name i.e. your account id",
+			},
+			"username": fwschema.StringAttribute{
+				Optional:    true,
+				Description: "Service user username. Required if neither auth_token nor application credentials are set.",
+			},
+			"user_domain_name": fwschema.StringAttribute{
+				Optional:    true,
+				Description: "Used for service accounts in other domain. If you don't know exactly what this field means then don't use it",
+			},
+			"password": fwschema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Service user password. Required if neither auth_token nor application credentials are set.",
+			},
+			"auth_token": fwschema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Pre-issued Keystone token. Takes precedence over application credentials and username/password.",
+			},
+			"application_credential_id": fwschema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("application_credential_secret")),
+				},
+				Description: "ID of the OpenStack application credential. Takes precedence over username/password.",
+			},
+			"application_credential_secret": fwschema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("application_credential_id")),
+				},
+				Description: "Secret of the OpenStack application credential.",
+			},
+			"max_retries": fwschema.Int64Attribute{
+				Optional:   true,
+				Validators: []validator.Int64{int64validator.AtLeast(0)},
+				Description: "Maximum number of retries of API requests that got 429, 5xx or a connection error. " +
+					"POST and PATCH requests are retried only after 429 or a failed connection.",
+			},
+			"max_backoff": fwschema.Int64Attribute{
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.AtLeast(1)},
+				Description: "Maximum time in seconds to wait between retries of API requests.",
+			},
+			"user_agent_suffix": fwschema.StringAttribute{
+				Optional:    true,
+				Description: "String appended to the User-Agent of API requests to tag the traffic.",
+			},
+			"allow_quotas_below_usage": fwschema.BoolAttribute{
+				Optional:    true,
+				Description: allowQuotasBelowUsageDescription,
+			},
+		},
+		Blocks: map[string]fwschema.Block{
+			"endpoints": fwschema.ListNestedBlock{
+				Description: endpointsDescription,
+				Validators:  []validator.List{listvalidator.SizeAtMost(1)},
+				NestedObject: fwschema.NestedBlockObject{
+					Attributes: endpoints,
+				},
+			},
+			"rate_limit": fwschema.ListNestedBlock{
+				Description: rateLimitDescriptions["rate_limit"],
+				NestedObject: fwschema.NestedBlockObject{
+					Attributes: map[string]fwschema.Attribute{
+						"service": fwschema.StringAttribute{
+							Required:    true,
+							Validators:  []validator.String{stringvalidator.OneOf(rateLimitServiceTypes...)},
+							Description: rateLimitDescriptions["service"],
+						},
+						"requests_per_second": fwschema.Float64Attribute{
+							Optional:    true,
+							Validators:  []validator.Float64{float64validator.AtLeast(0)},
+							Description: rateLimitDescriptions["requests_per_second"],
+						},
+						"burst": fwschema.Int64Attribute{
+							Optional:    true,
+							Validators:  []validator.Int64{int64validator.AtLeast(1)},
+							Description: rateLimitDescriptions["burst"],
+						},
+						"max_in_flight": fwschema.Int64Attribute{
+							Optional:    true,
+							Validators:  []validator.Int64{int64validator.AtLeast(0)},
+							Description: rateLimitDescriptions["max_in_flight"],
+						},
+					},
+				},
+			},
+			"naming_policy": fwschema.ListNestedBlock{
+				Description: namingPolicyDescriptions["naming_policy"],
+				Validators:  []validator.List{listvalidator.SizeAtMost(1)},
+				NestedObject: fwschema.NestedBlockObject{
+					Attributes: map[string]fwschema.Attribute{
+						"pattern": fwschema.StringAttribute{
+							Required:    true,
+							Description: namingPolicyDescriptions["pattern"],
+						},
+						"resources": fwschema.ListAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Description: namingPolicyDescriptions["resources"],
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (p *frameworkProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	var data frameworkProviderModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts, diags := data.options(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userAgent := fmt.Sprintf("Terraform/%s (+https://www.terraform.io) %s/%s", req.TerraformVersion, userAgentProductName, p.version)
+	config, err := p.shared.get(opts, userAgent)
+	if err != nil {
+		resp.Diagnostics.AddError(err.Error(), "")
+
+		return
+	}
+
+	resp.ResourceData = config
+	resp.DataSourceData = config
+	resp.EphemeralResourceData = config
+}
+
+func (p *frameworkProvider) Resources(_ context.Context) []func() resource.Resource {
+	return []func() resource.Resource{
+		newSecretsManagerSecretV1Resource,
+		newSecretsManagerCertificateV1Resource,
+	}
+}
+
+func (p *frameworkProvider) DataSources(_ context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+// EphemeralResources returns the resources which values are never stored in the state or plan.
+func (p *frameworkProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		newMKSKubeconfigV1EphemeralResource,
+		newCRaaSTokenV1EphemeralResource,
+	}
+}
+
+// options applies the environment defaults to the provider block in the same way
+// as the DefaultFunc of the attributes of the SDK provider does.
+func (m *frameworkProviderModel) options(ctx context.Context) (providerOptions, fwdiag.Diagnostics) {
+	var diags fwdiag.Diagnostics
+
+	opts := providerOptions{
+		ProjectID:                   stringValueOrEnv(m.ProjectID, "project_id"),
+		Region:                      stringValueOrEnv(m.Region, "region"),
+		AuthURL:                     stringValueOrEnv(m.AuthURL, "auth_url"),
+		AuthRegion:                  stringValueOrEnv(m.AuthRegion, "auth_region"),
+		DomainName:                  stringValueOrEnv(m.DomainName, "domain_name"),
+		Username:                    stringValueOrEnv(m.Username, "username"),
+		UserDomainName:              stringValueOrEnv(m.UserDomainName, "user_domain_name"),
+		Password:                    stringValueOrEnv(m.Password, "password"),
+		AuthToken:                   stringValueOrEnv(m.AuthToken, "auth_token"),
+		ApplicationCredentialID:     stringValueOrEnv(m.ApplicationCredentialID, "application_credential_id"),
+		ApplicationCredentialSecret: stringValueOrEnv(m.ApplicationCredentialSecret, "application_credential_secret"),
+		UserAgentSuffix:             stringValueOrEnv(m.UserAgentSuffix, "user_agent_suffix"),
+		AllowQuotasBelowUsage:       m.AllowQuotasBelowUsage.ValueBool(),
+		Endpoints:                   make(map[string]string),
+	}
+
+	for _, v := range m.Endpoints.Elements() {
+		endpoints, ok := v.(types.Object)
+		if !ok {
+			continue
+		}
+		for attr, value := range endpoints.Attributes() {
+			if value, ok := value.(types.String); ok && value.ValueString() != "" {
+				opts.Endpoints[attr] = value.ValueString()
+			}
+		}
+	}
+
+	for _, limit := range m.RateLimits {
+		burst := int64(defaultRateLimitBurst)
+		if !limit.Burst.IsNull() && !limit.Burst.IsUnknown() {
+			burst = limit.Burst.ValueInt64()
+		}
+		opts.RateLimits = append(opts.RateLimits, rateLimitOptions{
+			Service:           limit.Service.ValueString(),
+			RequestsPerSecond: limit.RequestsPerSecond.ValueFloat64(),
+			Burst:             int(burst),
+			MaxInFlight:       int(limit.MaxInFlight.ValueInt64()),
+		})
+	}
+
+	for _, policy := range m.NamingPolicy {
+		opts.NamingPolicy = &namingPolicyOptions{
+			Pattern: policy.Pattern.ValueString(),
+		}
+		diags.Append(policy.Resources.ElementsAs(ctx, &opts.NamingPolicy.Resources, false)...)
+	}
+
+	var err error
+	if opts.MaxRetries, err = intValueOrEnv(m.MaxRetries, "max_retries", defaultMaxRetries); err != nil {
+		diags.AddAttributeError(path.Root("max_retries"), err.Error(), "")
+	}
+	if opts.MaxBackoff, err = intValueOrEnv(m.MaxBackoff, "max_backoff", defaultMaxBackoff); err != nil {
+		diags.AddAttributeError(path.Root("max_backoff"), err.Error(), "")
+	}
+
+	return opts, diags
+}
+
+// providerEnvValue returns the value of the first set environment variable of the attribute.
+func providerEnvValue(attr string) (string, bool) {
+	for _, name := range providerEnvVars[attr] {
+		if v := os.Getenv(name); v != "" {
+			return v, true
+		}
+	}
+
+	return "", false
+}
+
+func stringValueOrEnv(v types.String, attr string) string {
+	if !v.IsNull() {
+		return v.ValueString()
+	}
+	value, _ := providerEnvValue(attr)
+
+	return value
+}
+
+func intValueOrEnv(v types.Int64, attr string, defaultValue int) (int, error) {
+	if !v.IsNull() {
+		return int(v.ValueInt64()), nil
+	}
+	value, ok := providerEnvValue(attr)
+	if !ok {
+		return defaultValue, nil
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("can't parse %s from the environment: %w", attr, err)
+	}
+
+	return i, nil
+}
+
+// frameworkResource is embedded into resources of the framework provider
+// to get the Config of the configured provider.
+type frameworkResource struct {
+	config *Config
+}
+
+func (r *frameworkResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	config, diags := frameworkProviderConfig(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	r.config = config
+}
+
+// frameworkEphemeralResource is embedded into ephemeral resources of the framework provider
+// to get the Config of the configured provider.
+type frameworkEphemeralResource struct {
+	config *Config
+}
+
+func (r *frameworkEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	config, diags := frameworkProviderConfig(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	r.config = config
+}
+
+// frameworkProviderConfig returns the Config from the provider data. The data is nil
+// when the provider is not configured yet.
+func frameworkProviderConfig(providerData any) (*Config, fwdiag.Diagnostics) {
+	var diags fwdiag.Diagnostics
+	if providerData == nil {
+		return nil, diags
+	}
+
+	config, ok := providerData.(*Config)
+	if !ok {
+		diags.AddError("Unexpected provider data", fmt.Sprintf("Expected *Config, got %T.", providerData))
+
+		return nil, diags
+	}
+
+	return config, diags
+}
@@ -0,0 +1,68 @@
+package selectel
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ProviderServerFactory returns the factory of the provider server which serves the
+// plugin framework provider and the SDK provider of the given version through a mux server.
+func ProviderServerFactory(ctx context.Context, version string) (func() tfprotov5.ProviderServer, error) {
+	shared := &sharedConfig{}
+
+	return newProviderServerFactory(ctx, newFrameworkProvider(version, shared), newProvider(version, shared))
+}
+
+func newProviderServerFactory(ctx context.Context, frameworkProvider provider.Provider, sdkProvider *schema.Provider) (func() tfprotov5.ProviderServer, error) {
+	// The framework provider is configured first, so the SDK provider reuses its Config.
+	servers := []func() tfprotov5.ProviderServer{
+		providerserver.NewProtocol5(frameworkProvider),
+		sdkProvider.GRPCProvider,
+	}
+
+	muxServer, err := tf5muxserver.NewMuxServer(ctx, servers...)
+	if err != nil {
+		return nil, err
+	}
+
+	return muxServer.ProviderServer, nil
+}
+
+// sharedConfig holds the Config of the providers served through the same mux server.
+// Both of them are configured with the same provider block, so they share auth tokens,
+// cached clients and rate limits rather than building a Config each.
+type sharedConfig struct {
+	mu     sync.Mutex
+	opts   providerOptions
+	config *Config
+}
+
+// get returns the Config built with the same options by the provider configured first,
+// or builds a new one. A nil sharedConfig always builds a new Config.
+func (s *sharedConfig) get(opts providerOptions, userAgent string) (*Config, error) {
+	if s == nil {
+		return newConfig(opts, userAgent)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.config != nil && reflect.DeepEqual(s.opts, opts) {
+		return s.config, nil
+	}
+
+	config, err := newConfig(opts, userAgent)
+	if err != nil {
+		return nil, err
+	}
+	s.opts, s.config = opts, config
+
+	return config, nil
+}
@@ -1,26 +1,36 @@
 package selectel
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
+	"os/exec"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/fakeapi"
 )
 
 var (
-	testAccProviders map[string]func() (*schema.Provider, error)
-	testAccProvider  *schema.Provider
+	testAccProtoV5ProviderFactories map[string]func() (tfprotov5.ProviderServer, error)
+	testAccProvider                 *schema.Provider
 )
 
 func init() {
 	testAccProvider = Provider()
-	testAccProviders = map[string]func() (*schema.Provider, error){
-		"selectel": func() (*schema.Provider, error) {
-			return testAccProvider, nil
+	testAccProtoV5ProviderFactories = map[string]func() (tfprotov5.ProviderServer, error){
+		"selectel": func() (tfprotov5.ProviderServer, error) {
+			// Checks of the tests get the Config of the SDK provider with testAccProvider.Meta().
+			factory, err := newProviderServerFactory(context.Background(), NewFrameworkProvider("dev")(), testAccProvider)
+			if err != nil {
+				return nil, err
+			}
+
+			return factory(), nil
 		},
 	}
 }
@@ -36,6 +46,14 @@ func testAccSelectelPreCheck(t *testing.T) {
 		t.Fatal("OS_DOMAIN_NAME must be set for acceptance tests")
 	}
 
+	if os.Getenv("OS_TOKEN") != "" || os.Getenv("OS_AUTH_TOKEN") != "" {
+		return
+	}
+
+	if os.Getenv("OS_APPLICATION_CREDENTIAL_ID") != "" && os.Getenv("OS_APPLICATION_CREDENTIAL_SECRET") != "" {
+		return
+	}
+
 	if v := os.Getenv("OS_USERNAME"); v == "" {
 		t.Fatal("OS_USERNAME must be set for acceptance tests")
 	}
@@ -45,6 +63,50 @@ func testAccSelectelPreCheck(t *testing.T) {
 	}
 }
 
+// testUnitPreCheck skips unit tests when the Terraform CLI, which runs the test steps, is not available.
+// In CI the missing CLI fails the tests, so they can't be skipped unnoticed.
+func testUnitPreCheck(t *testing.T) {
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") != "" || os.Getenv("TF_ACC_TERRAFORM_VERSION") != "" {
+		return
+	}
+	if _, err := exec.LookPath("terraform"); err != nil {
+		if os.Getenv("CI") != "" {
+			t.Fatal("terraform CLI is not found in PATH, it is required to run unit tests in CI")
+		}
+		t.Skip("terraform CLI is not found in PATH, set TF_ACC_TERRAFORM_PATH to run unit tests")
+	}
+}
+
+// newTestFakeAPI starts the fake Selectel API and clears the provider environment
+// variables, so the provider is configured only with fakeapi.Server.ProviderConfig.
+func newTestFakeAPI(t *testing.T) *fakeapi.Server {
+	t.Helper()
+
+	for _, env := range []string{
+		"INFRA_PROJECT_ID", "INFRA_REGION", "OS_AUTH_URL", "OS_REGION_NAME", "OS_DOMAIN_NAME",
+		"OS_USERNAME", "OS_USER_DOMAIN_NAME", "OS_PASSWORD", "OS_TOKEN", "OS_AUTH_TOKEN",
+		"OS_APPLICATION_CREDENTIAL_ID", "OS_APPLICATION_CREDENTIAL_SECRET",
+	} {
+		t.Setenv(env, "")
+	}
+	for attr := range endpointsServiceTypes {
+		t.Setenv(endpointEnvVar(attr), "")
+	}
+
+	return fakeapi.New(t)
+}
+
+// testUnitCheckFakeAPIEmpty checks that no objects of the kind are left in the fake API.
+func testUnitCheckFakeAPIEmpty(api *fakeapi.Server, kind string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		if n := api.Len(kind); n != 0 {
+			return fmt.Errorf("%d objects of %s are left in the fake API", n, kind)
+		}
+
+		return nil
+	}
+}
+
 func testAccSelectelPreCheckWithProjectID(t *testing.T) {
 	testAccSelectelPreCheck(t)
 	if v := os.Getenv("INFRA_PROJECT_ID"); v == "" {
@@ -0,0 +1,216 @@
+package selectel
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/selectel/go-selvpcclient/v4/selvpcclient/clients"
+	"golang.org/x/time/rate"
+)
+
+// rateLimitServiceTypes are the services that can be limited with the rate_limit block.
+// Requests to Keystone, Resell and Quota Manager APIs are limited as a single resell service,
+// requests to Certificate Manager are limited together with Secrets Manager.
+var rateLimitServiceTypes = []string{
+	DBaaS,
+	MKS,
+	CRaaS,
+	IAM,
+	SecretsManager,
+	Domains,
+	clients.ResellServiceType,
+}
+
+// rateLimitDescriptions are descriptions of the rate_limit block and its attributes.
+var rateLimitDescriptions = map[string]string{
+	"rate_limit":          "Client-side limits of requests to Selectel APIs, set per service type.",
+	"service":             "Service type the limits apply to.",
+	"requests_per_second": "Average number of requests per second. Zero means no limit.",
+	"burst":               "Maximum number of requests sent at once when requests_per_second is set.",
+	"max_in_flight":       "Maximum number of concurrent requests. Zero means no limit.",
+}
+
+// defaultRateLimitBurst is the burst of the rate_limit block if it is not set.
+const defaultRateLimitBurst = 1
+
+func rateLimitSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: rateLimitDescriptions["rate_limit"],
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"service": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice(rateLimitServiceTypes, false),
+					Description:  rateLimitDescriptions["service"],
+				},
+				"requests_per_second": {
+					Type:         schema.TypeFloat,
+					Optional:     true,
+					ValidateFunc: validation.FloatAtLeast(0),
+					Description:  rateLimitDescriptions["requests_per_second"],
+				},
+				"burst": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      defaultRateLimitBurst,
+					ValidateFunc: validation.IntAtLeast(1),
+					Description:  rateLimitDescriptions["burst"],
+				},
+				"max_in_flight": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntAtLeast(0),
+					Description:  rateLimitDescriptions["max_in_flight"],
+				},
+			},
+		},
+	}
+}
+
+// serviceLimiter limits requests to a single service type.
+// It is shared by all HTTP clients of the service within a provider instance.
+type serviceLimiter struct {
+	limiter  *rate.Limiter
+	inFlight chan struct{}
+}
+
+// rateLimitOptions are the settings of a rate_limit block.
+type rateLimitOptions struct {
+	Service           string
+	RequestsPerSecond float64
+	Burst             int
+	MaxInFlight       int
+}
+
+func flattenRateLimitBlocks(d *schema.ResourceData) []rateLimitOptions {
+	var limits []rateLimitOptions
+
+	for _, v := range d.Get("rate_limit").([]interface{}) {
+		limit, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		limits = append(limits, rateLimitOptions{
+			Service:           limit["service"].(string),
+			RequestsPerSecond: limit["requests_per_second"].(float64),
+			Burst:             limit["burst"].(int),
+			MaxInFlight:       limit["max_in_flight"].(int),
+		})
+	}
+
+	return limits
+}
+
+func newServiceLimiters(limits []rateLimitOptions) (map[string]*serviceLimiter, error) {
+	limiters := make(map[string]*serviceLimiter)
+
+	for _, limit := range limits {
+		if _, ok := limiters[limit.Service]; ok {
+			return nil, fmt.Errorf("rate_limit for the %s service is set more than once", limit.Service)
+		}
+
+		serviceLimiter := &serviceLimiter{}
+		if limit.RequestsPerSecond > 0 {
+			serviceLimiter.limiter = rate.NewLimiter(rate.Limit(limit.RequestsPerSecond), limit.Burst)
+		}
+		if limit.MaxInFlight > 0 {
+			serviceLimiter.inFlight = make(chan struct{}, limit.MaxInFlight)
+		}
+
+		limiters[limit.Service] = serviceLimiter
+	}
+
+	return limiters, nil
+}
+
+// rateLimitTransport waits for the token bucket of the service and
+// for a free in-flight slot before every request.
+type rateLimitTransport struct {
+	serviceLimiter *serviceLimiter
+	next           http.RoundTripper
+}
+
+// newRateLimitTransport wraps the transport with the limits of the service type, if there are any.
+func (c *Config) newRateLimitTransport(serviceType string, next http.RoundTripper) http.RoundTripper {
+	serviceLimiter, ok := c.limiters[serviceType]
+	if !ok {
+		return next
+	}
+
+	return &rateLimitTransport{
+		serviceLimiter: serviceLimiter,
+		next:           next,
+	}
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if t.serviceLimiter.inFlight != nil {
+		select {
+		case t.serviceLimiter.inFlight <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	release := func() {}
+	if t.serviceLimiter.inFlight != nil {
+		var once sync.Once
+		release = func() {
+			once.Do(func() {
+				<-t.serviceLimiter.inFlight
+			})
+		}
+	}
+
+	if t.serviceLimiter.limiter != nil {
+		if err := t.serviceLimiter.limiter.Wait(ctx); err != nil {
+			release()
+
+			return nil, err
+		}
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		release()
+
+		return nil, err
+	}
+
+	// The slot is taken until the response body is read to the end or closed.
+	resp.Body = &releasingReadCloser{ReadCloser: resp.Body, release: release}
+
+	return resp, nil
+}
+
+// releasingReadCloser releases the in-flight slot when the body is closed, or when it is read
+// to the end or fails to be read, as some API clients read the body without closing it.
+type releasingReadCloser struct {
+	io.ReadCloser
+	release func()
+}
+
+func (r *releasingReadCloser) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if err != nil {
+		r.release()
+	}
+
+	return n, err
+}
+
+func (r *releasingReadCloser) Close() error {
+	defer r.release()
+
+	return r.ReadCloser.Close()
+}
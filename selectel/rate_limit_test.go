@@ -0,0 +1,182 @@
+package selectel
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestNewServiceLimiters(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"rate_limit": []interface{}{
+			map[string]interface{}{
+				"service":             DBaaS,
+				"requests_per_second": 2.5,
+				"burst":               5,
+				"max_in_flight":       4,
+			},
+			map[string]interface{}{
+				"service":       MKS,
+				"max_in_flight": 1,
+			},
+		},
+	})
+
+	limiters, err := newServiceLimiters(flattenRateLimitBlocks(d))
+	require.NoError(t, err)
+	require.Len(t, limiters, 2)
+
+	assert.Equal(t, rate.Limit(2.5), limiters[DBaaS].limiter.Limit())
+	assert.Equal(t, 5, limiters[DBaaS].limiter.Burst())
+	assert.Equal(t, 4, cap(limiters[DBaaS].inFlight))
+
+	assert.Nil(t, limiters[MKS].limiter)
+	assert.Equal(t, 1, cap(limiters[MKS].inFlight))
+}
+
+func TestNewServiceLimitersDuplicateService(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"rate_limit": []interface{}{
+			map[string]interface{}{
+				"service":       DBaaS,
+				"max_in_flight": 4,
+			},
+			map[string]interface{}{
+				"service":       DBaaS,
+				"max_in_flight": 1,
+			},
+		},
+	})
+
+	_, err := newServiceLimiters(flattenRateLimitBlocks(d))
+	assert.EqualError(t, err, "rate_limit for the managed-database service is set more than once")
+}
+
+func TestNewRateLimitTransportWithoutLimits(t *testing.T) {
+	config := &Config{}
+	next := http.DefaultTransport
+
+	assert.Equal(t, next, config.newRateLimitTransport(DBaaS, next))
+}
+
+func TestRateLimitTransportMaxInFlight(t *testing.T) {
+	var inFlight, maxInFlight int32
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			observed := atomic.LoadInt32(&maxInFlight)
+			if current <= observed || atomic.CompareAndSwapInt32(&maxInFlight, observed, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	}))
+	t.Cleanup(api.Close)
+
+	config := &Config{
+		limiters: map[string]*serviceLimiter{
+			DBaaS: {inFlight: make(chan struct{}, 2)},
+		},
+	}
+	client := &http.Client{
+		Transport: config.newRateLimitTransport(DBaaS, config.httpTransport()),
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(api.URL)
+			if assert.NoError(t, err) {
+				resp.Body.Close()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(2))
+	assert.Empty(t, config.limiters[DBaaS].inFlight)
+}
+
+func TestRateLimitTransportBodyIsNotClosed(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id": "1"}`))
+	}))
+	t.Cleanup(api.Close)
+
+	config := &Config{
+		limiters: map[string]*serviceLimiter{
+			DBaaS: {inFlight: make(chan struct{}, 1)},
+		},
+	}
+	client := &http.Client{
+		Transport: config.newRateLimitTransport(DBaaS, config.httpTransport()),
+	}
+
+	// The body is read to the end, but is never closed.
+	for i := 0; i < 3; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, api.URL, nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req) //nolint:bodyclose // The body is deliberately not closed.
+		require.NoError(t, err)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"id": "1"}`, string(body))
+		cancel()
+	}
+	assert.Empty(t, config.limiters[DBaaS].inFlight)
+
+	// The body is never read, the next request waits for the slot only until its context is done.
+	resp, err := client.Get(api.URL)
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, api.URL, nil)
+	require.NoError(t, err)
+	_, err = client.Do(req) //nolint:bodyclose // The request fails.
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	resp.Body.Close()
+	assert.Empty(t, config.limiters[DBaaS].inFlight)
+}
+
+func TestRateLimitTransportRequestsPerSecond(t *testing.T) {
+	var calls int32
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	t.Cleanup(api.Close)
+
+	config := &Config{
+		limiters: map[string]*serviceLimiter{
+			MKS: {limiter: rate.NewLimiter(rate.Limit(20), 1)},
+		},
+	}
+	client := &http.Client{
+		Transport: config.newRateLimitTransport(MKS, config.httpTransport()),
+	}
+
+	started := time.Now()
+	for i := 0; i < 5; i++ {
+		resp, err := client.Get(api.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	// The first request takes the only token of the bucket, the rest wait 50ms each.
+	assert.GreaterOrEqual(t, time.Since(started), 150*time.Millisecond)
+	assert.Equal(t, int32(5), atomic.LoadInt32(&calls))
+}
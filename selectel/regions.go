@@ -1,12 +1,31 @@
 package selectel
 
 import (
+	"context"
 	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/selectel/go-selvpcclient/v4/selvpcclient"
+	"github.com/selectel/go-selvpcclient/v4/selvpcclient/clients"
 )
 
+// regionServiceTypes maps prefixes of resource names to the service types
+// which endpoints define the available regions of the resources.
+// Only the resources with the region attribute are validated.
+var regionServiceTypes = map[string]string{
+	"selectel_craas_":          CRaaS,
+	"selectel_dbaas_":          DBaaS,
+	"selectel_domains_":        Domains,
+	"selectel_mks_":            MKS,
+	"selectel_secretsmanager_": SecretsManager,
+	"selectel_vpc_":            clients.ResellServiceType,
+}
+
 func expandVPCV2Regions(rawRegions *schema.Set) []string {
 	regions := rawRegions.List()
 
@@ -19,20 +38,139 @@ func expandVPCV2Regions(rawRegions *schema.Set) []string {
 	return expandedRegions
 }
 
+// validateRegion checks that the service type has an endpoint in the region.
+// The catalog is fetched once per project scope and cached in the selvpc client.
 func validateRegion(selvpcClient *selvpcclient.Client, serviceType string, region string) error {
 	endpoints, err := selvpcClient.Catalog.GetEndpoints(serviceType)
 	if err != nil {
 		return fmt.Errorf("can't get endpoints for %s to validate region: %w", serviceType, err)
 	}
 
-	endpointRegions := make([]string, 0)
+	endpointRegions := make([]string, 0, len(endpoints))
 
 	for _, endpoint := range endpoints {
-		if endpoint.Region == region {
+		if endpoint.RegionID == region {
+			return nil
+		}
+		if !slices.Contains(endpointRegions, endpoint.RegionID) {
+			endpointRegions = append(endpointRegions, endpoint.RegionID)
+		}
+	}
+	sort.Strings(endpointRegions)
+
+	return fmt.Errorf("region %q is not available for %s, region value must contain one of the values: %+q",
+		region, serviceType, endpointRegions)
+}
+
+// setRegionValidation makes resources of the provider validate their region
+// against the service catalog when the plan is made rather than when the resource is created.
+func setRegionValidation(p *schema.Provider) {
+	for name, r := range p.ResourcesMap {
+		if _, ok := r.Schema["region"]; !ok {
+			continue
+		}
+		for prefix, serviceType := range regionServiceTypes {
+			if !strings.HasPrefix(name, prefix) {
+				continue
+			}
+			funcs := []schema.CustomizeDiffFunc{customizeDiffRegion(serviceType)}
+			if r.CustomizeDiff != nil {
+				funcs = append(funcs, r.CustomizeDiff)
+			}
+			r.CustomizeDiff = customdiff.Sequence(funcs...)
+		}
+	}
+}
+
+// customizeDiffRegions validates the regions of new resources that span several regions,
+// and of resources which regions change, the same way customizeDiffRegion does for a single region.
+func customizeDiffRegions(serviceType string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		config := meta.(*Config)
+
+		if _, ok := config.endpointOverride(serviceType); ok {
+			return nil
+		}
+		if d.Id() != "" && !d.HasChange("regions") && !d.HasChange("project_id") {
+			return nil
+		}
+		if !d.NewValueKnown("regions") {
+			log.Printf("[DEBUG] Skipping validation of the %s regions: regions are not known yet", serviceType)
+
+			return nil
+		}
+
+		selvpcClient, err := regionValidationClient(config, d)
+		if err != nil || selvpcClient == nil {
+			return err
+		}
+
+		regions := expandVPCV2Regions(d.Get("regions").(*schema.Set))
+		sort.Strings(regions)
+		for _, region := range regions {
+			if err := validateRegion(selvpcClient, serviceType, region); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// customizeDiffRegion validates the region of new resources and resources which region changes.
+// The validation is skipped if the region is not known yet, or if the service has a custom endpoint.
+func customizeDiffRegion(serviceType string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		config := meta.(*Config)
+
+		if _, ok := config.endpointOverride(serviceType); ok {
+			return nil
+		}
+		if d.Id() != "" && !d.HasChange("region") && !d.HasChange("project_id") {
+			return nil
+		}
+		if !d.NewValueKnown("region") {
+			log.Printf("[DEBUG] Skipping validation of the %s region: region is not known yet", serviceType)
+
+			return nil
+		}
+
+		region := d.Get("region").(string)
+		if region == "" {
 			return nil
 		}
-		endpointRegions = append(endpointRegions, endpoint.RegionID)
+
+		selvpcClient, err := regionValidationClient(config, d)
+		if err != nil || selvpcClient == nil {
+			return err
+		}
+
+		return validateRegion(selvpcClient, serviceType, region)
+	}
+}
+
+// regionValidationClient returns the selvpc client which catalog is used to validate the regions
+// of the resource. While the project is not known yet, for example when it is created in the same plan,
+// the catalog of the domain scope is used. The client is nil if the project is not set.
+func regionValidationClient(config *Config, d *schema.ResourceDiff) (*selvpcclient.Client, error) {
+	if !d.NewValueKnown("project_id") {
+		selvpcClient, err := config.GetSelVPCClient()
+		if err != nil {
+			return nil, fmt.Errorf("can't get domain-scope selvpc client to validate region: %w", err)
+		}
+
+		return selvpcClient, nil
+	}
+
+	projectID := d.Get("project_id").(string)
+	if projectID == "" {
+		return nil, nil
+	}
+
+	selvpcClient, err := config.GetSelVPCClientWithProjectScope(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("can't get project-scope selvpc client to validate region: %w", err)
 	}
 
-	return fmt.Errorf("region value must contain one of the values: %+q", endpointRegions)
+	return selvpcClient, nil
 }
@@ -1,10 +1,15 @@
 package selectel
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/selectel/go-selvpcclient/v4/selvpcclient/clients"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -24,3 +29,152 @@ func TestExpandVPCV2Regions(t *testing.T) {
 
 	assert.ElementsMatch(t, expected, actual)
 }
+
+func TestValidateRegion(t *testing.T) {
+	config := testProviderDefaultsConfig(t, "project-a", "ru-1")
+	selvpcClient, err := config.GetSelVPCClientWithProjectScope("project-a")
+	require.NoError(t, err)
+
+	assert.NoError(t, validateRegion(selvpcClient, MKS, "ru-7"))
+
+	err = validateRegion(selvpcClient, MKS, testRu3Region)
+	require.Error(t, err)
+	assert.Equal(t, `region "ru-3" is not available for managed-kubernetes, `+
+		`region value must contain one of the values: ["ru-1" "ru-7"]`, err.Error())
+}
+
+func TestCustomizeDiffRegionInvalidRegion(t *testing.T) {
+	config := testProviderDefaultsConfig(t, "project-a", testRu3Region)
+
+	_, err := testResourceDiff(t, Provider().ResourcesMap["selectel_mks_cluster_v1"], nil, map[string]string{
+		"name": "cluster",
+	}, config)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `region "ru-3" is not available for managed-kubernetes`)
+	assert.Contains(t, err.Error(), `["ru-1" "ru-7"]`)
+}
+
+func TestCustomizeDiffRegionValidRegion(t *testing.T) {
+	config := testProviderDefaultsConfig(t, "project-a", "ru-1")
+	r := Provider().ResourcesMap["selectel_vpc_floatingip_v2"]
+
+	diff, err := testResourceDiff(t, r, nil, map[string]string{"region": testRu3Region}, config)
+	require.NoError(t, err)
+	assert.Equal(t, testRu3Region, diff.Attributes["region"].New)
+
+	_, err = testResourceDiff(t, r, nil, map[string]string{"region": "ru-7"}, config)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `region "ru-7" is not available for resell`)
+}
+
+func TestCustomizeDiffRegionSkipsUnchangedRegion(t *testing.T) {
+	config := testProviderDefaultsConfig(t, "project-a", "ru-1")
+	// The region has been removed from the catalog after the resource was created.
+	state := &terraform.InstanceState{
+		ID: "floatingip-1",
+		Attributes: map[string]string{
+			"id":         "floatingip-1",
+			"project_id": "project-a",
+			"region":     "ru-9",
+		},
+	}
+
+	_, err := testResourceDiff(t, Provider().ResourcesMap["selectel_vpc_floatingip_v2"], state, map[string]string{
+		"region": "ru-9",
+	}, config)
+	assert.NoError(t, err)
+}
+
+func TestCustomizeDiffRegionSkipsEndpointOverride(t *testing.T) {
+	config := testProviderDefaultsConfig(t, "project-a", "ru-1")
+	config.Endpoints = map[string]string{clients.ResellServiceType: "http://127.0.0.1:8080/resell"}
+
+	_, err := testResourceDiff(t, Provider().ResourcesMap["selectel_vpc_floatingip_v2"], nil, map[string]string{
+		"region": "ru-9",
+	}, config)
+	assert.NoError(t, err)
+}
+
+func TestCustomizeDiffRegions(t *testing.T) {
+	config := testProviderDefaultsConfig(t, "project-a", "ru-1")
+	r := Provider().ResourcesMap["selectel_vpc_crossregion_subnet_v2"]
+
+	regions := func(regions ...string) map[string]cty.Value {
+		values := make([]cty.Value, len(regions))
+		for i, region := range regions {
+			values[i] = cty.StringVal(region)
+		}
+
+		return map[string]cty.Value{
+			"regions": cty.SetVal(values),
+			"cidr":    cty.StringVal("192.168.200.0/24"),
+		}
+	}
+
+	diff, err := testResourceDiffValues(t, r, nil, regions("ru-1", testRu3Region), config)
+	require.NoError(t, err)
+	assert.Equal(t, "project-a", diff.Attributes["project_id"].New)
+
+	_, err = testResourceDiffValues(t, r, nil, regions("ru-1", "ru-7"), config)
+	require.Error(t, err)
+	assert.Equal(t, `region "ru-7" is not available for resell, `+
+		`region value must contain one of the values: ["ru-1" "ru-3"]`, err.Error())
+
+	// The regions have been removed from the catalog after the resource was created.
+	state := &terraform.InstanceState{
+		ID: "1",
+		Attributes: map[string]string{
+			"id":         "1",
+			"project_id": "project-a",
+			"cidr":       "192.168.200.0/24",
+			"regions.#":  "2",
+			"regions.0":  "ru-8",
+			"regions.1":  "ru-9",
+			"subnets.#":  "0",
+			"servers.#":  "0",
+			"vlan_id":    "1001",
+			"status":     "DOWN",
+		},
+	}
+	_, err = testResourceDiffValues(t, r, state, regions("ru-8", "ru-9"), config)
+	assert.NoError(t, err)
+}
+
+func TestCustomizeDiffRegionUnknownProject(t *testing.T) {
+	config := testProviderDefaultsConfig(t, "project-a", "ru-1")
+	r := Provider().ResourcesMap["selectel_mks_cluster_v1"]
+
+	// The project is created in the same plan, the region is validated against the catalog of the domain scope.
+	_, err := testResourceDiffValues(t, r, nil, map[string]cty.Value{
+		"name":       cty.StringVal("cluster"),
+		"project_id": cty.UnknownVal(cty.String),
+		"region":     cty.StringVal("ru-7"),
+	}, config)
+	require.NoError(t, err)
+
+	_, err = testResourceDiffValues(t, r, nil, map[string]cty.Value{
+		"name":       cty.StringVal("cluster"),
+		"project_id": cty.UnknownVal(cty.String),
+		"region":     cty.StringVal(testRu3Region),
+	}, config)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `region "ru-3" is not available for managed-kubernetes`)
+}
+
+func TestRegionServiceTypesCoverResources(t *testing.T) {
+	for name, r := range Provider().ResourcesMap {
+		if _, ok := r.Schema["region"]; !ok {
+			continue
+		}
+		assert.Condition(t, func() bool {
+			for prefix := range regionServiceTypes {
+				if strings.HasPrefix(name, prefix) {
+					return true
+				}
+			}
+
+			return false
+		}, "the region of %s is not validated", name)
+	}
+}
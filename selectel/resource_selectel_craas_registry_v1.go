@@ -6,17 +6,15 @@ import (
 	"fmt"
 	"log"
 	"net/http"
-	"strconv"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/selectel/craas-go/pkg/v1/registry"
 )
 
 func resourceCRaaSRegistryV1() *schema.Resource {
-	return &schema.Resource{
+	return withDeletionProtection(objectRegistry, &schema.Resource{
 		CreateContext: resourceCRaaSRegistryV1Create,
 		ReadContext:   resourceCRaaSRegistryV1Read,
 		DeleteContext: resourceCRaaSRegistryV1Delete,
@@ -35,7 +33,8 @@ func resourceCRaaSRegistryV1() *schema.Resource {
 			},
 			"project_id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"status": {
@@ -47,7 +46,7 @@ func resourceCRaaSRegistryV1() *schema.Resource {
 				Computed: true,
 			},
 		},
-	}
+	})
 }
 
 func resourceCRaaSRegistryV1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -61,14 +60,14 @@ func resourceCRaaSRegistryV1Create(ctx context.Context, d *schema.ResourceData,
 	log.Print(msgCreate(objectRegistry, name))
 	newRegistry, _, err := registry.Create(ctx, craasClient, name)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectRegistry, err))
+		return diagCreatingObject(objectRegistry, err)
 	}
 
 	log.Printf("[DEBUG] Waiting for registry %s to achieve a stable state", newRegistry.ID)
 	timeout := d.Timeout(schema.TimeoutCreate)
 	err = waitForCRaaSRegistryV1StableState(ctx, craasClient, newRegistry.ID, timeout)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectRegistry, err))
+		return diagCreatingObject(objectRegistry, err)
 	}
 
 	d.SetId(newRegistry.ID)
@@ -97,7 +96,7 @@ func resourceCRaaSRegistryV1Read(ctx context.Context, d *schema.ResourceData, me
 			}
 		}
 
-		return diag.FromErr(errGettingObject(objectRegistry, d.Id(), err))
+		return diagGettingObject(objectRegistry, d.Id(), err)
 	}
 
 	d.Set("name", craasRegistry.Name)
@@ -116,33 +115,14 @@ func resourceCRaaSRegistryV1Delete(ctx context.Context, d *schema.ResourceData,
 	log.Print(msgDelete(objectRegistry, d.Id()))
 	_, err := registry.Delete(ctx, craasClient, d.Id())
 	if err != nil {
-		return diag.FromErr(errDeletingObject(objectRegistry, d.Id(), err))
-	}
-
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{strconv.Itoa(http.StatusOK)},
-		Target:  []string{strconv.Itoa(http.StatusNotFound)},
-		Timeout: d.Timeout(schema.TimeoutDelete),
-		Refresh: func() (result interface{}, state string, err error) {
-			result, response, err := registry.Get(ctx, craasClient, d.Id())
-			if err != nil {
-				if response != nil {
-					return result, strconv.Itoa(response.StatusCode), nil
-				}
-
-				return nil, "", err
-			}
-
-			return result, strconv.Itoa(response.StatusCode), err
-		},
-		Delay:        1 * time.Second,
-		PollInterval: 1 * time.Second,
+		return diagDeletingObject(objectRegistry, d.Id(), err)
 	}
 
 	log.Printf("[DEBUG] Waiting for registry %s to become deleted", d.Id())
-	_, err = stateConf.WaitForStateContext(ctx)
+	timeout := d.Timeout(schema.TimeoutDelete)
+	err = waitForCRaaSRegistryV1Deleted(ctx, craasClient, d.Id(), timeout)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error waiting for registry %s to become deleted: %s", d.Id(), err))
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/selectel/craas-go/pkg/v1/registry"
 	"github.com/selectel/go-selvpcclient/v4/selvpcclient/resell/v2/projects"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/fakeapi"
 )
 
 const craasV1RegistryHostName = "https://cr.selcloud.ru"
@@ -26,9 +27,9 @@ func TestAccCRaaSRegistryV1Basic(t *testing.T) {
 	registryEndpoint := fmt.Sprintf("%s/%s", craasV1RegistryHostName, registryName)
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCRaaSRegistryV1Basic(projectName, registryName),
@@ -44,6 +45,31 @@ func TestAccCRaaSRegistryV1Basic(t *testing.T) {
 	})
 }
 
+func TestUnitCRaaSRegistryV1Basic(t *testing.T) {
+	var craasRegistry registry.Registry
+
+	api := newTestFakeAPI(t)
+	registryName := acctest.RandomWithPrefix("tf-unit-reg")
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testUnitCheckFakeAPIEmpty(api, fakeapi.KindCRaaSRegistries),
+		Steps: []resource.TestStep{
+			{
+				Config: api.ProviderConfig() + testUnitCRaaSRegistryV1Basic(registryName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCRaaSRegistryV1Exists("selectel_craas_registry_v1.registry_tf_acc_test_1", &craasRegistry),
+					resource.TestCheckResourceAttr("selectel_craas_registry_v1.registry_tf_acc_test_1", "name", registryName),
+					resource.TestCheckResourceAttr("selectel_craas_registry_v1.registry_tf_acc_test_1", "project_id", fakeapi.ProjectID),
+					resource.TestCheckResourceAttr("selectel_craas_registry_v1.registry_tf_acc_test_1", "status", "ACTIVE"),
+					resource.TestCheckResourceAttr("selectel_craas_registry_v1.registry_tf_acc_test_1", "endpoint", fmt.Sprintf("%s/%s", api.URL, registryName)),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckCRaaSRegistryV1Exists(n string, craasRegistry *registry.Registry) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -90,3 +116,11 @@ resource "selectel_craas_registry_v1" "registry_tf_acc_test_1" {
 
 `, projectName, registryName)
 }
+
+func testUnitCRaaSRegistryV1Basic(registryName string) string {
+	return fmt.Sprintf(`
+resource "selectel_craas_registry_v1" "registry_tf_acc_test_1" {
+  name = "%s"
+}
+`, registryName)
+}
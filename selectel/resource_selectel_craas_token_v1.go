@@ -21,7 +21,8 @@ func resourceCRaaSTokenV1() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"project_id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"token_ttl": {
@@ -62,7 +63,7 @@ func resourceCRaaSTokenV1Create(ctx context.Context, d *schema.ResourceData, met
 	log.Print(msgCreate(objectRegistryToken, createOpts))
 	newToken, _, err := token.Create(ctx, craasClient, createOpts)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectRegistryToken, err))
+		return diagCreatingObject(objectRegistryToken, err)
 	}
 
 	tokenID := strconv.Itoa(hashcode.String(newToken.Token))
@@ -88,7 +89,7 @@ func resourceCRaaSTokenV1Read(ctx context.Context, d *schema.ResourceData, meta
 			}
 		}
 
-		return diag.FromErr(errGettingObject(objectRegistryToken, d.Id(), err))
+		return diagGettingObject(objectRegistryToken, d.Id(), err)
 	}
 
 	d.Set("username", craasV1TokenUsername)
@@ -106,7 +107,7 @@ func resourceCRaaSTokenV1Delete(ctx context.Context, d *schema.ResourceData, met
 	log.Print(msgDelete(objectRegistryToken, d.Id()))
 	_, err := token.Revoke(ctx, craasClient, d.Get("token").(string))
 	if err != nil {
-		return diag.FromErr(errDeletingObject(objectRegistryToken, d.Id(), err))
+		return diagDeletingObject(objectRegistryToken, d.Id(), err)
 	}
 
 	return nil
@@ -3,22 +3,18 @@ package selectel
 import (
 	"context"
 	"errors"
-	"fmt"
 	"log"
-	"net/http"
-	"strconv"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/selectel/dbaas-go"
 	waiters "github.com/terraform-providers/terraform-provider-selectel/selectel/waiters/dbaas"
 )
 
 func resourceDBaaSDatastoreV1() *schema.Resource {
-	return &schema.Resource{
+	return withDeletionProtection(objectDatastore, &schema.Resource{
 		CreateContext: resourceDBaaSDatastoreV1Create,
 		ReadContext:   resourceDBaaSDatastoreV1Read,
 		UpdateContext: resourceDBaaSDatastoreV1Update,
@@ -35,7 +31,7 @@ func resourceDBaaSDatastoreV1() *schema.Resource {
 			Delete: schema.DefaultTimeout(60 * time.Minute),
 		},
 		Schema: resourceDBaaSDatastoreV1Schema(),
-	}
+	})
 }
 
 func resourceDBaaSDatastoreV1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -107,14 +103,14 @@ func resourceDBaaSDatastoreV1Create(ctx context.Context, d *schema.ResourceData,
 	log.Print(msgCreate(objectDatastore, datastoreCreateOpts))
 	datastore, err := dbaasClient.CreateDatastore(ctx, datastoreCreateOpts)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectDatastore, err))
+		return diagCreatingObject(objectDatastore, err)
 	}
 
 	log.Printf("[DEBUG] waiting for datastore %s to become 'ACTIVE'", datastore.ID)
 	timeout := d.Timeout(schema.TimeoutCreate)
 	err = waiters.WaitForDBaaSDatastoreV1ActiveState(ctx, dbaasClient, datastore.ID, timeout)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectDatastore, err))
+		return diagCreatingObject(objectDatastore, err)
 	}
 
 	d.SetId(datastore.ID)
@@ -131,7 +127,7 @@ func resourceDBaaSDatastoreV1Read(ctx context.Context, d *schema.ResourceData, m
 	log.Print(msgGet(objectDatastore, d.Id()))
 	datastore, err := dbaasClient.Datastore(ctx, d.Id())
 	if err != nil {
-		return diag.FromErr(errGettingObject(objectDatastore, d.Id(), err))
+		return diagGettingObject(objectDatastore, d.Id(), err)
 	}
 	d.Set("name", datastore.Name)
 	d.Set("status", datastore.Status)
@@ -235,22 +231,14 @@ func resourceDBaaSDatastoreV1Delete(ctx context.Context, d *schema.ResourceData,
 	log.Print(msgDelete(objectDatastore, d.Id()))
 	err := dbaasClient.DeleteDatastore(ctx, d.Id())
 	if err != nil {
-		return diag.FromErr(errDeletingObject(objectDatastore, d.Id(), err))
-	}
-
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{strconv.Itoa(http.StatusOK)},
-		Target:     []string{strconv.Itoa(http.StatusNotFound)},
-		Refresh:    waiters.DBaaSDatastoreV1DeleteStateRefreshFunc(ctx, dbaasClient, d.Id()),
-		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      10 * time.Second,
-		MinTimeout: 20 * time.Second,
+		return diagDeletingObject(objectDatastore, d.Id(), err)
 	}
 
 	log.Printf("[DEBUG] waiting for datastore %s to become deleted", d.Id())
-	_, err = stateConf.WaitForStateContext(ctx)
+	timeout := d.Timeout(schema.TimeoutDelete)
+	err = waiters.WaitForDBaaSDatastoreV1Deleted(ctx, dbaasClient, d.Id(), timeout)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error waiting for the datastore %s to become deleted: %s", d.Id(), err))
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -3,14 +3,10 @@ package selectel
 import (
 	"context"
 	"errors"
-	"fmt"
 	"log"
-	"net/http"
-	"strconv"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/selectel/dbaas-go"
 	waiters "github.com/terraform-providers/terraform-provider-selectel/selectel/waiters/dbaas"
@@ -48,14 +44,14 @@ func resourceDBaaSExtensionV1Create(ctx context.Context, d *schema.ResourceData,
 	log.Print(msgCreate(objectExtension, extensionCreateOpts))
 	extension, err := dbaasClient.CreateExtension(ctx, extensionCreateOpts)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectExtension, err))
+		return diagCreatingObject(objectExtension, err)
 	}
 
 	log.Printf("[DEBUG] waiting for extension %s to become 'ACTIVE'", extension.ID)
 	timeout := d.Timeout(schema.TimeoutCreate)
 	err = waiters.WaitForDBaaSExtensionV1ActiveState(ctx, dbaasClient, extension.ID, timeout)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectExtension, err))
+		return diagCreatingObject(objectExtension, err)
 	}
 
 	d.SetId(extension.ID)
@@ -72,7 +68,7 @@ func resourceDBaaSExtensionV1Read(ctx context.Context, d *schema.ResourceData, m
 	log.Print(msgGet(objectExtension, d.Id()))
 	extension, err := dbaasClient.Extension(ctx, d.Id())
 	if err != nil {
-		return diag.FromErr(errGettingObject(objectExtension, d.Id(), err))
+		return diagGettingObject(objectExtension, d.Id(), err)
 	}
 
 	d.Set("available_extension_id", extension.AvailableExtensionID)
@@ -91,22 +87,14 @@ func resourceDBaaSExtensionV1Delete(ctx context.Context, d *schema.ResourceData,
 	log.Print(msgDelete(objectExtension, d.Id()))
 	err := dbaasClient.DeleteExtension(ctx, d.Id())
 	if err != nil {
-		return diag.FromErr(errGettingObject(objectExtension, d.Id(), err))
-	}
-
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{strconv.Itoa(http.StatusOK)},
-		Target:     []string{strconv.Itoa(http.StatusNotFound)},
-		Refresh:    waiters.DBaaSExtensionV1DeleteStateRefreshFunc(ctx, dbaasClient, d.Id()),
-		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      10 * time.Second,
-		MinTimeout: 20 * time.Second,
+		return diagGettingObject(objectExtension, d.Id(), err)
 	}
 
 	log.Printf("[DEBUG] waiting for extension %s to become deleted", d.Id())
-	_, err = stateConf.WaitForStateContext(ctx)
+	timeout := d.Timeout(schema.TimeoutDelete)
+	err = waiters.WaitForDBaaSExtensionV1Deleted(ctx, dbaasClient, d.Id(), timeout)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error waiting for the extension %s to become deleted: %s", d.Id(), err))
+		return diag.FromErr(err)
 	}
 
 	return nil
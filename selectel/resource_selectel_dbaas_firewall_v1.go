@@ -48,14 +48,14 @@ func resourceDBaaSFirewallV1Update(ctx context.Context, d *schema.ResourceData,
 	log.Print(msgUpdate(objectDatastore, datastoreID, firewallOpts))
 	_, err = dbaasClient.FirewallDatastore(ctx, datastoreID, firewallOpts)
 	if err != nil {
-		return diag.FromErr(errUpdatingObject(objectDatastore, datastoreID, err))
+		return diagUpdatingObject(objectDatastore, datastoreID, err)
 	}
 
 	log.Printf("[DEBUG] waiting for datastore %s to become 'ACTIVE'", datastoreID)
 	timeout := d.Timeout(schema.TimeoutUpdate)
 	err = waiters.WaitForDBaaSDatastoreV1ActiveState(ctx, dbaasClient, datastoreID, timeout)
 	if err != nil {
-		return diag.FromErr(errUpdatingObject(objectDatastore, datastoreID, err))
+		return diagUpdatingObject(objectDatastore, datastoreID, err)
 	}
 
 	return resourceDBaaSFirewallV1Read(ctx, d, meta)
@@ -72,7 +72,7 @@ func resourceDBaaSFirewallV1Read(ctx context.Context, d *schema.ResourceData, me
 	log.Print(msgGet(objectDatastore, datastoreID))
 	datastore, err := dbaasClient.Datastore(ctx, datastoreID)
 	if err != nil {
-		return diag.FromErr(errGettingObject(objectDatastore, datastoreID, err))
+		return diagGettingObject(objectDatastore, datastoreID, err)
 	}
 
 	checksum, err := firewallChecksum(datastore.Firewall, datastoreID)
@@ -97,14 +97,14 @@ func resourceDBaaSFirewallV1Delete(ctx context.Context, d *schema.ResourceData,
 	log.Print(msgUpdate(objectDatastore, datastoreID, firewallOpts))
 	_, err := dbaasClient.FirewallDatastore(ctx, datastoreID, firewallOpts)
 	if err != nil {
-		return diag.FromErr(errUpdatingObject(objectDatastore, datastoreID, err))
+		return diagUpdatingObject(objectDatastore, datastoreID, err)
 	}
 
 	log.Printf("[DEBUG] waiting for datastore %s to become 'ACTIVE'", datastoreID)
 	timeout := d.Timeout(schema.TimeoutUpdate)
 	err = waiters.WaitForDBaaSDatastoreV1ActiveState(ctx, dbaasClient, datastoreID, timeout)
 	if err != nil {
-		return diag.FromErr(errUpdatingObject(objectDatastore, datastoreID, err))
+		return diagUpdatingObject(objectDatastore, datastoreID, err)
 	}
 
 	return nil
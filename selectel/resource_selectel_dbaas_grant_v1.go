@@ -3,14 +3,10 @@ package selectel
 import (
 	"context"
 	"errors"
-	"fmt"
 	"log"
-	"net/http"
-	"strconv"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/selectel/dbaas-go"
 	waiters "github.com/terraform-providers/terraform-provider-selectel/selectel/waiters/dbaas"
@@ -48,14 +44,14 @@ func resourceDBaaSGrantV1Create(ctx context.Context, d *schema.ResourceData, met
 	log.Print(msgCreate(objectGrant, grantCreateOpts))
 	grant, err := dbaasClient.CreateGrant(ctx, grantCreateOpts)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectGrant, err))
+		return diagCreatingObject(objectGrant, err)
 	}
 
 	log.Printf("[DEBUG] waiting for grant %s to become 'ACTIVE'", grant.ID)
 	timeout := d.Timeout(schema.TimeoutCreate)
 	err = waiters.WaitForDBaaSGrantV1ActiveState(ctx, dbaasClient, grant.ID, timeout)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectGrant, err))
+		return diagCreatingObject(objectGrant, err)
 	}
 
 	d.SetId(grant.ID)
@@ -72,7 +68,7 @@ func resourceDBaaSGrantV1Read(ctx context.Context, d *schema.ResourceData, meta
 	log.Print(msgGet(objectGrant, d.Id()))
 	grant, err := dbaasClient.Grant(ctx, d.Id())
 	if err != nil {
-		return diag.FromErr(errGettingObject(objectGrant, d.Id(), err))
+		return diagGettingObject(objectGrant, d.Id(), err)
 	}
 	d.Set("user_id", grant.UserID)
 	d.Set("database_id", grant.DatabaseID)
@@ -91,22 +87,14 @@ func resourceDBaaSGrantV1Delete(ctx context.Context, d *schema.ResourceData, met
 	log.Print(msgDelete(objectGrant, d.Id()))
 	err := dbaasClient.DeleteGrant(ctx, d.Id())
 	if err != nil {
-		return diag.FromErr(errDeletingObject(objectGrant, d.Id(), err))
-	}
-
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{strconv.Itoa(http.StatusOK)},
-		Target:     []string{strconv.Itoa(http.StatusNotFound)},
-		Refresh:    waiters.DBaaSGrantV1DeleteStateRefreshFunc(ctx, dbaasClient, d.Id()),
-		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      10 * time.Second,
-		MinTimeout: 20 * time.Second,
+		return diagDeletingObject(objectGrant, d.Id(), err)
 	}
 
 	log.Printf("[DEBUG] waiting for grant %s to become deleted", d.Id())
-	_, err = stateConf.WaitForStateContext(ctx)
+	timeout := d.Timeout(schema.TimeoutDelete)
+	err = waiters.WaitForDBaaSGrantV1Deleted(ctx, dbaasClient, d.Id(), timeout)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error waiting for the grant %s to become deleted: %s", d.Id(), err))
+		return diag.FromErr(err)
 	}
 
 	return nil
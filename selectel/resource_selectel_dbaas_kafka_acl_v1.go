@@ -3,14 +3,10 @@ package selectel
 import (
 	"context"
 	"errors"
-	"fmt"
 	"log"
-	"net/http"
-	"strconv"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/selectel/dbaas-go"
 	waiters "github.com/terraform-providers/terraform-provider-selectel/selectel/waiters/dbaas"
@@ -52,14 +48,14 @@ func resourceDBaaSACLV1Create(ctx context.Context, d *schema.ResourceData, meta
 	log.Print(msgCreate(objectACL, aclCreateOpts))
 	acl, err := dbaasClient.CreateACL(ctx, aclCreateOpts)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectACL, err))
+		return diagCreatingObject(objectACL, err)
 	}
 
 	log.Printf("[DEBUG] waiting for acl %s to become 'ACTIVE'", acl.ID)
 	timeout := d.Timeout(schema.TimeoutCreate)
 	err = waiters.WaitForDBaaSACLV1ActiveState(ctx, dbaasClient, acl.ID, timeout)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectACL, err))
+		return diagCreatingObject(objectACL, err)
 	}
 
 	d.SetId(acl.ID)
@@ -76,7 +72,7 @@ func resourceDBaaSACLV1Read(ctx context.Context, d *schema.ResourceData, meta in
 	log.Print(msgGet(objectACL, d.Id()))
 	acl, err := dbaasClient.ACL(ctx, d.Id())
 	if err != nil {
-		return diag.FromErr(errGettingObject(objectACL, d.Id(), err))
+		return diagGettingObject(objectACL, d.Id(), err)
 	}
 	d.Set("datastore_id", acl.DatastoreID)
 	if acl.Pattern != "" {
@@ -105,14 +101,14 @@ func resourceDBaaSACLV1Update(ctx context.Context, d *schema.ResourceData, meta
 		log.Print(msgUpdate(objectACL, d.Id(), updateOpts))
 		_, err := dbaasClient.UpdateACL(ctx, d.Id(), updateOpts)
 		if err != nil {
-			return diag.FromErr(errUpdatingObject(objectACL, d.Id(), err))
+			return diagUpdatingObject(objectACL, d.Id(), err)
 		}
 
 		log.Printf("[DEBUG] waiting for acl %s to become 'ACTIVE'", d.Id())
 		timeout := d.Timeout(schema.TimeoutCreate)
 		err = waiters.WaitForDBaaSACLV1ActiveState(ctx, dbaasClient, d.Id(), timeout)
 		if err != nil {
-			return diag.FromErr(errUpdatingObject(objectACL, d.Id(), err))
+			return diagUpdatingObject(objectACL, d.Id(), err)
 		}
 	}
 
@@ -128,22 +124,14 @@ func resourceDBaaSACLV1Delete(ctx context.Context, d *schema.ResourceData, meta
 	log.Print(msgDelete(objectACL, d.Id()))
 	err := dbaasClient.DeleteACL(ctx, d.Id())
 	if err != nil {
-		return diag.FromErr(errDeletingObject(objectACL, d.Id(), err))
-	}
-
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{strconv.Itoa(http.StatusOK)},
-		Target:     []string{strconv.Itoa(http.StatusNotFound)},
-		Refresh:    waiters.DBaaSACLV1DeleteStateRefreshFunc(ctx, dbaasClient, d.Id()),
-		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      10 * time.Second,
-		MinTimeout: 20 * time.Second,
+		return diagDeletingObject(objectACL, d.Id(), err)
 	}
 
 	log.Printf("[DEBUG] waiting for acl %s to become deleted", d.Id())
-	_, err = stateConf.WaitForStateContext(ctx)
+	timeout := d.Timeout(schema.TimeoutDelete)
+	err = waiters.WaitForDBaaSACLV1Deleted(ctx, dbaasClient, d.Id(), timeout)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error waiting for the acl %s to become deleted: %s", d.Id(), err))
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -3,14 +3,10 @@ package selectel
 import (
 	"context"
 	"errors"
-	"fmt"
 	"log"
-	"net/http"
-	"strconv"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/selectel/dbaas-go"
 	waiters "github.com/terraform-providers/terraform-provider-selectel/selectel/waiters/dbaas"
@@ -49,14 +45,14 @@ func resourceDBaaSTopicV1Create(ctx context.Context, d *schema.ResourceData, met
 	log.Print(msgCreate(objectTopic, topicCreateOpts))
 	topic, err := dbaasClient.CreateTopic(ctx, topicCreateOpts)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectTopic, err))
+		return diagCreatingObject(objectTopic, err)
 	}
 
 	log.Printf("[DEBUG] waiting for topic %s to become 'ACTIVE'", topic.ID)
 	timeout := d.Timeout(schema.TimeoutCreate)
 	err = waiters.WaitForDBaaSTopicV1ActiveState(ctx, dbaasClient, topic.ID, timeout)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectTopic, err))
+		return diagCreatingObject(objectTopic, err)
 	}
 
 	d.SetId(topic.ID)
@@ -73,7 +69,7 @@ func resourceDBaaSTopicV1Read(ctx context.Context, d *schema.ResourceData, meta
 	log.Print(msgGet(objectTopic, d.Id()))
 	topic, err := dbaasClient.Topic(ctx, d.Id())
 	if err != nil {
-		return diag.FromErr(errGettingObject(objectTopic, d.Id(), err))
+		return diagGettingObject(objectTopic, d.Id(), err)
 	}
 	d.Set("datastore_id", topic.DatastoreID)
 	d.Set("name", topic.Name)
@@ -98,14 +94,14 @@ func resourceDBaaSTopicV1Update(ctx context.Context, d *schema.ResourceData, met
 		log.Print(msgUpdate(objectTopic, d.Id(), updateOpts))
 		_, err := dbaasClient.UpdateTopic(ctx, d.Id(), updateOpts)
 		if err != nil {
-			return diag.FromErr(errUpdatingObject(objectTopic, d.Id(), err))
+			return diagUpdatingObject(objectTopic, d.Id(), err)
 		}
 
 		log.Printf("[DEBUG] waiting for topic %s to become 'ACTIVE'", d.Id())
 		timeout := d.Timeout(schema.TimeoutCreate)
 		err = waiters.WaitForDBaaSTopicV1ActiveState(ctx, dbaasClient, d.Id(), timeout)
 		if err != nil {
-			return diag.FromErr(errUpdatingObject(objectTopic, d.Id(), err))
+			return diagUpdatingObject(objectTopic, d.Id(), err)
 		}
 	}
 
@@ -121,22 +117,14 @@ func resourceDBaaSTopicV1Delete(ctx context.Context, d *schema.ResourceData, met
 	log.Print(msgDelete(objectTopic, d.Id()))
 	err := dbaasClient.DeleteTopic(ctx, d.Id())
 	if err != nil {
-		return diag.FromErr(errDeletingObject(objectTopic, d.Id(), err))
-	}
-
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{strconv.Itoa(http.StatusOK)},
-		Target:     []string{strconv.Itoa(http.StatusNotFound)},
-		Refresh:    waiters.DBaaSTopicV1DeleteStateRefreshFunc(ctx, dbaasClient, d.Id()),
-		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      10 * time.Second,
-		MinTimeout: 20 * time.Second,
+		return diagDeletingObject(objectTopic, d.Id(), err)
 	}
 
 	log.Printf("[DEBUG] waiting for topic %s to become deleted", d.Id())
-	_, err = stateConf.WaitForStateContext(ctx)
+	timeout := d.Timeout(schema.TimeoutDelete)
+	err = waiters.WaitForDBaaSTopicV1Deleted(ctx, dbaasClient, d.Id(), timeout)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error waiting for the topic %s to become deleted: %s", d.Id(), err))
+		return diag.FromErr(err)
 	}
 
 	return nil
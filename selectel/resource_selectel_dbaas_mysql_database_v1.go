@@ -3,14 +3,10 @@ package selectel
 import (
 	"context"
 	"errors"
-	"fmt"
 	"log"
-	"net/http"
-	"strconv"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/selectel/dbaas-go"
 	waiters "github.com/terraform-providers/terraform-provider-selectel/selectel/waiters/dbaas"
@@ -47,14 +43,14 @@ func resourceDBaaSMySQLDatabaseV1Create(ctx context.Context, d *schema.ResourceD
 	log.Print(msgCreate(objectDatabase, databaseCreateOpts))
 	database, err := dbaasClient.CreateDatabase(ctx, databaseCreateOpts)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectDatabase, err))
+		return diagCreatingObject(objectDatabase, err)
 	}
 
 	log.Printf("[DEBUG] waiting for database %s to become 'ACTIVE'", database.ID)
 	timeout := d.Timeout(schema.TimeoutCreate)
 	err = waiters.WaitForDBaaSDatabaseV1ActiveState(ctx, dbaasClient, database.ID, timeout)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectDatabase, err))
+		return diagCreatingObject(objectDatabase, err)
 	}
 
 	d.SetId(database.ID)
@@ -71,7 +67,7 @@ func resourceDBaaSMySQLDatabaseV1Read(ctx context.Context, d *schema.ResourceDat
 	log.Print(msgGet(objectDatabase, d.Id()))
 	database, err := dbaasClient.Database(ctx, d.Id())
 	if err != nil {
-		return diag.FromErr(errGettingObject(objectDatabase, d.Id(), err))
+		return diagGettingObject(objectDatabase, d.Id(), err)
 	}
 	d.Set("datastore_id", database.DatastoreID)
 	d.Set("name", database.Name)
@@ -89,22 +85,14 @@ func resourceDBaaSMySQLDatabaseV1Delete(ctx context.Context, d *schema.ResourceD
 	log.Print(msgDelete(objectDatabase, d.Id()))
 	err := dbaasClient.DeleteDatabase(ctx, d.Id())
 	if err != nil {
-		return diag.FromErr(errDeletingObject(objectDatabase, d.Id(), err))
-	}
-
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{strconv.Itoa(http.StatusOK)},
-		Target:     []string{strconv.Itoa(http.StatusNotFound)},
-		Refresh:    waiters.DBaaSDatabaseV1DeleteStateRefreshFunc(ctx, dbaasClient, d.Id()),
-		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      10 * time.Second,
-		MinTimeout: 15 * time.Second,
+		return diagDeletingObject(objectDatabase, d.Id(), err)
 	}
 
 	log.Printf("[DEBUG] waiting for database %s to become deleted", d.Id())
-	_, err = stateConf.WaitForStateContext(ctx)
+	timeout := d.Timeout(schema.TimeoutDelete)
+	err = waiters.WaitForDBaaSDatabaseV1Deleted(ctx, dbaasClient, d.Id(), timeout)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error waiting for the database %s to become deleted: %s", d.Id(), err))
+		return diag.FromErr(err)
 	}
 
 	return nil
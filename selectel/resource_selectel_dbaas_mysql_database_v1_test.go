@@ -23,9 +23,9 @@ func TestAccDBaaSMySQLDatabaseV1Basic(t *testing.T) {
 	datastoreTypeEngine := mySQLDatastoreType
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDBaaSMySQLDatabaseV1Basic(projectName, datastoreName, datastoreTypeEngine, databaseName, nodeCount),
@@ -53,9 +53,9 @@ func TestAccDBaaSMySQLNativeDatabaseV1Basic(t *testing.T) {
 	datastoreTypeEngine := mySQLNativeDatastoreType
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDBaaSMySQLDatabaseV1Basic(projectName, datastoreName, datastoreTypeEngine, databaseName, nodeCount),
@@ -9,25 +9,28 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/selectel/dbaas-go"
 	"github.com/selectel/go-selvpcclient/v4/selvpcclient/resell/v2/projects"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/fakeapi"
 )
 
 func TestAccDBaaSPostgreSQLDatastoreV1Basic(t *testing.T) {
+	recording := testAccStartRecording(t)
+
 	var (
 		dbaasDatastore dbaas.Datastore
 		project        projects.Project
 	)
 
-	projectName := acctest.RandomWithPrefix("tf-acc")
-	datastoreName := acctest.RandomWithPrefix("tf-acc-ds")
+	projectName := recording.RandomWithPrefix("tf-acc")
+	datastoreName := recording.RandomWithPrefix("tf-acc-ds")
 	nodeCount := 1
 	resizeNodeCount := 2
 
-	updatedDatastoreName := acctest.RandomWithPrefix("tf-acc-ds-updated")
+	updatedDatastoreName := recording.RandomWithPrefix("tf-acc-ds-updated")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDBaaSPostgreSQLDatastoreV1Basic(projectName, datastoreName, nodeCount),
@@ -166,6 +169,81 @@ func TestAccDBaaSPostgreSQLDatastoreV1Basic(t *testing.T) {
 	})
 }
 
+func TestUnitDBaaSPostgreSQLDatastoreV1Basic(t *testing.T) {
+	var (
+		dbaasDatastore dbaas.Datastore
+		dbaasUser      dbaas.User
+	)
+
+	api := newTestFakeAPI(t)
+	datastoreName := acctest.RandomWithPrefix("tf-unit-ds")
+	userName := RandomWithPrefix("tf_unit_user")
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testUnitCheckFakeAPIEmpty(api, fakeapi.KindDBaaSDatastores),
+			testUnitCheckFakeAPIEmpty(api, fakeapi.KindDBaaSUsers),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: api.ProviderConfig() + testUnitDBaaSPostgreSQLDatastoreV1Basic(datastoreName, userName, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSDatastoreV1Exists("selectel_dbaas_postgresql_datastore_v1.datastore_tf_acc_test_1", &dbaasDatastore),
+					resource.TestCheckResourceAttr("selectel_dbaas_postgresql_datastore_v1.datastore_tf_acc_test_1", "name", datastoreName),
+					resource.TestCheckResourceAttr("selectel_dbaas_postgresql_datastore_v1.datastore_tf_acc_test_1", "project_id", fakeapi.ProjectID),
+					resource.TestCheckResourceAttr("selectel_dbaas_postgresql_datastore_v1.datastore_tf_acc_test_1", "region", fakeapi.Region),
+					resource.TestCheckResourceAttr("selectel_dbaas_postgresql_datastore_v1.datastore_tf_acc_test_1", "type_id", fakeapi.DBaaSPostgreSQLTypeID),
+					resource.TestCheckResourceAttr("selectel_dbaas_postgresql_datastore_v1.datastore_tf_acc_test_1", "node_count", "1"),
+					resource.TestCheckResourceAttr("selectel_dbaas_postgresql_datastore_v1.datastore_tf_acc_test_1", "status", string(dbaas.StatusActive)),
+					resource.TestCheckResourceAttr("selectel_dbaas_postgresql_datastore_v1.datastore_tf_acc_test_1", "flavor.0.vcpus", strconv.Itoa(2)),
+					resource.TestCheckResourceAttr("selectel_dbaas_postgresql_datastore_v1.datastore_tf_acc_test_1", "config.work_mem", strconv.Itoa(128)),
+					resource.TestCheckResourceAttrSet("selectel_dbaas_postgresql_datastore_v1.datastore_tf_acc_test_1", "connections.master"),
+					testAccCheckDBaaSUserV1Exists("selectel_dbaas_user_v1.user_tf_acc_test_1", &dbaasUser),
+					resource.TestCheckResourceAttr("selectel_dbaas_user_v1.user_tf_acc_test_1", "name", userName),
+					resource.TestCheckResourceAttr("selectel_dbaas_user_v1.user_tf_acc_test_1", "status", string(dbaas.StatusActive)),
+				),
+			},
+			{
+				Config: api.ProviderConfig() + testUnitDBaaSPostgreSQLDatastoreV1Basic(datastoreName, userName, 2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDBaaSDatastoreV1Exists("selectel_dbaas_postgresql_datastore_v1.datastore_tf_acc_test_1", &dbaasDatastore),
+					resource.TestCheckResourceAttr("selectel_dbaas_postgresql_datastore_v1.datastore_tf_acc_test_1", "node_count", "2"),
+					resource.TestCheckResourceAttr("selectel_dbaas_postgresql_datastore_v1.datastore_tf_acc_test_1", "status", string(dbaas.StatusActive)),
+				),
+			},
+		},
+	})
+}
+
+func testUnitDBaaSPostgreSQLDatastoreV1Basic(datastoreName, userName string, nodeCount int) string {
+	return fmt.Sprintf(`
+data "selectel_dbaas_datastore_type_v1" "dt" {
+  filter {
+    engine = "postgresql"
+    version = "16"
+  }
+}
+
+resource "selectel_dbaas_postgresql_datastore_v1" "datastore_tf_acc_test_1" {
+  name = "%s"
+  type_id = "${data.selectel_dbaas_datastore_type_v1.dt.datastore_types[0].id}"
+  subnet_id = "00000000-0000-4000-8000-000000000001"
+  node_count = "%d"
+  flavor_id = "%s"
+  config = {
+    work_mem = 128
+  }
+}
+
+resource "selectel_dbaas_user_v1" "user_tf_acc_test_1" {
+  datastore_id = "${selectel_dbaas_postgresql_datastore_v1.datastore_tf_acc_test_1.id}"
+  name = "%s"
+  password = "secret"
+}`, datastoreName, nodeCount, fakeapi.DBaaSFlavorID, userName)
+}
+
 func testAccDBaaSPostgreSQLDatastoreV1Basic(projectName, datastoreName string, nodeCount int) string {
 	return fmt.Sprintf(`
 resource "selectel_vpc_project_v2" "project_tf_acc_test_1" {
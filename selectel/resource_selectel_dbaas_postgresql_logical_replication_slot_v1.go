@@ -3,14 +3,10 @@ package selectel
 import (
 	"context"
 	"errors"
-	"fmt"
 	"log"
-	"net/http"
-	"strconv"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/selectel/dbaas-go"
 	waiters "github.com/terraform-providers/terraform-provider-selectel/selectel/waiters/dbaas"
@@ -48,14 +44,14 @@ func resourceDBaaSPostgreSQLLogicalReplicationSlotV1Create(ctx context.Context,
 	log.Print(msgCreate(objectLogicalReplicationSlot, slotCreateOpts))
 	slot, err := dbaasClient.CreateLogicalReplicationSlot(ctx, slotCreateOpts)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectLogicalReplicationSlot, err))
+		return diagCreatingObject(objectLogicalReplicationSlot, err)
 	}
 
 	log.Printf("[DEBUG] waiting for slot %s to become 'ACTIVE'", slot.ID)
 	timeout := d.Timeout(schema.TimeoutCreate)
 	err = waiters.WaitForDBaaSLogicalReplicationSlotV1ActiveState(ctx, dbaasClient, slot.ID, timeout)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectLogicalReplicationSlot, err))
+		return diagCreatingObject(objectLogicalReplicationSlot, err)
 	}
 
 	d.SetId(slot.ID)
@@ -72,7 +68,7 @@ func resourceDBaaSPostgreSQLLogicalReplicationSlotV1Read(ctx context.Context, d
 	log.Print(msgGet(objectLogicalReplicationSlot, d.Id()))
 	slot, err := dbaasClient.LogicalReplicationSlot(ctx, d.Id())
 	if err != nil {
-		return diag.FromErr(errGettingObject(objectLogicalReplicationSlot, d.Id(), err))
+		return diagGettingObject(objectLogicalReplicationSlot, d.Id(), err)
 	}
 
 	d.Set("name", slot.Name)
@@ -91,22 +87,14 @@ func resourceDBaaSPostgreSQLLogicalReplicationSlotV1Delete(ctx context.Context,
 	log.Print(msgDelete(objectLogicalReplicationSlot, d.Id()))
 	err := dbaasClient.DeleteLogicalReplicationSlot(ctx, d.Id())
 	if err != nil {
-		return diag.FromErr(errDeletingObject(objectLogicalReplicationSlot, d.Id(), err))
-	}
-
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{strconv.Itoa(http.StatusOK)},
-		Target:     []string{strconv.Itoa(http.StatusNotFound)},
-		Refresh:    waiters.DBaaSLogicalReplicationSlotV1DeleteStateRefreshFunc(ctx, dbaasClient, d.Id()),
-		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      10 * time.Second,
-		MinTimeout: 20 * time.Second,
+		return diagDeletingObject(objectLogicalReplicationSlot, d.Id(), err)
 	}
 
 	log.Printf("[DEBUG] waiting for slot %s to become deleted", d.Id())
-	_, err = stateConf.WaitForStateContext(ctx)
+	timeout := d.Timeout(schema.TimeoutDelete)
+	err = waiters.WaitForDBaaSLogicalReplicationSlotV1Deleted(ctx, dbaasClient, d.Id(), timeout)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error waiting for the slot %s to become deleted: %s", d.Id(), err))
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -3,16 +3,13 @@ package selectel
 import (
 	"context"
 	"errors"
-	"fmt"
 	"log"
-	"net/http"
-	"strconv"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/selectel/dbaas-go"
+	waiters "github.com/terraform-providers/terraform-provider-selectel/selectel/waiters/dbaas"
 )
 
 func resourceDBaaSPrometheusMetricTokenV1() *schema.Resource {
@@ -46,7 +43,7 @@ func resourceDBaaSPrometheusMetricTokenV1Create(ctx context.Context, d *schema.R
 	log.Print(msgCreate(objectPrometheusMetricToken, prometheusMetricsTokenCreateOpts))
 	token, err := dbaasClient.CreatePrometheusMetricToken(ctx, prometheusMetricsTokenCreateOpts)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectPrometheusMetricToken, err))
+		return diagCreatingObject(objectPrometheusMetricToken, err)
 	}
 
 	d.SetId(token.ID)
@@ -63,7 +60,7 @@ func resourceDBaaSPrometheusMetricTokenV1Read(ctx context.Context, d *schema.Res
 	log.Print(msgGet(objectPrometheusMetricToken, d.Id()))
 	token, err := dbaasClient.PrometheusMetricToken(ctx, d.Id())
 	if err != nil {
-		return diag.FromErr(errGettingObject(objectPrometheusMetricToken, d.Id(), err))
+		return diagGettingObject(objectPrometheusMetricToken, d.Id(), err)
 	}
 	d.Set("name", token.Name)
 	d.Set("value", token.Value)
@@ -85,7 +82,7 @@ func resourceDBaaSPrometheusMetricTokenV1Update(ctx context.Context, d *schema.R
 		log.Print(msgUpdate(objectPrometheusMetricToken, d.Id(), updateOpts))
 		_, err := dbaasClient.UpdatePrometheusMetricToken(ctx, d.Id(), updateOpts)
 		if err != nil {
-			return diag.FromErr(errUpdatingObject(objectPrometheusMetricToken, d.Id(), err))
+			return diagUpdatingObject(objectPrometheusMetricToken, d.Id(), err)
 		}
 	}
 
@@ -101,22 +98,14 @@ func resourceDBaaSPrometheusMetricTokenV1Delete(ctx context.Context, d *schema.R
 	log.Print(msgDelete(objectPrometheusMetricToken, d.Id()))
 	err := dbaasClient.DeletePrometheusMetricToken(ctx, d.Id())
 	if err != nil {
-		return diag.FromErr(errDeletingObject(objectPrometheusMetricToken, d.Id(), err))
-	}
-
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{strconv.Itoa(http.StatusOK)},
-		Target:     []string{strconv.Itoa(http.StatusNotFound)},
-		Refresh:    dbaasPrometheusMetricTokenV1DeleteStateRefreshFunc(ctx, dbaasClient, d.Id()),
-		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      10 * time.Second,
-		MinTimeout: 10 * time.Second,
+		return diagDeletingObject(objectPrometheusMetricToken, d.Id(), err)
 	}
 
 	log.Printf("[DEBUG] waiting for token %s to become deleted", d.Id())
-	_, err = stateConf.WaitForStateContext(ctx)
+	timeout := d.Timeout(schema.TimeoutDelete)
+	err = waiters.WaitForDBaaSPrometheusMetricTokenV1Deleted(ctx, dbaasClient, d.Id(), timeout)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error waiting for the token %s to become deleted: %s", d.Id(), err))
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -136,19 +125,3 @@ func resourceDBaaSPrometheusMetricTokenV1ImportState(_ context.Context, d *schem
 
 	return []*schema.ResourceData{d}, nil
 }
-
-func dbaasPrometheusMetricTokenV1DeleteStateRefreshFunc(ctx context.Context, client *dbaas.API, prometheusMetricsTokenID string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		d, err := client.PrometheusMetricToken(ctx, prometheusMetricsTokenID)
-		if err != nil {
-			var dbaasError *dbaas.DBaaSAPIError
-			if errors.As(err, &dbaasError) {
-				return d, strconv.Itoa(dbaasError.StatusCode()), nil
-			}
-
-			return nil, "", err
-		}
-
-		return d, strconv.Itoa(200), err
-	}
-}
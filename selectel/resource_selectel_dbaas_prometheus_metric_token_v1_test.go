@@ -24,9 +24,9 @@ func TestAccDBaaSPrometheusMetricTokenV1Basic(t *testing.T) {
 	updatedTokenName := acctest.RandomWithPrefix("tf-acc-token-updated")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDBaaSPrometheusMetricTokenV1Basic(projectName, tokenName),
@@ -3,22 +3,18 @@ package selectel
 import (
 	"context"
 	"errors"
-	"fmt"
 	"log"
-	"net/http"
-	"strconv"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/selectel/dbaas-go"
 	waiters "github.com/terraform-providers/terraform-provider-selectel/selectel/waiters/dbaas"
 )
 
 func resourceDBaaSRedisDatastoreV1() *schema.Resource {
-	return &schema.Resource{
+	return withDeletionProtection(objectDatastore, &schema.Resource{
 		CreateContext: resourceDBaaSRedisDatastoreV1Create,
 		ReadContext:   resourceDBaaSRedisDatastoreV1Read,
 		UpdateContext: resourceDBaaSRedisDatastoreV1Update,
@@ -35,7 +31,7 @@ func resourceDBaaSRedisDatastoreV1() *schema.Resource {
 			Delete: schema.DefaultTimeout(60 * time.Minute),
 		},
 		Schema: resourceDBaaSRedisDatastoreV1Schema(),
-	}
+	})
 }
 
 func resourceDBaaSRedisDatastoreV1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -78,10 +74,7 @@ func resourceDBaaSRedisDatastoreV1Create(ctx context.Context, d *schema.Resource
 		datastoreCreateOpts.FlavorID = flavorID.(string)
 	}
 
-	redisPassword, redisPasswordOk := d.GetOk("redis_password")
-	if redisPasswordOk {
-		datastoreCreateOpts.RedisPassword = redisPassword.(string)
-	}
+	datastoreCreateOpts.RedisPassword = secretValue(d, "redis_password", "redis_password_wo")
 
 	backupRetentionDays, ok := d.GetOk("backup_retention_days")
 	if ok {
@@ -91,14 +84,14 @@ func resourceDBaaSRedisDatastoreV1Create(ctx context.Context, d *schema.Resource
 	log.Print(msgCreate(objectDatastore, datastoreCreateOpts))
 	datastore, err := dbaasClient.CreateDatastore(ctx, datastoreCreateOpts)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectDatastore, err))
+		return diagCreatingObject(objectDatastore, err)
 	}
 
 	log.Printf("[DEBUG] waiting for datastore %s to become 'ACTIVE'", datastore.ID)
 	timeout := d.Timeout(schema.TimeoutCreate)
 	err = waiters.WaitForDBaaSDatastoreV1ActiveState(ctx, dbaasClient, datastore.ID, timeout)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectDatastore, err))
+		return diagCreatingObject(objectDatastore, err)
 	}
 
 	d.SetId(datastore.ID)
@@ -115,7 +108,7 @@ func resourceDBaaSRedisDatastoreV1Read(ctx context.Context, d *schema.ResourceDa
 	log.Print(msgGet(objectDatastore, d.Id()))
 	datastore, err := dbaasClient.Datastore(ctx, d.Id())
 	if err != nil {
-		return diag.FromErr(errGettingObject(objectDatastore, d.Id(), err))
+		return diagGettingObject(objectDatastore, d.Id(), err)
 	}
 	d.Set("name", datastore.Name)
 	d.Set("status", datastore.Status)
@@ -182,7 +175,7 @@ func resourceDBaaSRedisDatastoreV1Update(ctx context.Context, d *schema.Resource
 			return diag.FromErr(err)
 		}
 	}
-	if d.HasChange("redis_password") {
+	if d.HasChanges("redis_password", "redis_password_wo_version") {
 		err := updateRedisDatastorePassword(ctx, d, dbaasClient)
 		if err != nil {
 			return diag.FromErr(err)
@@ -213,22 +206,14 @@ func resourceDBaaSRedisDatastoreV1Delete(ctx context.Context, d *schema.Resource
 	log.Print(msgDelete(objectDatastore, d.Id()))
 	err := dbaasClient.DeleteDatastore(ctx, d.Id())
 	if err != nil {
-		return diag.FromErr(errDeletingObject(objectDatastore, d.Id(), err))
-	}
-
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{strconv.Itoa(http.StatusOK)},
-		Target:     []string{strconv.Itoa(http.StatusNotFound)},
-		Refresh:    waiters.DBaaSDatastoreV1DeleteStateRefreshFunc(ctx, dbaasClient, d.Id()),
-		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      10 * time.Second,
-		MinTimeout: 15 * time.Second,
+		return diagDeletingObject(objectDatastore, d.Id(), err)
 	}
 
 	log.Printf("[DEBUG] waiting for datastore %s to become deleted", d.Id())
-	_, err = stateConf.WaitForStateContext(ctx)
+	timeout := d.Timeout(schema.TimeoutDelete)
+	err = waiters.WaitForDBaaSDatastoreV1Deleted(ctx, dbaasClient, d.Id(), timeout)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error waiting for the datastore %s to become deleted: %s", d.Id(), err))
+		return diag.FromErr(err)
 	}
 
 	return nil
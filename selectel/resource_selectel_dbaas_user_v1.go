@@ -3,14 +3,10 @@ package selectel
 import (
 	"context"
 	"errors"
-	"fmt"
 	"log"
-	"net/http"
-	"strconv"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/selectel/dbaas-go"
 	waiters "github.com/terraform-providers/terraform-provider-selectel/selectel/waiters/dbaas"
@@ -43,20 +39,20 @@ func resourceDBaaSUserV1Create(ctx context.Context, d *schema.ResourceData, meta
 	userCreateOpts := dbaas.UserCreateOpts{
 		DatastoreID: d.Get("datastore_id").(string),
 		Name:        d.Get("name").(string),
-		Password:    d.Get("password").(string),
+		Password:    secretValue(d, "password", "password_wo"),
 	}
 
 	log.Print(msgCreate(objectUser, userCreateOpts))
 	user, err := dbaasClient.CreateUser(ctx, userCreateOpts)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectUser, err))
+		return diagCreatingObject(objectUser, err)
 	}
 
 	log.Printf("[DEBUG] waiting for user %s to become 'ACTIVE'", user.ID)
 	timeout := d.Timeout(schema.TimeoutCreate)
 	err = waiters.WaitForDBaaSUserV1ActiveState(ctx, dbaasClient, user.ID, timeout)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectUser, err))
+		return diagCreatingObject(objectUser, err)
 	}
 
 	d.SetId(user.ID)
@@ -73,7 +69,7 @@ func resourceDBaaSUserV1Read(ctx context.Context, d *schema.ResourceData, meta i
 	log.Print(msgGet(objectUser, d.Id()))
 	user, err := dbaasClient.User(ctx, d.Id())
 	if err != nil {
-		return diag.FromErr(errGettingObject(objectUser, d.Id(), err))
+		return diagGettingObject(objectUser, d.Id(), err)
 	}
 	d.Set("datastore_id", user.DatastoreID)
 	d.Set("name", user.Name)
@@ -88,22 +84,22 @@ func resourceDBaaSUserV1Update(ctx context.Context, d *schema.ResourceData, meta
 		return diagErr
 	}
 
-	if d.HasChange("password") {
+	if d.HasChanges("password", "password_wo_version") {
 		updateOpts := dbaas.UserUpdateOpts{
-			Password: d.Get("password").(string),
+			Password: secretValue(d, "password", "password_wo"),
 		}
 
 		log.Print(msgUpdate(objectUser, d.Id(), updateOpts))
 		_, err := dbaasClient.UpdateUser(ctx, d.Id(), updateOpts)
 		if err != nil {
-			return diag.FromErr(errUpdatingObject(objectUser, d.Id(), err))
+			return diagUpdatingObject(objectUser, d.Id(), err)
 		}
 
 		log.Printf("[DEBUG] waiting for user %s to become 'ACTIVE'", d.Id())
 		timeout := d.Timeout(schema.TimeoutCreate)
 		err = waiters.WaitForDBaaSUserV1ActiveState(ctx, dbaasClient, d.Id(), timeout)
 		if err != nil {
-			return diag.FromErr(errUpdatingObject(objectUser, d.Id(), err))
+			return diagUpdatingObject(objectUser, d.Id(), err)
 		}
 	}
 
@@ -119,22 +115,14 @@ func resourceDBaaSUserV1Delete(ctx context.Context, d *schema.ResourceData, meta
 	log.Print(msgDelete(objectUser, d.Id()))
 	err := dbaasClient.DeleteUser(ctx, d.Id())
 	if err != nil {
-		return diag.FromErr(errDeletingObject(objectUser, d.Id(), err))
-	}
-
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{strconv.Itoa(http.StatusOK)},
-		Target:     []string{strconv.Itoa(http.StatusNotFound)},
-		Refresh:    waiters.DBaaSUserV1DeleteStateRefreshFunc(ctx, dbaasClient, d.Id()),
-		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      10 * time.Second,
-		MinTimeout: 15 * time.Second,
+		return diagDeletingObject(objectUser, d.Id(), err)
 	}
 
 	log.Printf("[DEBUG] waiting for user %s to become deleted", d.Id())
-	_, err = stateConf.WaitForStateContext(ctx)
+	timeout := d.Timeout(schema.TimeoutDelete)
+	err = waiters.WaitForDBaaSUserV1Deleted(ctx, dbaasClient, d.Id(), timeout)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error waiting for the user %s to become deleted: %s", d.Id(), err))
+		return diag.FromErr(err)
 	}
 
 	return nil
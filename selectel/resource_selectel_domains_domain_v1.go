@@ -46,7 +46,7 @@ func resourceDomainsDomainV1Create(ctx context.Context, d *schema.ResourceData,
 	log.Print(msgCreate(objectDomain, createOpts))
 	domainObj, _, err := domain.Create(ctx, client, createOpts)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectDomain, err))
+		return diagCreatingObject(objectDomain, err)
 	}
 
 	d.SetId(strconv.Itoa(domainObj.ID))
@@ -74,7 +74,7 @@ func resourceDomainsDomainV1Read(ctx context.Context, d *schema.ResourceData, me
 			return nil
 		}
 
-		return diag.FromErr(errGettingObject(objectDomain, d.Id(), err))
+		return diagGettingObject(objectDomain, d.Id(), err)
 	}
 
 	d.Set("name", domainObj.Name)
@@ -98,7 +98,7 @@ func resourceDomainsDomainV1Delete(ctx context.Context, d *schema.ResourceData,
 
 	_, err = domain.Delete(ctx, client, domainID)
 	if err != nil {
-		return diag.FromErr(errDeletingObject(objectDomain, d.Id(), err))
+		return diagDeletingObject(objectDomain, d.Id(), err)
 	}
 
 	return nil
@@ -19,9 +19,9 @@ func TestAccDomainsDomainV1Basic(t *testing.T) {
 	testDomainName := fmt.Sprintf("%s.xyz", acctest.RandomWithPrefix("tf-acc"))
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckDomainsV1DomainDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckDomainsV1DomainDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDomainsDomainV1Basic(testDomainName),
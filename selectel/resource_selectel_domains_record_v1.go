@@ -157,7 +157,7 @@ func resourceDomainsRecordV1Create(ctx context.Context, d *schema.ResourceData,
 	log.Print(msgCreate(objectRecord, createOpts))
 	recordObj, _, err := record.Create(ctx, client, domainID, createOpts)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectRecord, err))
+		return diagCreatingObject(objectRecord, err)
 	}
 
 	d.SetId(strconv.Itoa(recordObj.ID))
@@ -179,7 +179,7 @@ func resourceDomainsRecordV1Read(ctx context.Context, d *schema.ResourceData, me
 	domainID, recordID, err := domainsV1ParseDomainRecordIDsPair(d.Id())
 	if err != nil {
 		d.SetId("")
-		return diag.FromErr(errGettingObject(objectRecord, d.Id(), err))
+		return diagGettingObject(objectRecord, d.Id(), err)
 	}
 
 	log.Print(msgGet(objectRecord, d.Id()))
@@ -191,7 +191,7 @@ func resourceDomainsRecordV1Read(ctx context.Context, d *schema.ResourceData, me
 			return nil
 		}
 
-		return diag.FromErr(errGettingObject(objectRecord, d.Id(), err))
+		return diagGettingObject(objectRecord, d.Id(), err)
 	}
 
 	d.Set("name", recordObj.Name)
@@ -217,7 +217,7 @@ func resourceDomainsRecordV1Update(ctx context.Context, d *schema.ResourceData,
 	domainID, recordID, err := domainsV1ParseDomainRecordIDsPair(d.Id())
 	if err != nil {
 		d.SetId("")
-		return diag.FromErr(errGettingObject(objectRecord, d.Id(), err))
+		return diagGettingObject(objectRecord, d.Id(), err)
 	}
 	selMutexKV.Lock(strconv.Itoa(domainID))
 	defer selMutexKV.Unlock(strconv.Itoa(domainID))
@@ -247,7 +247,7 @@ func resourceDomainsRecordV1Update(ctx context.Context, d *schema.ResourceData,
 		}
 		_, _, err = record.Update(ctx, client, domainID, recordID, updateOpts)
 		if err != nil {
-			return diag.FromErr(errUpdatingObject(objectRecord, d.Id(), err))
+			return diagUpdatingObject(objectRecord, d.Id(), err)
 		}
 	}
 
@@ -258,7 +258,7 @@ func resourceDomainsRecordV1Delete(ctx context.Context, d *schema.ResourceData,
 	domainID, recordID, err := domainsV1ParseDomainRecordIDsPair(d.Id())
 	if err != nil {
 		d.SetId("")
-		return diag.FromErr(errGettingObject(objectRecord, d.Id(), err))
+		return diagGettingObject(objectRecord, d.Id(), err)
 	}
 	selMutexKV.Lock(strconv.Itoa(domainID))
 	defer selMutexKV.Unlock(strconv.Itoa(domainID))
@@ -272,7 +272,7 @@ func resourceDomainsRecordV1Delete(ctx context.Context, d *schema.ResourceData,
 
 	_, err = record.Delete(ctx, client, domainID, recordID)
 	if err != nil {
-		return diag.FromErr(errDeletingObject(objectRecord, d.Id(), err))
+		return diagDeletingObject(objectRecord, d.Id(), err)
 	}
 
 	return nil
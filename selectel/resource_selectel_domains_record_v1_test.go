@@ -42,9 +42,9 @@ func TestAccDomainsRecordV1Basic(t *testing.T) {
 	testRecordNameSSHFP := fmt.Sprintf("sshfp.%s", testDomainName)
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckDomainsRecordV1Destroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckDomainsRecordV1Destroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDomainsRecordV1Basic(
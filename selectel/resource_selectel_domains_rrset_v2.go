@@ -41,7 +41,8 @@ func resourceDomainsRRSetV2() *schema.Resource {
 			},
 			"project_id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"comment": {
@@ -105,12 +106,12 @@ func resourceDomainsRRSetV2Create(ctx context.Context, d *schema.ResourceData, m
 
 	rrset, err := client.CreateRRSet(ctx, zoneID, &createOpts)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectRRSet, err))
+		return diagCreatingObject(objectRRSet, err)
 	}
 
 	err = setRRSetToResourceData(d, rrset)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectRRSet, err))
+		return diagCreatingObject(objectRRSet, err)
 	}
 
 	return nil
@@ -130,12 +131,12 @@ func resourceDomainsRRSetV2Read(ctx context.Context, d *schema.ResourceData, met
 	rrset, err := client.GetRRSet(ctx, zoneID, d.Id())
 	if err != nil {
 		d.SetId("")
-		return diag.FromErr(errGettingObject(objectRRSet, zoneIDWithRRSetID, err))
+		return diagGettingObject(objectRRSet, zoneIDWithRRSetID, err)
 	}
 
 	err = setRRSetToResourceData(d, rrset)
 	if err != nil {
-		return diag.FromErr(errGettingObject(objectRRSet, zoneIDWithRRSetID, err))
+		return diagGettingObject(objectRRSet, zoneIDWithRRSetID, err)
 	}
 
 	return nil
@@ -188,7 +189,7 @@ func resourceDomainsRRSetV2Update(ctx context.Context, d *schema.ResourceData, m
 
 	client, err := getDomainsV2Client(d, meta)
 	if err != nil {
-		return diag.FromErr(errUpdatingObject(objectRRSet, d.Id(), err))
+		return diagUpdatingObject(objectRRSet, d.Id(), err)
 	}
 
 	if d.HasChanges("ttl", "comment", "records") {
@@ -208,7 +209,7 @@ func resourceDomainsRRSetV2Update(ctx context.Context, d *schema.ResourceData, m
 		}
 		err = client.UpdateRRSet(ctx, zoneID, d.Id(), &updateOpts)
 		if err != nil {
-			return diag.FromErr(errUpdatingObject(objectRRSet, d.Id(), err))
+			return diagUpdatingObject(objectRRSet, d.Id(), err)
 		}
 	}
 
@@ -220,14 +221,14 @@ func resourceDomainsRRSetV2Delete(ctx context.Context, d *schema.ResourceData, m
 
 	client, err := getDomainsV2Client(d, meta)
 	if err != nil {
-		return diag.FromErr(errDeletingObject(objectRRSet, d.Id(), err))
+		return diagDeletingObject(objectRRSet, d.Id(), err)
 	}
 
 	log.Print(msgDelete(objectRRSet, fmt.Sprintf("zone_id: %s, rrset_id: %s", zoneID, d.Id())))
 
 	err = client.DeleteRRSet(ctx, zoneID, d.Id())
 	if err != nil {
-		return diag.FromErr(errDeletingObject(objectRRSet, d.Id(), err))
+		return diagDeletingObject(objectRRSet, d.Id(), err)
 	}
 
 	return nil
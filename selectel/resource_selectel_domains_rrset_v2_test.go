@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	domainsV2 "github.com/selectel/domains-go/pkg/v2"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/fakeapi"
 )
 
 const resourceRRSetName = "rrset_tf_acc_test_1"
@@ -26,9 +27,9 @@ func TestAccDomainsRRSetV2Basic(t *testing.T) {
 	resourceRRSetName := "rrset_tf_acc_test_1"
 	dataSourceRRSetName := fmt.Sprintf("selectel_domains_rrset_v2.%[1]s", resourceRRSetName)
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckDomainsV2ZoneDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckDomainsV2ZoneDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDomainsRRSetV2WithZoneBasic(projectName, resourceRRSetName, testRRSetName, string(testRRSetType), testRRSetContent, testRRSetTTL, resourceZoneName, testZoneName),
@@ -43,6 +44,41 @@ func TestAccDomainsRRSetV2Basic(t *testing.T) {
 	})
 }
 
+func TestUnitDomainsRRSetV2Basic(t *testing.T) {
+	api := newTestFakeAPI(t)
+	testZoneName := fmt.Sprintf("%s.ru.", acctest.RandomWithPrefix("tf-unit"))
+	testRRSetName := fmt.Sprintf("%[1]s.%[2]s", acctest.RandomWithPrefix("tf-unit"), testZoneName)
+	rrsetResource := fmt.Sprintf("selectel_domains_rrset_v2.%[1]s", resourceRRSetName)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckDomainsV2RRSetDestroy,
+			testUnitCheckFakeAPIEmpty(api, fakeapi.KindDomainsRRSets),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: api.ProviderConfig() + testUnitDomainsRRSetV2(testZoneName, testRRSetName, 60, "192.0.2.1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDomainsRRSetV2ID(rrsetResource),
+					resource.TestCheckResourceAttr(rrsetResource, "name", testRRSetName),
+					resource.TestCheckResourceAttr(rrsetResource, "type", string(domainsV2.A)),
+					resource.TestCheckResourceAttr(rrsetResource, "ttl", "60"),
+					resource.TestCheckResourceAttr(rrsetResource, "records.0.content", "192.0.2.1"),
+					resource.TestCheckResourceAttrSet(rrsetResource, "zone_id"),
+				),
+			},
+			{
+				Config: api.ProviderConfig() + testUnitDomainsRRSetV2(testZoneName, testRRSetName, 120, "192.0.2.2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(rrsetResource, "ttl", "120"),
+					resource.TestCheckResourceAttr(rrsetResource, "records.0.content", "192.0.2.2"),
+				),
+			},
+		},
+	})
+}
+
 func testAccDomainsRRSetV2WithZoneBasic(projectName, resourceRRSetName, rrsetName, rrsetType, rrsetContent string, ttl int, resourceZoneName, zoneName string) string {
 	return fmt.Sprintf(`
 	%[7]s
@@ -98,3 +134,21 @@ func testAccDomainsRRSetV2ID(name string) resource.TestCheckFunc {
 		return nil
 	}
 }
+
+func testUnitDomainsRRSetV2(zoneName, rrsetName string, ttl int, content string) string {
+	return fmt.Sprintf(`
+	resource "selectel_domains_zone_v2" %[1]q {
+		name = %[2]q
+	}
+
+	resource "selectel_domains_rrset_v2" %[3]q {
+		name = %[4]q
+		type = "A"
+		ttl = %[5]d
+		zone_id = selectel_domains_zone_v2.%[1]s.id
+		records {
+			content = %[6]q
+			disabled = false
+		}
+	}`, resourceZoneName, zoneName, resourceRRSetName, rrsetName, ttl, content)
+}
@@ -13,7 +13,7 @@ import (
 var ErrZoneNotFound = errors.New("zone not found")
 
 func resourceDomainsZoneV2() *schema.Resource {
-	return &schema.Resource{
+	return withDeletionProtection(objectZone, &schema.Resource{
 		CreateContext: resourceDomainsZoneV2Create,
 		ReadContext:   resourceDomainsZoneV2Read,
 		DeleteContext: resourceDomainsZoneV2Delete,
@@ -29,7 +29,8 @@ func resourceDomainsZoneV2() *schema.Resource {
 			},
 			"project_id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"comment": {
@@ -62,7 +63,7 @@ func resourceDomainsZoneV2() *schema.Resource {
 				Default:  false,
 			},
 		},
-	}
+	})
 }
 
 func resourceDomainsZoneV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -80,7 +81,7 @@ func resourceDomainsZoneV2Create(ctx context.Context, d *schema.ResourceData, me
 
 	zone, err := client.CreateZone(ctx, &createOpts)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectZone, err))
+		return diagCreatingObject(objectZone, err)
 	}
 	// Update comment after creating
 	// because set comment in creating request not supporting
@@ -88,7 +89,7 @@ func resourceDomainsZoneV2Create(ctx context.Context, d *schema.ResourceData, me
 		comment := v.(string)
 		err = client.UpdateZoneComment(ctx, zone.ID, comment)
 		if err != nil {
-			return diag.FromErr(errUpdatingObject(objectZone, zone.ID, err))
+			return diagUpdatingObject(objectZone, zone.ID, err)
 		}
 	}
 	// Update disabled after creating
@@ -97,13 +98,13 @@ func resourceDomainsZoneV2Create(ctx context.Context, d *schema.ResourceData, me
 		disabled := v.(bool)
 		err = client.UpdateZoneState(ctx, zone.ID, disabled)
 		if err != nil {
-			return diag.FromErr(errUpdatingObject(objectZone, zone.ID, err))
+			return diagUpdatingObject(objectZone, zone.ID, err)
 		}
 	}
 
 	err = setZoneToResourceData(d, zone)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectZone, err))
+		return diagCreatingObject(objectZone, err)
 	}
 
 	return nil
@@ -120,12 +121,12 @@ func resourceDomainsZoneV2Read(ctx context.Context, d *schema.ResourceData, meta
 	log.Println(msgGet(objectZone, zoneName))
 	zone, err := getZoneByName(ctx, client, zoneName)
 	if err != nil {
-		return diag.FromErr(errGettingObject(objectZone, zoneName, err))
+		return diagGettingObject(objectZone, zoneName, err)
 	}
 
 	err = setZoneToResourceData(d, zone)
 	if err != nil {
-		return diag.FromErr(errGettingObject(objectZone, zoneName, err))
+		return diagGettingObject(objectZone, zoneName, err)
 	}
 
 	return nil
@@ -165,7 +166,7 @@ func resourceDomainsZoneV2ImportState(ctx context.Context, d *schema.ResourceDat
 func resourceDomainsZoneV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client, err := getDomainsV2Client(d, meta)
 	if err != nil {
-		return diag.FromErr(errUpdatingObject(objectZone, d.Id(), err))
+		return diagUpdatingObject(objectZone, d.Id(), err)
 	}
 
 	if d.HasChange("comment") {
@@ -177,7 +178,7 @@ func resourceDomainsZoneV2Update(ctx context.Context, d *schema.ResourceData, me
 
 		err = client.UpdateZoneComment(ctx, d.Id(), comment)
 		if err != nil {
-			return diag.FromErr(errUpdatingObject(objectZone, d.Id(), err))
+			return diagUpdatingObject(objectZone, d.Id(), err)
 		}
 	}
 
@@ -190,7 +191,7 @@ func resourceDomainsZoneV2Update(ctx context.Context, d *schema.ResourceData, me
 
 		err = client.UpdateZoneState(ctx, d.Id(), disabled)
 		if err != nil {
-			return diag.FromErr(errUpdatingObject(objectZone, d.Id(), err))
+			return diagUpdatingObject(objectZone, d.Id(), err)
 		}
 	}
 
@@ -207,7 +208,7 @@ func resourceDomainsZoneV2Delete(ctx context.Context, d *schema.ResourceData, me
 
 	err = client.DeleteZone(ctx, d.Id())
 	if err != nil {
-		return diag.FromErr(errDeletingObject(objectZone, d.Id(), err))
+		return diagDeletingObject(objectZone, d.Id(), err)
 	}
 
 	return nil
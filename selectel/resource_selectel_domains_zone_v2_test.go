@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/fakeapi"
 )
 
 const resourceZoneName = "zone_tf_acc_test_1"
@@ -18,9 +19,9 @@ func TestAccDomainsZoneV2Basic(t *testing.T) {
 	testZoneName := fmt.Sprintf("%s.xyz.", acctest.RandomWithPrefix("tf-acc"))
 	resourceZoneName := "zone_tf_acc_test_1"
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckDomainsV2ZoneDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckDomainsV2ZoneDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDomainsZoneV2Basic(projectName, resourceZoneName, testZoneName),
@@ -33,6 +34,36 @@ func TestAccDomainsZoneV2Basic(t *testing.T) {
 	})
 }
 
+func TestUnitDomainsZoneV2Basic(t *testing.T) {
+	api := newTestFakeAPI(t)
+	testZoneName := fmt.Sprintf("%s.xyz.", acctest.RandomWithPrefix("tf-unit"))
+	zoneResource := fmt.Sprintf("selectel_domains_zone_v2.%[1]s", resourceZoneName)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckDomainsV2ZoneDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: api.ProviderConfig() + testUnitDomainsZoneV2(resourceZoneName, testZoneName, "", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDomainsZoneV2Exists(zoneResource),
+					resource.TestCheckResourceAttr(zoneResource, "name", testZoneName),
+					resource.TestCheckResourceAttr(zoneResource, "project_id", fakeapi.ProjectID),
+					resource.TestCheckResourceAttr(zoneResource, "disabled", "false"),
+				),
+			},
+			{
+				Config: api.ProviderConfig() + testUnitDomainsZoneV2(resourceZoneName, testZoneName, "updated", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDomainsZoneV2Exists(zoneResource),
+					resource.TestCheckResourceAttr(zoneResource, "comment", "updated"),
+					resource.TestCheckResourceAttr(zoneResource, "disabled", "true"),
+				),
+			},
+		},
+	})
+}
+
 func testAccDomainsZoneV2Basic(projectName, resourceName, zoneName string) string {
 	return fmt.Sprintf(`
 		resource "selectel_vpc_project_v2" "project_tf_acc_test_1" {
@@ -90,3 +121,12 @@ func testAccDomainsZoneV2Exists(name string) resource.TestCheckFunc {
 		return nil
 	}
 }
+
+func testUnitDomainsZoneV2(resourceName, zoneName, comment string, disabled bool) string {
+	return fmt.Sprintf(`
+		resource "selectel_domains_zone_v2" %[1]q {
+			name = %[2]q
+			comment = %[3]q
+			disabled = %[4]t
+		}`, resourceName, zoneName, comment, disabled)
+}
@@ -46,11 +46,11 @@ func resourceIAMGroupMembershipV1Create(ctx context.Context, d *schema.ResourceD
 
 	if len(userIDs) == 0 {
 		createErr := fmt.Errorf("error creating group membership: no user ids specified")
-		return diag.FromErr(errCreatingObject(objectGroupMembership, createErr))
+		return diagCreatingObject(objectGroupMembership, createErr)
 	}
 	err := iamClient.Groups.AddUsers(ctx, d.Get("group_id").(string), userIDs)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectGroupMembership, err))
+		return diagCreatingObject(objectGroupMembership, err)
 	}
 
 	d.SetId(d.Get("group_id").(string))
@@ -74,7 +74,7 @@ func resourceIAMGroupMembershipV1Read(ctx context.Context, d *schema.ResourceDat
 
 	response, err := iamClient.Groups.Get(ctx, groupID)
 	if err != nil {
-		return diag.FromErr(errGettingObject(objectGroupMembership, d.Id(), err))
+		return diagGettingObject(objectGroupMembership, d.Id(), err)
 	}
 
 	responseUserIDs := make([]string, 0)
@@ -150,7 +150,7 @@ func resourceIAMGroupMembershipV1Delete(ctx context.Context, d *schema.ResourceD
 
 	err := iamClient.Groups.DeleteUsers(ctx, groupID, userIDs)
 	if err != nil {
-		return diag.FromErr(errDeletingObject(objectGroupMembership, d.Id(), err))
+		return diagDeletingObject(objectGroupMembership, d.Id(), err)
 	}
 
 	d.SetId("")
@@ -79,14 +79,14 @@ func resourceIAMGroupV1Create(ctx context.Context, d *schema.ResourceData, meta
 
 	group, err := iamClient.Groups.Create(ctx, opts)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectGroup, err))
+		return diagCreatingObject(objectGroup, err)
 	}
 	d.SetId(group.ID)
 
 	if len(roles) != 0 {
 		err = iamClient.Groups.AssignRoles(ctx, group.ID, roles)
 		if err != nil {
-			return diag.FromErr(errCreatingObject(objectGroup, err))
+			return diagCreatingObject(objectGroup, err)
 		}
 	}
 
@@ -102,7 +102,7 @@ func resourceIAMGroupV1Read(ctx context.Context, d *schema.ResourceData, meta in
 	log.Print(msgGet(objectGroup, d.Id()))
 	group, err := iamClient.Groups.Get(ctx, d.Id())
 	if err != nil {
-		return diag.FromErr(errGettingObject(objectGroup, d.Id(), err))
+		return diagGettingObject(objectGroup, d.Id(), err)
 	}
 
 	d.Set("role", convertIAMRolesToSet(group.Roles))
@@ -126,13 +126,13 @@ func resourceIAMGroupV1Update(ctx context.Context, d *schema.ResourceData, meta
 	log.Print(msgUpdate(objectGroup, d.Id(), fmt.Sprintf("Name: %+v, description: %+v", opts.Name, opts.Description)))
 	_, err := iamClient.Groups.Update(ctx, d.Id(), opts)
 	if err != nil {
-		return diag.FromErr(errUpdatingObject(objectGroup, d.Id(), err))
+		return diagUpdatingObject(objectGroup, d.Id(), err)
 	}
 
 	if d.HasChange("role") {
 		currentGroup, err := iamClient.Groups.Get(ctx, d.Id())
 		if err != nil {
-			return diag.FromErr(errGettingObject(objectGroup, d.Id(), err))
+			return diagGettingObject(objectGroup, d.Id(), err)
 		}
 		oldRoles := currentGroup.Roles
 		newRoles, err := convertIAMSetToRoles(d.Get("role").(*schema.Set))
@@ -145,7 +145,7 @@ func resourceIAMGroupV1Update(ctx context.Context, d *schema.ResourceData, meta
 		log.Print(msgUpdate(objectGroup, d.Id(), fmt.Sprintf("Roles to unassign: %+v, roles to assign: %+v", rolesToUnassign, rolesToAssign)))
 		err = applyGroupRoles(ctx, d, iamClient, rolesToUnassign, rolesToAssign)
 		if err != nil {
-			return diag.FromErr(errUpdatingObject(objectGroup, d.Id(), err))
+			return diagUpdatingObject(objectGroup, d.Id(), err)
 		}
 
 		return nil
@@ -163,7 +163,7 @@ func resourceIAMGroupV1Delete(ctx context.Context, d *schema.ResourceData, meta
 	log.Print(msgDelete(objectGroup, d.Id()))
 	err := iamClient.Groups.Delete(ctx, d.Id())
 	if err != nil && !errors.Is(err, iamerrors.ErrGroupNotFound) {
-		return diag.FromErr(errDeletingObject(objectGroup, d.Id(), err))
+		return diagDeletingObject(objectGroup, d.Id(), err)
 	}
 
 	d.SetId("")
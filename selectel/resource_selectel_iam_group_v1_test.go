@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/selectel/iam-go/service/groups"
@@ -14,12 +15,12 @@ import (
 func TestAccIAMV1GroupBasic(t *testing.T) {
 	var group groups.Group
 
-	testName := "test-name"
+	testName := acctest.RandomWithPrefix("tf-acc")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckIAMV1GroupDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckIAMV1GroupDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccIAMV1GroupBasic(testName),
@@ -38,12 +39,12 @@ func TestAccIAMV1GroupBasic(t *testing.T) {
 func TestAccIAMV1GroupUpdateRoles(t *testing.T) {
 	var group groups.Group
 
-	testName := "test-name"
+	testName := acctest.RandomWithPrefix("tf-acc")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckIAMV1GroupDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckIAMV1GroupDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccIAMV1GroupBasic(testName),
@@ -37,7 +37,8 @@ func resourceIAMS3CredentialsV1() *schema.Resource {
 			},
 			"project_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
 				Description: "Project ID to associate S3 Credentials with.",
 			},
@@ -71,7 +72,7 @@ func resourceIAMS3CredentialsV1Create(ctx context.Context, d *schema.ResourceDat
 		d.Get("project_id").(string),
 	)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectS3Credentials, err))
+		return diagCreatingObject(objectS3Credentials, err)
 	}
 
 	d.SetId(credentials.AccessKey)
@@ -92,7 +93,7 @@ func resourceIAMS3CredentialsV1Read(ctx context.Context, d *schema.ResourceData,
 	log.Print(msgGet(objectS3Credentials, d.Id()))
 	response, err := iamClient.S3Credentials.List(ctx, d.Get("user_id").(string))
 	if err != nil {
-		return diag.FromErr(errGettingObject(objectS3Credentials, d.Id(), err))
+		return diagGettingObject(objectS3Credentials, d.Id(), err)
 	}
 
 	var credential s3credentials.Credential
@@ -103,7 +104,7 @@ func resourceIAMS3CredentialsV1Read(ctx context.Context, d *schema.ResourceData,
 		}
 	}
 	if credential.AccessKey == "" {
-		return diag.FromErr(errGettingObject(objectS3Credentials, d.Id(), fmt.Errorf("S3 Credentials with ID %s not found", d.Id())))
+		return diagGettingObject(objectS3Credentials, d.Id(), fmt.Errorf("S3 Credentials with ID %s not found", d.Id()))
 	}
 
 	d.Set("name", credential.Name)
@@ -125,7 +126,7 @@ func resourceIAMS3CredentialsV1Delete(ctx context.Context, d *schema.ResourceDat
 	log.Print(msgDelete(objectS3Credentials, d.Id()))
 	err := iamClient.S3Credentials.Delete(ctx, d.Get("user_id").(string), d.Id())
 	if err != nil && !errors.Is(err, iamerrors.ErrCredentialNotFound) {
-		return diag.FromErr(errDeletingObject(objectS3Credentials, d.Id(), err))
+		return diagDeletingObject(objectS3Credentials, d.Id(), err)
 	}
 
 	return nil
@@ -20,9 +20,9 @@ func TestAccIAMV1S3CredentialsBasic(t *testing.T) {
 	userPassword := "A" + acctest.RandString(8) + "1"
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckIAMV1S3CredentialsDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckIAMV1S3CredentialsDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccIAMV1S3CredentialsBasic(projectName, userName, userPassword, s3CredsName),
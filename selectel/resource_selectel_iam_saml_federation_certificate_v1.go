@@ -86,7 +86,7 @@ func resourceIAMSAMLFederationCertificateV1Create(ctx context.Context, d *schema
 
 	certificate, err := iamClient.SAMLFederations.Certificates.Create(ctx, d.Get("federation_id").(string), opts)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectSAMLFederationCertificate, err))
+		return diagCreatingObject(objectSAMLFederationCertificate, err)
 	}
 
 	d.SetId(certificate.ID)
@@ -103,7 +103,7 @@ func resourceIAMSAMLFederationCertificateV1Read(ctx context.Context, d *schema.R
 	log.Print(msgGet(objectSAMLFederationCertificate, d.Id()))
 	certificate, err := iamClient.SAMLFederations.Certificates.Get(ctx, d.Get("federation_id").(string), d.Id())
 	if err != nil {
-		return diag.FromErr(errGettingObject(objectSAMLFederationCertificate, d.Id(), err))
+		return diagGettingObject(objectSAMLFederationCertificate, d.Id(), err)
 	}
 
 	d.Set("account_id", certificate.AccountID)
@@ -133,7 +133,7 @@ func resourceIAMSAMLFederationCertificateV1Update(ctx context.Context, d *schema
 	log.Print(msgUpdate(objectSAMLFederationCertificate, d.Id(), opts))
 	_, err := iamClient.SAMLFederations.Certificates.Update(ctx, d.Get("federation_id").(string), d.Id(), opts)
 	if err != nil {
-		return diag.FromErr(errUpdatingObject(objectSAMLFederationCertificate, d.Id(), err))
+		return diagUpdatingObject(objectSAMLFederationCertificate, d.Id(), err)
 	}
 
 	return resourceIAMSAMLFederationCertificateV1Read(ctx, d, meta)
@@ -148,7 +148,7 @@ func resourceIAMSAMLFederationCertificateV1Delete(ctx context.Context, d *schema
 	log.Print(msgDelete(objectSAMLFederationCertificate, d.Id()))
 	err := iamClient.SAMLFederations.Certificates.Delete(ctx, d.Get("federation_id").(string), d.Id())
 	if err != nil && !errors.Is(err, iamerrors.ErrFederationCertificateNotFound) {
-		return diag.FromErr(errDeletingObject(objectSAMLFederationCertificate, d.Id(), err))
+		return diagDeletingObject(objectSAMLFederationCertificate, d.Id(), err)
 	}
 
 	return nil
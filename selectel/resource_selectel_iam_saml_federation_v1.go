@@ -88,7 +88,7 @@ func resourceIAMSAMLFederationV1Create(ctx context.Context, d *schema.ResourceDa
 
 	federation, err := iamClient.SAMLFederations.Create(ctx, opts)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectSAMLFederation, err))
+		return diagCreatingObject(objectSAMLFederation, err)
 	}
 
 	d.SetId(federation.ID)
@@ -105,7 +105,7 @@ func resourceIAMSAMLFederationV1Read(ctx context.Context, d *schema.ResourceData
 	log.Print(msgGet(objectSAMLFederation, d.Id()))
 	federation, err := iamClient.SAMLFederations.Get(ctx, d.Id())
 	if err != nil {
-		return diag.FromErr(errGettingObject(objectSAMLFederation, d.Id(), err))
+		return diagGettingObject(objectSAMLFederation, d.Id(), err)
 	}
 
 	d.Set("account_id", federation.AccountID)
@@ -143,7 +143,7 @@ func resourceIAMSAMLFederationV1Update(ctx context.Context, d *schema.ResourceDa
 	log.Print(msgUpdate(objectSAMLFederation, d.Id(), opts))
 	err := iamClient.SAMLFederations.Update(ctx, d.Id(), opts)
 	if err != nil {
-		return diag.FromErr(errUpdatingObject(objectSAMLFederation, d.Id(), err))
+		return diagUpdatingObject(objectSAMLFederation, d.Id(), err)
 	}
 
 	return resourceIAMSAMLFederationV1Read(ctx, d, meta)
@@ -158,7 +158,7 @@ func resourceIAMSAMLFederationV1Delete(ctx context.Context, d *schema.ResourceDa
 	log.Print(msgDelete(objectSAMLFederation, d.Id()))
 	err := iamClient.SAMLFederations.Delete(ctx, d.Id())
 	if err != nil && !errors.Is(err, iamerrors.ErrFederationNotFound) {
-		return diag.FromErr(errDeletingObject(objectSAMLFederation, d.Id(), err))
+		return diagDeletingObject(objectSAMLFederation, d.Id(), err)
 	}
 
 	return nil
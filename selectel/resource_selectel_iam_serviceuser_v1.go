@@ -37,10 +37,26 @@ func resourceIAMServiceUserV1() *schema.Resource {
 				Description: "Name of the Service User.",
 			},
 			"password": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Sensitive:   true,
-				Description: "Password of the Service User.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ExactlyOneOf: []string{"password", "password_wo"},
+				Description:  "Password of the Service User.",
+			},
+			"password_wo": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				WriteOnly:    true,
+				ExactlyOneOf: []string{"password", "password_wo"},
+				RequiredWith: []string{"password_wo_version"},
+				Description:  "Password of the Service User which is not stored in the state.",
+			},
+			"password_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"password_wo"},
+				Description:  "Version of the write-only password. Change it to update the password.",
 			},
 			"role": {
 				Type:        schema.TypeSet,
@@ -82,11 +98,11 @@ func resourceIAMServiceUserV1Create(ctx context.Context, d *schema.ResourceData,
 	user, err := iamClient.ServiceUsers.Create(ctx, serviceusers.CreateRequest{
 		Enabled:  d.Get("enabled").(bool),
 		Name:     d.Get("name").(string),
-		Password: d.Get("password").(string),
+		Password: secretValue(d, "password", "password_wo"),
 		Roles:    roles,
 	})
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectServiceUser, err))
+		return diagCreatingObject(objectServiceUser, err)
 	}
 
 	d.SetId(user.ID)
@@ -103,13 +119,14 @@ func resourceIAMServiceUserV1Read(ctx context.Context, d *schema.ResourceData, m
 	log.Print(msgGet(objectServiceUser, d.Id()))
 	user, err := iamClient.ServiceUsers.Get(ctx, d.Id())
 	if err != nil {
-		return diag.FromErr(errGettingObject(objectServiceUser, d.Id(), err))
+		return diagGettingObject(objectServiceUser, d.Id(), err)
 	}
 
 	d.Set("name", user.Name)
 	d.Set("enabled", user.Enabled)
 	d.Set("role", convertIAMRolesToSet(user.Roles))
-	if _, ok := d.GetOk("password"); !ok {
+	// The password is unknown after the import, unless it is write-only.
+	if _, ok := d.GetOk("password"); !ok && d.Get("password_wo_version").(int) == 0 {
 		d.Set("password", importIAMUndefined)
 	}
 
@@ -126,6 +143,9 @@ func resourceIAMServiceUserV1Update(ctx context.Context, d *schema.ResourceData,
 	if password == importIAMUndefined {
 		password = ""
 	}
+	if d.HasChange("password_wo_version") {
+		password = writeOnlyString(d, "password_wo")
+	}
 
 	opts := serviceusers.UpdateRequest{
 		Enabled:  d.Get("enabled").(bool),
@@ -136,13 +156,13 @@ func resourceIAMServiceUserV1Update(ctx context.Context, d *schema.ResourceData,
 	log.Print(msgUpdate(objectServiceUser, d.Id(), fmt.Sprintf("Enabled: %v, Name: %v", opts.Enabled, opts.Name)))
 	_, err := iamClient.ServiceUsers.Update(ctx, d.Id(), opts)
 	if err != nil {
-		return diag.FromErr(errUpdatingObject(objectServiceUser, d.Id(), err))
+		return diagUpdatingObject(objectServiceUser, d.Id(), err)
 	}
 
 	if d.HasChange("role") {
 		currentUser, err := iamClient.ServiceUsers.Get(ctx, d.Id())
 		if err != nil {
-			return diag.FromErr(errGettingObject(objectServiceUser, d.Id(), err))
+			return diagGettingObject(objectServiceUser, d.Id(), err)
 		}
 		oldRoles := currentUser.Roles
 		newRoles, err := convertIAMSetToRoles(d.Get("role").(*schema.Set))
@@ -155,7 +175,7 @@ func resourceIAMServiceUserV1Update(ctx context.Context, d *schema.ResourceData,
 		log.Print(msgUpdate(objectServiceUser, d.Id(), fmt.Sprintf("Roles to unassign: %+v, roles to assign: %+v", rolesToUnassign, rolesToAssign)))
 		err = applyServiceUserRoles(ctx, d, iamClient, rolesToUnassign, rolesToAssign)
 		if err != nil {
-			return diag.FromErr(errUpdatingObject(objectServiceUser, d.Id(), err))
+			return diagUpdatingObject(objectServiceUser, d.Id(), err)
 		}
 
 		return nil
@@ -173,7 +193,7 @@ func resourceIAMServiceUserV1Delete(ctx context.Context, d *schema.ResourceData,
 	log.Print(msgDelete(objectServiceUser, d.Id()))
 	err := iamClient.ServiceUsers.Delete(ctx, d.Id())
 	if err != nil && !errors.Is(err, iamerrors.ErrUserNotFound) {
-		return diag.FromErr(errDeletingObject(objectServiceUser, d.Id(), err))
+		return diagDeletingObject(objectServiceUser, d.Id(), err)
 	}
 
 	return nil
@@ -18,9 +18,9 @@ func TestAccIAMV1ServiceUserBasic(t *testing.T) {
 	serviceUserPassword := "A" + acctest.RandString(8) + "1"
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckIAMV1ServiceUserDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckIAMV1ServiceUserDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccIAMV1ServiceUserBasic(serviceUserName, serviceUserPassword),
@@ -44,9 +44,9 @@ func TestAccIAMV1ServiceUserUpdateRoles(t *testing.T) {
 	serviceUserPassword := "A" + acctest.RandString(8) + "1"
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckIAMV1ServiceUserDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckIAMV1ServiceUserDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccIAMV1ServiceUserBasic(serviceUserName, serviceUserPassword),
@@ -98,9 +98,9 @@ func TestAccIAMV1ServiceUserUpdateName(t *testing.T) {
 	serviceUserPassword := "A" + acctest.RandString(8)
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckIAMV1ServiceUserDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckIAMV1ServiceUserDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccIAMV1ServiceUserBasic(serviceUserName, serviceUserPassword),
@@ -110,7 +110,7 @@ func resourceIAMUserV1Create(ctx context.Context, d *schema.ResourceData, meta i
 		Roles:      roles,
 	})
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectUser, err))
+		return diagCreatingObject(objectUser, err)
 	}
 	d.SetId(user.ID)
 
@@ -126,7 +126,7 @@ func resourceIAMUserV1Read(ctx context.Context, d *schema.ResourceData, meta int
 	log.Print(msgGet(objectUser, d.Id()))
 	user, err := iamClient.Users.Get(ctx, d.Id())
 	if err != nil {
-		return diag.FromErr(errGettingObject(objectUser, d.Id(), err))
+		return diagGettingObject(objectUser, d.Id(), err)
 	}
 
 	d.Set("keystone_id", user.KeystoneID)
@@ -150,7 +150,7 @@ func resourceIAMUserV1Update(ctx context.Context, d *schema.ResourceData, meta i
 	if d.HasChange("role") {
 		currentUser, err := iamClient.Users.Get(ctx, d.Id())
 		if err != nil {
-			return diag.FromErr(errGettingObject(objectUser, d.Id(), err))
+			return diagGettingObject(objectUser, d.Id(), err)
 		}
 		oldRoles := currentUser.Roles
 		newRoles, err := convertIAMSetToRoles(d.Get("role").(*schema.Set))
@@ -163,7 +163,7 @@ func resourceIAMUserV1Update(ctx context.Context, d *schema.ResourceData, meta i
 		log.Print(msgUpdate(objectUser, d.Id(), fmt.Sprintf("Roles to unassign: %+v, roles to assign: %+v", rolesToUnassign, rolesToAssign)))
 		err = applyUserRoles(ctx, d, iamClient, rolesToUnassign, rolesToAssign)
 		if err != nil {
-			return diag.FromErr(errUpdatingObject(objectUser, d.Id(), err))
+			return diagUpdatingObject(objectUser, d.Id(), err)
 		}
 
 		return nil
@@ -181,7 +181,7 @@ func resourceIAMUserV1Delete(ctx context.Context, d *schema.ResourceData, meta i
 	log.Print(msgDelete(objectUser, d.Id()))
 	err := iamClient.Users.Delete(ctx, d.Id())
 	if err != nil && !errors.Is(err, iamerrors.ErrUserNotFound) {
-		return diag.FromErr(errDeletingObject(objectUser, d.Id(), err))
+		return diagDeletingObject(objectUser, d.Id(), err)
 	}
 
 	return nil
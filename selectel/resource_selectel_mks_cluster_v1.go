@@ -6,20 +6,18 @@ import (
 	"fmt"
 	"log"
 	"net/http"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/selectel/go-selvpcclient/v4/selvpcclient/quotamanager/quotas"
 	"github.com/selectel/mks-go/pkg/v1/cluster"
 )
 
 func resourceMKSClusterV1() *schema.Resource {
-	return &schema.Resource{
+	return withDeletionProtection(objectCluster, &schema.Resource{
 		CreateContext: resourceMKSClusterV1Create,
 		ReadContext:   resourceMKSClusterV1Read,
 		UpdateContext: resourceMKSClusterV1Update,
@@ -51,12 +49,14 @@ func resourceMKSClusterV1() *schema.Resource {
 			},
 			"project_id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"region": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"kube_version": {
@@ -197,7 +197,7 @@ func resourceMKSClusterV1() *schema.Resource {
 				},
 			},
 		},
-	}
+	})
 }
 
 func resourceMKSClusterV1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -239,12 +239,12 @@ func resourceMKSClusterV1Create(ctx context.Context, d *schema.ResourceData, met
 
 	featureGates, err := getSetAsStrings(d, featureGatesKey)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectCluster, err))
+		return diagCreatingObject(objectCluster, err)
 	}
 
 	admissionControllers, err := getSetAsStrings(d, admissionControllersKey)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectCluster, err))
+		return diagCreatingObject(objectCluster, err)
 	}
 
 	oidc, err := expandAndValidateMKSClusterV1OIDC(d)
@@ -276,24 +276,24 @@ func resourceMKSClusterV1Create(ctx context.Context, d *schema.ResourceData, met
 
 	projectQuotas, _, err := quotas.GetProjectQuotas(selvpcClient, projectID, region)
 	if err != nil {
-		return diag.FromErr(errGettingObject(objectProjectQuotas, projectID, err))
+		return diagGettingObject(objectProjectQuotas, projectID, err)
 	}
 
 	if err := checkQuotasForCluster(projectQuotas, zonal); err != nil {
-		return diag.FromErr(errCreatingObject(objectCluster, err))
+		return diagCreatingObject(objectCluster, err)
 	}
 
 	log.Print(msgCreate(objectCluster, createOpts))
 	newCluster, _, err := cluster.Create(ctx, mksClient, createOpts)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectCluster, err))
+		return diagCreatingObject(objectCluster, err)
 	}
 
 	log.Printf("[DEBUG] waiting for cluster %s to become 'ACTIVE'", newCluster.ID)
 	timeout := d.Timeout(schema.TimeoutCreate)
 	err = waitForMKSClusterV1ActiveState(ctx, mksClient, newCluster.ID, timeout)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectCluster, err))
+		return diagCreatingObject(objectCluster, err)
 	}
 
 	d.SetId(newCluster.ID)
@@ -317,7 +317,7 @@ func resourceMKSClusterV1Read(ctx context.Context, d *schema.ResourceData, meta
 			}
 		}
 
-		return diag.FromErr(errGettingObject(objectCluster, d.Id(), err))
+		return diagGettingObject(objectCluster, d.Id(), err)
 	}
 
 	d.Set("name", mksCluster.Name)
@@ -349,7 +349,7 @@ func resourceMKSClusterV1Update(ctx context.Context, d *schema.ResourceData, met
 
 	if d.HasChange("kube_version") {
 		if err := upgradeMKSClusterV1KubeVersion(ctx, d, mksClient); err != nil {
-			return diag.FromErr(errUpdatingObject(objectCluster, d.Id(), err))
+			return diagUpdatingObject(objectCluster, d.Id(), err)
 		}
 	}
 
@@ -374,14 +374,14 @@ func resourceMKSClusterV1Update(ctx context.Context, d *schema.ResourceData, met
 	if d.HasChange(featureGatesKey) {
 		v, err := getSetAsStrings(d, featureGatesKey)
 		if err != nil {
-			return diag.FromErr(errCreatingObject(objectCluster, err))
+			return diagCreatingObject(objectCluster, err)
 		}
 		kubeOptions.FeatureGates = v
 	}
 	if d.HasChange(admissionControllersKey) {
 		v, err := getSetAsStrings(d, admissionControllersKey)
 		if err != nil {
-			return diag.FromErr(errCreatingObject(objectCluster, err))
+			return diagCreatingObject(objectCluster, err)
 		}
 		kubeOptions.AdmissionControllers = v
 	}
@@ -403,14 +403,14 @@ func resourceMKSClusterV1Update(ctx context.Context, d *schema.ResourceData, met
 		log.Print(msgUpdate(objectCluster, d.Id(), updateOpts))
 		_, _, err := cluster.Update(ctx, mksClient, d.Id(), &updateOpts)
 		if err != nil {
-			return diag.FromErr(errUpdatingObject(objectCluster, d.Id(), err))
+			return diagUpdatingObject(objectCluster, d.Id(), err)
 		}
 
 		log.Printf("[DEBUG] waiting for cluster %s to become 'ACTIVE'", d.Id())
 		timeout := d.Timeout(schema.TimeoutUpdate)
 		err = waitForMKSClusterV1ActiveState(ctx, mksClient, d.Id(), timeout)
 		if err != nil {
-			return diag.FromErr(errUpdatingObject(objectCluster, d.Id(), err))
+			return diagUpdatingObject(objectCluster, d.Id(), err)
 		}
 	}
 
@@ -426,33 +426,14 @@ func resourceMKSClusterV1Delete(ctx context.Context, d *schema.ResourceData, met
 	log.Print(msgDelete(objectCluster, d.Id()))
 	_, err := cluster.Delete(ctx, mksClient, d.Id())
 	if err != nil {
-		return diag.FromErr(errDeletingObject(objectCluster, d.Id(), err))
-	}
-
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{strconv.Itoa(http.StatusOK)},
-		Target:  []string{strconv.Itoa(http.StatusNotFound)},
-		Refresh: func() (result interface{}, state string, err error) {
-			result, response, err := cluster.Get(ctx, mksClient, d.Id())
-			if err != nil {
-				if response != nil {
-					return result, strconv.Itoa(response.StatusCode), nil
-				}
-
-				return nil, "", err
-			}
-
-			return result, strconv.Itoa(response.StatusCode), err
-		},
-		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      10 * time.Second,
-		MinTimeout: 3 * time.Second,
+		return diagDeletingObject(objectCluster, d.Id(), err)
 	}
 
 	log.Printf("[DEBUG] waiting for cluster %s to become deleted", d.Id())
-	_, err = stateConf.WaitForStateContext(ctx)
+	timeout := d.Timeout(schema.TimeoutDelete)
+	err = waitForMKSClusterV1Deleted(ctx, mksClient, d.Id(), timeout)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error waiting for the cluster %s to become deleted: %s", d.Id(), err))
+		return diag.FromErr(err)
 	}
 
 	return nil
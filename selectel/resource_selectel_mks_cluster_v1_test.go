@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"strings"
 	"testing"
 	"time"
@@ -16,31 +17,38 @@ import (
 	"github.com/selectel/mks-go/pkg/v1/cluster"
 	"github.com/selectel/mks-go/pkg/v1/kubeoptions"
 	"github.com/selectel/mks-go/pkg/v1/kubeversion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/fakeapi"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/recorder"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/waiter"
 )
 
 func TestAccMKSClusterV1Basic(t *testing.T) {
+	recording := testAccStartRecording(t)
+
 	var (
 		mksCluster cluster.View
 		project    projects.Project
 	)
 
-	projectName := acctest.RandomWithPrefix("tf-acc")
-	clusterName := acctest.RandomWithPrefix("tf-acc-cl")
-	kubeVersion := testAccMKSClusterV1GetDefaultKubeVersion(t)
-	maintenanceWindowStart := testAccMKSClusterV1GetMaintenanceWindowStart(12 * time.Hour)
-	maintenanceWindowStartUpdated := testAccMKSClusterV1GetMaintenanceWindowStart(14 * time.Hour)
+	projectName := recording.RandomWithPrefix("tf-acc")
+	clusterName := recording.RandomWithPrefix("tf-acc-cl")
+	kubeVersion := testAccMKSClusterV1GetDefaultKubeVersion(t, recording)
+	maintenanceWindowStart := testAccMKSClusterV1GetMaintenanceWindowStart(recording, 12*time.Hour)
+	maintenanceWindowStartUpdated := testAccMKSClusterV1GetMaintenanceWindowStart(recording, 14*time.Hour)
 
-	defaultFeatureGates := testDefaultFeatureGates(t)
-	defaultAdmissionControllers := testDefaultAdmissionControllers(t)
+	defaultFeatureGates := testDefaultFeatureGates(t, recording)
+	defaultAdmissionControllers := testDefaultAdmissionControllers(t, recording)
 	featureGates := defaultFeatureGates[:1]
 	featureGatesUpdate := defaultFeatureGates[1:2]
 	admissionControllers := defaultAdmissionControllers[:1]
 	admissionControllersUpdate := defaultAdmissionControllers[1:2]
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccMKSClusterV1BasicWithKubeOptions(projectName, clusterName, kubeVersion, maintenanceWindowStart, featureGates, admissionControllers),
@@ -91,21 +99,103 @@ func TestAccMKSClusterV1Basic(t *testing.T) {
 	})
 }
 
+func TestUnitMKSClusterV1Basic(t *testing.T) {
+	var mksCluster cluster.View
+
+	api := newTestFakeAPI(t)
+	clusterName := acctest.RandomWithPrefix("tf-unit-cl")
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testUnitCheckFakeAPIEmpty(api, fakeapi.KindMKSClusters),
+		Steps: []resource.TestStep{
+			{
+				Config: api.ProviderConfig() + testUnitMKSClusterV1Basic(clusterName, "01:00:00"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMKSClusterV1Exists("selectel_mks_cluster_v1.cluster_tf_acc_test_1", &mksCluster),
+					resource.TestCheckResourceAttr("selectel_mks_cluster_v1.cluster_tf_acc_test_1", "name", clusterName),
+					resource.TestCheckResourceAttr("selectel_mks_cluster_v1.cluster_tf_acc_test_1", "kube_version", fakeapi.MKSKubeVersion),
+					resource.TestCheckResourceAttr("selectel_mks_cluster_v1.cluster_tf_acc_test_1", "project_id", fakeapi.ProjectID),
+					resource.TestCheckResourceAttr("selectel_mks_cluster_v1.cluster_tf_acc_test_1", "region", fakeapi.Region),
+					resource.TestCheckResourceAttr("selectel_mks_cluster_v1.cluster_tf_acc_test_1", "maintenance_window_start", "01:00:00"),
+					resource.TestCheckResourceAttr("selectel_mks_cluster_v1.cluster_tf_acc_test_1", "maintenance_window_end", "05:00:00"),
+					resource.TestCheckResourceAttr("selectel_mks_cluster_v1.cluster_tf_acc_test_1", "status", "ACTIVE"),
+				),
+			},
+			{
+				Config: api.ProviderConfig() + testUnitMKSClusterV1Basic(clusterName, "22:00:00"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("selectel_mks_cluster_v1.cluster_tf_acc_test_1", "maintenance_window_start", "22:00:00"),
+					resource.TestCheckResourceAttr("selectel_mks_cluster_v1.cluster_tf_acc_test_1", "maintenance_window_end", "02:00:00"),
+					resource.TestCheckResourceAttr("selectel_mks_cluster_v1.cluster_tf_acc_test_1", "status", "ACTIVE"),
+				),
+			},
+		},
+	})
+}
+
+// TestMKSClusterV1Replay creates and deletes the cluster calling the resource directly,
+// so the cassette is replayed without the Terraform CLI and credentials. The cassette
+// is recorded with SEL_ACC_RECORDER=record, INFRA_PROJECT_ID and INFRA_REGION.
+func TestMKSClusterV1Replay(t *testing.T) {
+	recording := testStartReplay(t)
+	testAccSelectelPreCheckWithProjectID(t)
+	start := time.Now()
+
+	ctx := context.Background()
+	config := testConfigureProvider(t, map[string]interface{}{})
+	projectID, region := os.Getenv("INFRA_PROJECT_ID"), os.Getenv("INFRA_REGION")
+
+	versions := dataSourceMKSKubeVersionsV1().TestResourceData()
+	require.NoError(t, versions.Set("project_id", projectID))
+	require.NoError(t, versions.Set("region", region))
+	require.False(t, dataSourceMKSKubeVersionsV1Read(ctx, versions, config).HasError())
+	kubeVersion := versions.Get("default_version").(string)
+	require.NotEmpty(t, kubeVersion)
+
+	r := resourceMKSClusterV1()
+	d := r.TestResourceData()
+	clusterName := recording.RandomWithPrefix("tf-acc-cl")
+	require.NoError(t, d.Set("name", clusterName))
+	require.NoError(t, d.Set("project_id", projectID))
+	require.NoError(t, d.Set("region", region))
+	require.NoError(t, d.Set("kube_version", kubeVersion))
+	require.NoError(t, d.Set("maintenance_window_start", testAccMKSClusterV1GetMaintenanceWindowStart(recording, 12*time.Hour)))
+
+	diags := r.CreateContext(ctx, d, config)
+	require.False(t, diags.HasError(), "unexpected diagnostics: %+v", diags)
+	assert.Equal(t, clusterName, d.Get("name"))
+	assert.Equal(t, "ACTIVE", d.Get("status"))
+
+	diags = r.DeleteContext(ctx, d, config)
+	require.False(t, diags.HasError(), "unexpected diagnostics: %+v", diags)
+
+	if os.Getenv(recorderModeEnvVar) == string(recorder.ModeReplay) {
+		// Both the create and the delete waiters wait for 10 seconds before the first poll
+		// on the replay clock, not in real time.
+		assert.GreaterOrEqual(t, waiter.DefaultClock.Now().Sub(recording.Now()), 20*time.Second)
+		assert.Less(t, time.Since(start), 5*time.Second)
+	}
+}
+
 func TestAccMKSClusterV1Zonal(t *testing.T) {
+	recording := testAccStartRecording(t)
+
 	var (
 		mksCluster cluster.View
 		project    projects.Project
 	)
 
-	projectName := acctest.RandomWithPrefix("tf-acc")
-	clusterName := acctest.RandomWithPrefix("tf-acc-cl")
-	kubeVersion := testAccMKSClusterV1GetDefaultKubeVersion(t)
-	maintenanceWindowStart := testAccMKSClusterV1GetMaintenanceWindowStart(12 * time.Hour)
+	projectName := recording.RandomWithPrefix("tf-acc")
+	clusterName := recording.RandomWithPrefix("tf-acc-cl")
+	kubeVersion := testAccMKSClusterV1GetDefaultKubeVersion(t, recording)
+	maintenanceWindowStart := testAccMKSClusterV1GetMaintenanceWindowStart(recording, 12*time.Hour)
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccMKSClusterV1Zonal(projectName, clusterName, kubeVersion, maintenanceWindowStart),
@@ -135,20 +225,22 @@ func TestAccMKSClusterV1Zonal(t *testing.T) {
 }
 
 func TestAccMKSClusterV1PrivateKubeAPI(t *testing.T) {
+	recording := testAccStartRecording(t)
+
 	var (
 		mksCluster cluster.View
 		project    projects.Project
 	)
 
-	projectName := acctest.RandomWithPrefix("tf-acc")
-	clusterName := acctest.RandomWithPrefix("tf-acc-cl")
-	kubeVersion := testAccMKSClusterV1GetDefaultKubeVersion(t)
-	maintenanceWindowStart := testAccMKSClusterV1GetMaintenanceWindowStart(12 * time.Hour)
+	projectName := recording.RandomWithPrefix("tf-acc")
+	clusterName := recording.RandomWithPrefix("tf-acc-cl")
+	kubeVersion := testAccMKSClusterV1GetDefaultKubeVersion(t, recording)
+	maintenanceWindowStart := testAccMKSClusterV1GetMaintenanceWindowStart(recording, 12*time.Hour)
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccMKSClusterV1PrivateKubeAPI(projectName, clusterName, kubeVersion, maintenanceWindowStart),
@@ -177,21 +269,21 @@ func TestAccMKSClusterV1PrivateKubeAPI(t *testing.T) {
 	})
 }
 
-func testAccMKSClusterV1GetMaintenanceWindowStart(delay time.Duration) string {
-	return time.Now().UTC().Add(delay).Format("15:04:00")
+func testAccMKSClusterV1GetMaintenanceWindowStart(recording *testAccRecording, delay time.Duration) string {
+	return recording.Now().UTC().Add(delay).Format("15:04:00")
 }
 
-func testAccMKSClusterV1GetDefaultKubeVersion(t *testing.T) string {
+func testAccMKSClusterV1GetDefaultKubeVersion(t *testing.T, recording *testAccRecording) string {
 	var (
 		kubeVersion string
 		project     projects.Project
 	)
-	projectName := acctest.RandomWithPrefix("tf-acc")
+	projectName := recording.RandomWithPrefix("tf-acc")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccVPCV2ProjectBasic(projectName),
@@ -280,6 +372,15 @@ resource "selectel_mks_cluster_v1" "cluster_tf_acc_test_1" {
 }`, projectName, clusterName, kubeVersion, maintenanceWindowStart)
 }
 
+func testUnitMKSClusterV1Basic(clusterName, maintenanceWindowStart string) string {
+	return fmt.Sprintf(`
+resource "selectel_mks_cluster_v1" "cluster_tf_acc_test_1" {
+  name                     = "%s"
+  kube_version             = "%s"
+  maintenance_window_start = "%s"
+}`, clusterName, fakeapi.MKSKubeVersion, maintenanceWindowStart)
+}
+
 func testAccMKSClusterV1BasicWithKubeOptions(projectName, clusterName, kubeVersion, maintenanceWindowStart string, featureGates, admissionControllers []string) string {
 	flatFeatureGates := flatStringsListWithQuotes(featureGates)
 	flatAdmissionControllers := flatStringsListWithQuotes(admissionControllers)
@@ -368,15 +469,15 @@ func testAccMKSClusterV1PrivateKubeAPI(projectName, clusterName, kubeVersion, ma
  }`, projectName, clusterName, kubeVersion, maintenanceWindowStart)
 }
 
-func testDefaultFeatureGates(t *testing.T) []string {
+func testDefaultFeatureGates(t *testing.T, recording *testAccRecording) []string {
 	var project projects.Project
 	featureGates := make([]string, 0)
-	projectName := acctest.RandomWithPrefix("tf-acc")
+	projectName := recording.RandomWithPrefix("tf-acc")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccVPCV2ProjectBasic(projectName),
@@ -431,15 +532,15 @@ func testAccCheckMKSClusterV1DefaultKubeVersionFeatureGates(n string, featureGat
 	}
 }
 
-func testDefaultAdmissionControllers(t *testing.T) []string {
+func testDefaultAdmissionControllers(t *testing.T, recording *testAccRecording) []string {
 	var project projects.Project
 	admissionContollers := make([]string, 0)
-	projectName := acctest.RandomWithPrefix("tf-acc")
+	projectName := recording.RandomWithPrefix("tf-acc")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccVPCV2ProjectBasic(projectName),
@@ -506,7 +607,12 @@ func newTestMKSClientWithParams(projectID, region string) (*v1.ServiceClient, er
 		return nil, fmt.Errorf("can't get endpoint for mks acc tests: %w", err)
 	}
 
-	mksClient := v1.NewMKSClientV1(selvpcClient.GetXAuthToken(), endpoint.URL)
+	token, err := config.GetXAuthTokenWithProjectScope(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("can't get token for mks acc tests: %w", err)
+	}
+
+	mksClient := v1.NewMKSClientV1(token, endpoint.URL)
 
 	return mksClient, nil
 }
@@ -38,7 +38,8 @@ func resourceMKSNodegroupV1() *schema.Resource {
 			},
 			"project_id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"status": {
@@ -47,7 +48,8 @@ func resourceMKSNodegroupV1() *schema.Resource {
 			},
 			"region": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"availability_zone": {
@@ -236,7 +238,7 @@ func resourceMKSNodegroupV1Create(ctx context.Context, d *schema.ResourceData, m
 	// Get a list of all nodegroups in the cluster.
 	allNodegroups, _, err := nodegroup.List(ctx, mksClient, clusterID)
 	if err != nil {
-		return diag.FromErr(errGettingObject("all nodegroups in the cluster", clusterID, err))
+		return diagGettingObject("all nodegroups in the cluster", clusterID, err)
 	}
 
 	// Prepare a map with known nodegroup IDs.
@@ -275,13 +277,13 @@ func resourceMKSNodegroupV1Create(ctx context.Context, d *schema.ResourceData, m
 
 	projectQuotas, _, err := quotas.GetProjectQuotas(selvpcClient, projectID, region)
 	if err != nil {
-		return diag.FromErr(errGettingObject(objectProjectQuotas, projectID, err))
+		return diagGettingObject(objectProjectQuotas, projectID, err)
 	}
 
 	// Skip quota validation cause we can not open flavor and check resource claim.
 	if createOpts.FlavorID == "" {
 		if err := checkQuotasForNodegroup(projectQuotas, createOpts); err != nil {
-			return diag.FromErr(errCreatingObject(objectNodegroup, err))
+			return diagCreatingObject(objectNodegroup, err)
 		}
 	}
 
@@ -307,20 +309,20 @@ func resourceMKSNodegroupV1Create(ctx context.Context, d *schema.ResourceData, m
 	log.Print(msgCreate(objectNodegroup, createOpts))
 	_, err = nodegroup.Create(ctx, mksClient, clusterID, createOpts)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectNodegroup, err))
+		return diagCreatingObject(objectNodegroup, err)
 	}
 
 	log.Printf("[DEBUG] waiting for cluster %s to become 'ACTIVE'", clusterID)
 	timeout := d.Timeout(schema.TimeoutCreate)
 	err = waitForMKSClusterV1ActiveState(ctx, mksClient, clusterID, timeout)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectNodegroup, err))
+		return diagCreatingObject(objectNodegroup, err)
 	}
 
 	// Get a list of all nodegroups in the cluster and find a new nodegroup.
 	allNodegroups, _, err = nodegroup.List(ctx, mksClient, clusterID)
 	if err != nil {
-		return diag.FromErr(errGettingObject("all nodegroups in the cluster", clusterID, err))
+		return diagGettingObject("all nodegroups in the cluster", clusterID, err)
 	}
 
 	var nodegroupID string
@@ -347,7 +349,7 @@ func resourceMKSNodegroupV1Read(ctx context.Context, d *schema.ResourceData, met
 	clusterID, nodegroupID, err := mksNodegroupV1ParseID(d.Id())
 	if err != nil {
 		d.SetId("")
-		return diag.FromErr(errGettingObject(objectNodegroup, d.Id(), err))
+		return diagGettingObject(objectNodegroup, d.Id(), err)
 	}
 
 	mksClient, diagErr := getMKSClient(d, meta)
@@ -365,7 +367,7 @@ func resourceMKSNodegroupV1Read(ctx context.Context, d *schema.ResourceData, met
 			}
 		}
 
-		return diag.FromErr(errGettingObject(objectNodegroup, d.Id(), err))
+		return diagGettingObject(objectNodegroup, d.Id(), err)
 	}
 
 	d.Set("cluster_id", mksNodegroup.ClusterID)
@@ -405,7 +407,7 @@ func resourceMKSNodegroupV1Update(ctx context.Context, d *schema.ResourceData, m
 	clusterID, nodegroupID, err := mksNodegroupV1ParseID(d.Id())
 	if err != nil {
 		d.SetId("")
-		return diag.FromErr(errUpdatingObject(objectNodegroup, d.Id(), err))
+		return diagUpdatingObject(objectNodegroup, d.Id(), err)
 	}
 
 	selMutexKV.Lock(clusterID)
@@ -461,14 +463,14 @@ func resourceMKSNodegroupV1Update(ctx context.Context, d *schema.ResourceData, m
 		log.Print(msgUpdate(objectNodegroup, d.Id(), updateOpts))
 		_, err := nodegroup.Update(ctx, mksClient, clusterID, nodegroupID, &updateOpts)
 		if err != nil {
-			return diag.FromErr(errUpdatingObject(objectNodegroup, d.Id(), err))
+			return diagUpdatingObject(objectNodegroup, d.Id(), err)
 		}
 
 		log.Printf("[DEBUG] waiting for nodegroup %s to become 'ACTIVE'", nodegroupID)
 		timeout := d.Timeout(schema.TimeoutUpdate)
 		err = waitForMKSNodegroupV1ActiveState(ctx, mksClient, clusterID, nodegroupID, timeout)
 		if err != nil {
-			return diag.FromErr(errUpdatingObject(objectNodegroup, d.Id(), err))
+			return diagUpdatingObject(objectNodegroup, d.Id(), err)
 		}
 	}
 
@@ -488,11 +490,11 @@ func resourceMKSNodegroupV1Update(ctx context.Context, d *schema.ResourceData, m
 
 		projectQuotas, _, err := quotas.GetProjectQuotas(selvpcClient, projectID, region)
 		if err != nil {
-			return diag.FromErr(errGettingObject(objectProjectQuotas, projectID, err))
+			return diagGettingObject(objectProjectQuotas, projectID, err)
 		}
 
 		if err := checkQuotasForNodegroup(projectQuotas, &newNodesRequest); err != nil {
-			return diag.FromErr(errUpdatingObject(objectNodegroup, d.Id(), err))
+			return diagUpdatingObject(objectNodegroup, d.Id(), err)
 		}
 
 		resizeOpts := nodegroup.ResizeOpts{
@@ -502,14 +504,14 @@ func resourceMKSNodegroupV1Update(ctx context.Context, d *schema.ResourceData, m
 		log.Print(msgUpdate(objectNodegroup, d.Id(), resizeOpts))
 		_, err = nodegroup.Resize(ctx, mksClient, clusterID, nodegroupID, &resizeOpts)
 		if err != nil {
-			return diag.FromErr(errUpdatingObject(objectNodegroup, d.Id(), err))
+			return diagUpdatingObject(objectNodegroup, d.Id(), err)
 		}
 
 		log.Printf("[DEBUG] waiting for nodegroup %s to become 'ACTIVE'", nodegroupID)
 		timeout := d.Timeout(schema.TimeoutUpdate)
 		err = waitForMKSNodegroupV1ActiveState(ctx, mksClient, clusterID, nodegroupID, timeout)
 		if err != nil {
-			return diag.FromErr(errUpdatingObject(objectNodegroup, d.Id(), err))
+			return diagUpdatingObject(objectNodegroup, d.Id(), err)
 		}
 	}
 
@@ -520,7 +522,7 @@ func resourceMKSNodegroupV1Delete(ctx context.Context, d *schema.ResourceData, m
 	clusterID, nodegroupID, err := mksNodegroupV1ParseID(d.Id())
 	if err != nil {
 		d.SetId("")
-		return diag.FromErr(errDeletingObject(objectNodegroup, d.Id(), err))
+		return diagDeletingObject(objectNodegroup, d.Id(), err)
 	}
 
 	selMutexKV.Lock(clusterID)
@@ -534,14 +536,14 @@ func resourceMKSNodegroupV1Delete(ctx context.Context, d *schema.ResourceData, m
 	log.Print(msgDelete(objectNodegroup, d.Id()))
 	_, err = nodegroup.Delete(ctx, mksClient, clusterID, nodegroupID)
 	if err != nil {
-		return diag.FromErr(errDeletingObject(objectNodegroup, d.Id(), err))
+		return diagDeletingObject(objectNodegroup, d.Id(), err)
 	}
 
 	log.Printf("[DEBUG] waiting for cluster %s to become 'ACTIVE'", clusterID)
 	timeout := d.Timeout(schema.TimeoutDelete)
 	err = waitForMKSClusterV1ActiveState(ctx, mksClient, clusterID, timeout)
 	if err != nil {
-		return diag.FromErr(errDeletingObject(objectNodegroup, d.Id(), err))
+		return diagDeletingObject(objectNodegroup, d.Id(), err)
 	}
 
 	return nil
@@ -7,7 +7,6 @@ import (
 	"testing"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/selectel/go-selvpcclient/v4/selvpcclient/resell/v2/projects"
@@ -15,20 +14,22 @@ import (
 )
 
 func TestAccMKSNodegroupV1Basic(t *testing.T) {
+	recording := testAccStartRecording(t)
+
 	var (
 		mksNodegroup nodegroup.GetView
 		project      projects.Project
 	)
 
-	projectName := acctest.RandomWithPrefix("tf-acc")
-	clusterName := acctest.RandomWithPrefix("tf-acc-cl")
-	kubeVersion := testAccMKSClusterV1GetDefaultKubeVersion(t)
-	maintenanceWindowStart := testAccMKSClusterV1GetMaintenanceWindowStart(12 * time.Hour)
+	projectName := recording.RandomWithPrefix("tf-acc")
+	clusterName := recording.RandomWithPrefix("tf-acc-cl")
+	kubeVersion := testAccMKSClusterV1GetDefaultKubeVersion(t, recording)
+	maintenanceWindowStart := testAccMKSClusterV1GetMaintenanceWindowStart(recording, 12*time.Hour)
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccMKSNodegroupV1Basic(projectName, clusterName, kubeVersion, maintenanceWindowStart),
@@ -0,0 +1,241 @@
+package selectel
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/selectel/go-selvpcclient/v4/selvpcclient/clients"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/crossregionsubnets"
+)
+
+func resourceVPCCrossRegionSubnetV2() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVPCCrossRegionSubnetV2Create,
+		ReadContext:   resourceVPCCrossRegionSubnetV2Read,
+		DeleteContext: resourceVPCCrossRegionSubnetV2Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVPCCrossRegionSubnetV2ImportState,
+		},
+		CustomizeDiff: customizeDiffRegions(clients.ResellServiceType),
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"regions": {
+				Type:     schema.TypeSet,
+				Required: true,
+				ForceNew: true,
+				MinItems: 2,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+			"cidr": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsCIDR,
+			},
+			"vlan_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"servers": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Set:      hashServers,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"subnets": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"network_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"subnet_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"region": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cidr": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"vlan_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"project_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"vtep_ip_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceVPCCrossRegionSubnetV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	projectID := d.Get("project_id").(string)
+	selvpcClient, err := config.GetSelVPCClientWithProjectScope(projectID)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("can't get selvpc client for cross-region subnet object: %w", err))
+	}
+
+	regions := expandVPCV2Regions(d.Get("regions").(*schema.Set))
+	sort.Strings(regions)
+
+	regionOpts := make([]crossregionsubnets.CrossRegionOpt, len(regions))
+	for i, region := range regions {
+		regionOpts[i] = crossregionsubnets.CrossRegionOpt{Region: region}
+	}
+
+	opts := crossregionsubnets.CrossRegionSubnetOpts{
+		CrossRegionSubnets: []crossregionsubnets.CrossRegionSubnetOpt{
+			{
+				Quantity: 1,
+				Regions:  regionOpts,
+				CIDR:     d.Get("cidr").(string),
+			},
+		},
+	}
+
+	log.Print(msgCreate(objectCrossRegionSubnet, opts))
+	crossRegionSubnets, _, err := crossregionsubnets.Create(selvpcClient, projectID, opts)
+	if err != nil {
+		return diagCreatingObject(objectCrossRegionSubnet, err)
+	}
+	if len(crossRegionSubnets) != 1 {
+		return diag.FromErr(errReadFromResponse(objectCrossRegionSubnet))
+	}
+
+	d.SetId(strconv.Itoa(crossRegionSubnets[0].ID))
+
+	return resourceVPCCrossRegionSubnetV2Read(ctx, d, meta)
+}
+
+func resourceVPCCrossRegionSubnetV2Read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	projectID := d.Get("project_id").(string)
+	selvpcClient, err := config.GetSelVPCClientWithProjectScope(projectID)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("can't get selvpc client for cross-region subnet object: %w", err))
+	}
+
+	log.Print(msgGet(objectCrossRegionSubnet, d.Id()))
+	crossRegionSubnet, response, err := crossregionsubnets.Get(selvpcClient, d.Id())
+	if err != nil {
+		if response != nil && response.StatusCode == http.StatusNotFound {
+			d.SetId("")
+			return nil
+		}
+
+		return diagGettingObject(objectCrossRegionSubnet, d.Id(), err)
+	}
+
+	d.Set("cidr", crossRegionSubnet.CIDR)
+	d.Set("vlan_id", crossRegionSubnet.VLANID)
+	d.Set("status", crossRegionSubnet.Status)
+
+	regions := make([]interface{}, len(crossRegionSubnet.Subnets))
+	for i, subnet := range crossRegionSubnet.Subnets {
+		regions[i] = subnet.Region
+	}
+	if err := d.Set("regions", regions); err != nil {
+		return diag.FromErr(errParseCrossRegionSubnetV2Regions(err))
+	}
+
+	// The regional subnets are created in the project of the cross-region subnet.
+	if len(crossRegionSubnet.Subnets) > 0 {
+		if err := d.Set("project_id", crossRegionSubnet.Subnets[0].ProjectID); err != nil {
+			return diag.FromErr(errParseCrossRegionSubnetV2ProjectID(err))
+		}
+	}
+
+	associatedServers := serversMapsFromStructs(crossRegionSubnet.Servers)
+	if err := d.Set("servers", associatedServers); err != nil {
+		log.Print(errSettingComplexAttr("servers", err))
+	}
+
+	associatedSubnets := subnetsMapsFromStructs(crossRegionSubnet.Subnets)
+	if err := d.Set("subnets", associatedSubnets); err != nil {
+		log.Print(errSettingComplexAttr("subnets", err))
+	}
+
+	return nil
+}
+
+func resourceVPCCrossRegionSubnetV2Delete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	projectID := d.Get("project_id").(string)
+	selvpcClient, err := config.GetSelVPCClientWithProjectScope(projectID)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("can't get selvpc client for cross-region subnet object: %w", err))
+	}
+
+	log.Print(msgDelete(objectCrossRegionSubnet, d.Id()))
+	response, err := crossregionsubnets.Delete(selvpcClient, d.Id())
+	if err != nil {
+		if response != nil && response.StatusCode == http.StatusNotFound {
+			d.SetId("")
+			return nil
+		}
+
+		return diagDeletingObject(objectCrossRegionSubnet, d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceVPCCrossRegionSubnetV2ImportState(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	config := meta.(*Config)
+	if config.ProjectID == "" {
+		return nil, fmt.Errorf("INFRA_PROJECT_ID must be set for the resource import")
+	}
+
+	d.Set("project_id", config.ProjectID)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,189 @@
+package selectel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/selectel/go-selvpcclient/v4/selvpcclient/resell/v2/projects"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/crossregionsubnets"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/fakeapi"
+)
+
+func TestAccVPCV2CrossRegionSubnetBasic(t *testing.T) {
+	var (
+		crossRegionSubnet crossregionsubnets.CrossRegionSubnet
+		project           projects.Project
+	)
+	projectName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2CrossRegionSubnetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVPCV2CrossRegionSubnetBasic(projectName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVPCV2ProjectExists("selectel_vpc_project_v2.project_tf_acc_test_1", &project),
+					testAccCheckVPCV2CrossRegionSubnetExists("selectel_vpc_crossregion_subnet_v2.crossregion_subnet_tf_acc_test_1", &crossRegionSubnet),
+					resource.TestCheckResourceAttr("selectel_vpc_crossregion_subnet_v2.crossregion_subnet_tf_acc_test_1", "cidr", "192.168.200.0/24"),
+					resource.TestCheckResourceAttr("selectel_vpc_crossregion_subnet_v2.crossregion_subnet_tf_acc_test_1", "regions.#", "2"),
+					resource.TestCheckResourceAttr("selectel_vpc_crossregion_subnet_v2.crossregion_subnet_tf_acc_test_1", "subnets.#", "2"),
+					resource.TestCheckResourceAttrSet("selectel_vpc_crossregion_subnet_v2.crossregion_subnet_tf_acc_test_1", "vlan_id"),
+					resource.TestCheckResourceAttrSet("selectel_vpc_crossregion_subnet_v2.crossregion_subnet_tf_acc_test_1", "subnets.0.vtep_ip_address"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitVPCV2CrossRegionSubnetBasic(t *testing.T) {
+	var crossRegionSubnet crossregionsubnets.CrossRegionSubnet
+
+	api := newTestFakeAPI(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testUnitCheckFakeAPIEmpty(api, fakeapi.KindResellCrossRegionSubnets),
+		Steps: []resource.TestStep{
+			{
+				Config: api.ProviderConfig() + testUnitVPCV2CrossRegionSubnetBasic(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVPCV2CrossRegionSubnetExists("selectel_vpc_crossregion_subnet_v2.crossregion_subnet_tf_acc_test_1", &crossRegionSubnet),
+					resource.TestCheckResourceAttr("selectel_vpc_crossregion_subnet_v2.crossregion_subnet_tf_acc_test_1", "project_id", fakeapi.ProjectID),
+					resource.TestCheckResourceAttr("selectel_vpc_crossregion_subnet_v2.crossregion_subnet_tf_acc_test_1", "subnets.#", "2"),
+					resource.TestCheckResourceAttr("selectel_vpc_crossregion_subnet_v2.crossregion_subnet_tf_acc_test_1", "status", "DOWN"),
+				),
+			},
+			{
+				ResourceName:      "selectel_vpc_crossregion_subnet_v2.crossregion_subnet_tf_acc_test_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestVPCV2CrossRegionSubnetCRUD(t *testing.T) {
+	ctx := context.Background()
+	api := newTestFakeAPI(t)
+	config := testConfigureProvider(t, map[string]interface{}{
+		"auth_url":    api.AuthURL(),
+		"auth_region": fakeapi.Region,
+		"domain_name": fakeapi.DomainName,
+		"username":    fakeapi.Username,
+		"password":    fakeapi.Password,
+	})
+
+	r := resourceVPCCrossRegionSubnetV2()
+	d := r.TestResourceData()
+	require.NoError(t, d.Set("project_id", fakeapi.ProjectID))
+	require.NoError(t, d.Set("regions", []interface{}{fakeapi.CrossRegion, fakeapi.Region}))
+	require.NoError(t, d.Set("cidr", "192.168.200.0/24"))
+
+	require.False(t, r.CreateContext(ctx, d, config).HasError())
+	require.NotEmpty(t, d.Id())
+	assert.Equal(t, 1, api.Len(fakeapi.KindResellCrossRegionSubnets))
+	assert.Equal(t, "DOWN", d.Get("status"))
+	assert.ElementsMatch(t, []interface{}{fakeapi.Region, fakeapi.CrossRegion}, d.Get("regions").(interface{ List() []interface{} }).List())
+
+	subnets := d.Get("subnets").([]interface{})
+	require.Len(t, subnets, 2)
+	for _, raw := range subnets {
+		subnet := raw.(map[string]interface{})
+		assert.Equal(t, d.Get("vlan_id"), subnet["vlan_id"])
+		assert.Equal(t, "192.168.200.0/24", subnet["cidr"])
+		assert.Equal(t, fakeapi.ProjectID, subnet["project_id"])
+		assert.NotEmpty(t, subnet["vtep_ip_address"])
+	}
+
+	require.False(t, r.DeleteContext(ctx, d, config).HasError())
+	assert.Equal(t, 0, api.Len(fakeapi.KindResellCrossRegionSubnets))
+
+	// The subnet deleted outside of Terraform is removed from the state.
+	require.False(t, r.ReadContext(ctx, d, config).HasError())
+	assert.Empty(t, d.Id())
+}
+
+func testAccCheckVPCV2CrossRegionSubnetDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+	selvpcClient, err := config.GetSelVPCClient()
+	if err != nil {
+		return fmt.Errorf("can't get selvpc client for test cross-region subnet object: %w", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "selectel_vpc_crossregion_subnet_v2" {
+			continue
+		}
+
+		_, _, err := crossregionsubnets.Get(selvpcClient, rs.Primary.ID)
+		if err == nil {
+			return errors.New("cross-region subnet still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckVPCV2CrossRegionSubnetExists(n string, crossRegionSubnet *crossregionsubnets.CrossRegionSubnet) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return errors.New("no ID is set")
+		}
+
+		config := testAccProvider.Meta().(*Config)
+		selvpcClient, err := config.GetSelVPCClient()
+		if err != nil {
+			return fmt.Errorf("can't get selvpc client for test cross-region subnet object: %w", err)
+		}
+
+		foundCrossRegionSubnet, _, err := crossregionsubnets.Get(selvpcClient, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if strconv.Itoa(foundCrossRegionSubnet.ID) != rs.Primary.ID {
+			return errors.New("cross-region subnet not found")
+		}
+
+		*crossRegionSubnet = *foundCrossRegionSubnet
+
+		return nil
+	}
+}
+
+func testAccVPCV2CrossRegionSubnetBasic(projectName string) string {
+	return fmt.Sprintf(`
+resource "selectel_vpc_project_v2" "project_tf_acc_test_1" {
+  name = "%s"
+}
+
+resource "selectel_vpc_crossregion_subnet_v2" "crossregion_subnet_tf_acc_test_1" {
+  project_id = selectel_vpc_project_v2.project_tf_acc_test_1.id
+  regions    = ["ru-1", "ru-3"]
+  cidr       = "192.168.200.0/24"
+}`, projectName)
+}
+
+func testUnitVPCV2CrossRegionSubnetBasic() string {
+	return fmt.Sprintf(`
+resource "selectel_vpc_crossregion_subnet_v2" "crossregion_subnet_tf_acc_test_1" {
+  regions = [%q, %q]
+  cidr    = "192.168.200.0/24"
+}`, fakeapi.Region, fakeapi.CrossRegion)
+}
@@ -23,12 +23,14 @@ func resourceVPCFloatingIPV2() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"project_id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"region": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"port_id": {
@@ -98,7 +100,7 @@ func resourceVPCFloatingIPV2Create(ctx context.Context, d *schema.ResourceData,
 	log.Print(msgCreate(objectFloatingIP, opts))
 	floatingIPs, _, err := floatingips.Create(selvpcClient, projectID, opts)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectFloatingIP, err))
+		return diagCreatingObject(objectFloatingIP, err)
 	}
 	if len(floatingIPs) != 1 {
 		return diag.FromErr(errReadFromResponse(objectFloatingIP))
@@ -127,7 +129,7 @@ func resourceVPCFloatingIPV2Read(_ context.Context, d *schema.ResourceData, meta
 			}
 		}
 
-		return diag.FromErr(errGettingObject(objectFloatingIP, d.Id(), err))
+		return diagGettingObject(objectFloatingIP, d.Id(), err)
 	}
 
 	d.Set("fixed_ip_address", floatingIP.FixedIPAddress)
@@ -163,7 +165,7 @@ func resourceVPCFloatingIPV2Delete(_ context.Context, d *schema.ResourceData, me
 			}
 		}
 
-		return diag.FromErr(errDeletingObject(objectFloatingIP, d.Id(), err))
+		return diagDeletingObject(objectFloatingIP, d.Id(), err)
 	}
 
 	return nil
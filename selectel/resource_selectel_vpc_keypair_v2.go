@@ -63,7 +63,7 @@ func resourceVPCKeypairV2Create(ctx context.Context, d *schema.ResourceData, met
 	log.Print(msgCreate(objectKeypair, opts))
 	newKeypairs, _, err := keypairs.Create(selvpcClient, opts)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectKeypair, err))
+		return diagCreatingObject(objectKeypair, err)
 	}
 
 	// There can be several keypairs if user specified more than one region.
@@ -136,7 +136,7 @@ func resourceVPCKeypairV2Delete(_ context.Context, d *schema.ResourceData, meta
 			}
 		}
 
-		return diag.FromErr(errDeletingObject(objectKeypair, d.Id(), err))
+		return diagDeletingObject(objectKeypair, d.Id(), err)
 	}
 
 	return nil
@@ -24,9 +24,9 @@ func TestAccVPCV2KeypairBasic(t *testing.T) {
 	userPassword := acctest.RandString(8)
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2KeypairDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2KeypairDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccVPCV2KeypairBasic(userName, userPassword, keypairName, publicKey),
@@ -24,12 +24,14 @@ func resourceVPCLicenseV2() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"project_id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"region": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"type": {
@@ -106,7 +108,7 @@ func resourceVPCLicenseV2Create(ctx context.Context, d *schema.ResourceData, met
 	log.Print(msgCreate(objectLicense, opts))
 	newLicenses, _, err := licenses.Create(selvpcClient, projectID, opts)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectLicense, err))
+		return diagCreatingObject(objectLicense, err)
 	}
 
 	if len(newLicenses) != 1 {
@@ -136,7 +138,7 @@ func resourceVPCLicenseV2Read(_ context.Context, d *schema.ResourceData, meta in
 			}
 		}
 
-		return diag.FromErr(errGettingObject(objectLicense, d.Id(), err))
+		return diagGettingObject(objectLicense, d.Id(), err)
 	}
 
 	d.Set("project_id", license.ProjectID)
@@ -172,7 +174,7 @@ func resourceVPCLicenseV2Delete(_ context.Context, d *schema.ResourceData, meta
 			}
 		}
 
-		return diag.FromErr(errDeletingObject(objectLicense, d.Id(), err))
+		return diagDeletingObject(objectLicense, d.Id(), err)
 	}
 
 	return nil
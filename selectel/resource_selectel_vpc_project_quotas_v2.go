@@ -0,0 +1,258 @@
+package selectel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/selectel/go-selvpcclient/v4/selvpcclient/quotamanager/quotas"
+)
+
+func resourceVPCProjectQuotasV2() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVPCProjectQuotasV2Create,
+		ReadContext:   resourceVPCProjectQuotasV2Read,
+		UpdateContext: resourceVPCProjectQuotasV2Update,
+		DeleteContext: resourceVPCProjectQuotasV2Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVPCProjectQuotasV2ImportState,
+		},
+		CustomizeDiff: customizeDiffVPCProjectQuotasV2Usage,
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"authoritative": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"quotas": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"resource_name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"resource_quotas": {
+							Type:     schema.TypeSet,
+							Required: true,
+							Set:      hashProjectQuotasV2ResourceQuotas,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"zone": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+									"value": {
+										Type:         schema.TypeInt,
+										Required:     true,
+										ValidateFunc: validation.IntAtLeast(0),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceVPCProjectQuotasV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	projectID := d.Get("project_id").(string)
+	region := d.Get("region").(string)
+
+	diags := resourceVPCProjectQuotasV2Apply(d, meta, projectID, region)
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", projectID, region))
+
+	return append(diags, resourceVPCProjectQuotasV2Read(ctx, d, meta)...)
+}
+
+func resourceVPCProjectQuotasV2Read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	selvpcClient, err := config.GetSelVPCClient()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("can't get selvpc client for project quotas object: %w", err))
+	}
+
+	projectID := d.Get("project_id").(string)
+	region := d.Get("region").(string)
+
+	log.Print(msgGet(objectProjectQuotas, d.Id()))
+	projectQuotas, response, err := quotas.GetProjectQuotas(selvpcClient, projectID, region)
+	if err != nil {
+		if response != nil && response.StatusCode == http.StatusNotFound {
+			d.SetId("")
+			return nil
+		}
+
+		return diagGettingObject(objectProjectQuotas, d.Id(), err)
+	}
+
+	// Only the quotas listed in the resource are read back, unless the resource is
+	// authoritative: then every non-zero quota of the region is read, so the quotas
+	// that are not listed show up in the plan and are reset.
+	managed := projectQuotasV2Keys(d.Get("quotas").(*schema.Set))
+	authoritative := d.Get("authoritative").(bool)
+	quotaSet := resourceVPCProjectQuotasV2ToSet(projectQuotas, func(key projectQuotaKey, value int) bool {
+		_, ok := managed[key]
+
+		return ok || (authoritative && value != 0)
+	})
+	if err := d.Set("quotas", quotaSet); err != nil {
+		return diag.FromErr(errParseProjectV2Quotas(err))
+	}
+
+	return nil
+}
+
+func resourceVPCProjectQuotasV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if d.HasChanges("quotas", "authoritative") {
+		projectID := d.Get("project_id").(string)
+		region := d.Get("region").(string)
+
+		diags = resourceVPCProjectQuotasV2Apply(d, meta, projectID, region)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	return append(diags, resourceVPCProjectQuotasV2Read(ctx, d, meta)...)
+}
+
+func resourceVPCProjectQuotasV2Delete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	// Quotas can't be deleted, they are left as is and are no longer managed.
+	log.Print(msgDelete(objectProjectQuotas, d.Id()))
+
+	return nil
+}
+
+func resourceVPCProjectQuotasV2ImportState(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	config := meta.(*Config)
+	selvpcClient, err := config.GetSelVPCClient()
+	if err != nil {
+		return nil, fmt.Errorf("can't get selvpc client for project quotas object: %w", err)
+	}
+
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, errors.New("id must include two parts: project_id/region")
+	}
+	projectID, region := parts[0], parts[1]
+
+	log.Print(msgImport(objectProjectQuotas, d.Id()))
+	projectQuotas, _, err := quotas.GetProjectQuotas(selvpcClient, projectID, region)
+	if err != nil {
+		return nil, errGettingObject(objectProjectQuotas, d.Id(), err)
+	}
+
+	// All non-zero quotas of the region are imported, the ones that shouldn't be
+	// managed can be removed from the configuration afterwards.
+	quotaSet := resourceVPCProjectQuotasV2ToSet(projectQuotas, func(_ projectQuotaKey, value int) bool {
+		return value != 0
+	})
+
+	d.Set("project_id", projectID)
+	d.Set("region", region)
+	d.Set("authoritative", false)
+	if err := d.Set("quotas", quotaSet); err != nil {
+		return nil, errParseProjectV2Quotas(err)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceVPCProjectQuotasV2Apply updates the quotas listed in the resource. In the
+// authoritative mode the other quotas of the region are reset to zero.
+func resourceVPCProjectQuotasV2Apply(d *schema.ResourceData, meta interface{}, projectID, region string) diag.Diagnostics {
+	config := meta.(*Config)
+	selvpcClient, err := config.GetSelVPCClient()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("can't get selvpc client for project quotas object: %w", err))
+	}
+
+	quotaSet := d.Get("quotas").(*schema.Set)
+	opts := resourceVPCProjectQuotasV2OptsFromSet(quotaSet)
+
+	if d.Get("authoritative").(bool) {
+		projectQuotas, _, err := quotas.GetProjectQuotas(selvpcClient, projectID, region)
+		if err != nil {
+			return diagGettingObject(objectProjectQuotas, projectID, err)
+		}
+		opts.QuotasOpts = append(opts.QuotasOpts,
+			resourceVPCProjectQuotasV2ResetOpts(projectQuotas, projectQuotasV2Keys(quotaSet))...)
+	}
+
+	if len(opts.QuotasOpts) == 0 {
+		return nil
+	}
+
+	diags := diagProjectQuotasBelowUsage(config, selvpcClient, projectID, map[string]quotas.UpdateProjectQuotasOpts{region: opts})
+
+	log.Print(msgUpdate(objectProjectQuotas, projectID, opts))
+	if _, _, err := quotas.UpdateProjectQuotas(selvpcClient, projectID, region, opts); err != nil {
+		return append(diags, diagUpdatingObject(objectProjectQuotas, projectID, err)...)
+	}
+
+	return diags
+}
+
+// customizeDiffVPCProjectQuotasV2Usage checks that the quotas, and the quotas reset
+// in the authoritative mode, are not lower than the current usage.
+func customizeDiffVPCProjectQuotasV2Usage(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && !d.HasChanges("quotas", "authoritative") {
+		return nil
+	}
+	if !d.NewValueKnown("quotas") || !d.NewValueKnown("project_id") || !d.NewValueKnown("region") {
+		return nil
+	}
+	projectID := d.Get("project_id").(string)
+	region := d.Get("region").(string)
+	if projectID == "" || region == "" {
+		return nil
+	}
+
+	quotaSet := d.Get("quotas").(*schema.Set)
+	projectQuotasOpts := map[string]quotas.UpdateProjectQuotasOpts{
+		region: resourceVPCProjectQuotasV2OptsFromSet(quotaSet),
+	}
+	var reset func(projectQuotas []*quotas.Quota) []quotas.QuotaOpts
+	if d.Get("authoritative").(bool) {
+		keys := projectQuotasV2Keys(quotaSet)
+		reset = func(projectQuotas []*quotas.Quota) []quotas.QuotaOpts {
+			return resourceVPCProjectQuotasV2ResetOpts(projectQuotas, keys)
+		}
+	}
+
+	config := meta.(*Config)
+	selvpcClient, err := config.GetSelVPCClient()
+	if err != nil {
+		return fmt.Errorf("can't get selvpc client for project quotas object: %w", err)
+	}
+
+	return customizeDiffProjectQuotasUsage(config, selvpcClient, projectID, projectQuotasOpts, reset)
+}
@@ -0,0 +1,340 @@
+package selectel
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/selectel/go-selvpcclient/v4/selvpcclient/quotamanager/quotas"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/fakeapi"
+)
+
+// clusterQuotaTestValue is the default quota of MKS clusters in the fake API.
+const clusterQuotaTestValue = 5
+
+func TestAccVPCV2ProjectQuotasBasic(t *testing.T) {
+	projectName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVPCV2ProjectQuotasBasic(projectName, 4),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("selectel_vpc_project_quotas_v2.quotas_tf_acc_test_1", "region", "ru-3"),
+					resource.TestCheckResourceAttr("selectel_vpc_project_quotas_v2.quotas_tf_acc_test_1", "quotas.#", "1"),
+					resource.TestCheckResourceAttr("selectel_vpc_project_quotas_v2.quotas_tf_acc_test_1", "authoritative", "false"),
+				),
+			},
+			{
+				Config: testAccVPCV2ProjectQuotasBasic(projectName, 8),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("selectel_vpc_project_quotas_v2.quotas_tf_acc_test_1", "quotas.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "selectel_vpc_project_quotas_v2.quotas_tf_acc_test_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// All non-zero quotas of the region are imported.
+				ImportStateVerifyIgnore: []string{"quotas"},
+			},
+		},
+	})
+}
+
+func TestUnitVPCV2ProjectQuotasBasic(t *testing.T) {
+	api := newTestFakeAPI(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: api.ProviderConfig() + testUnitVPCV2ProjectQuotasBasic(4),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("selectel_vpc_project_quotas_v2.quotas_tf_acc_test_1", "id", fakeapi.ProjectID+"/"+fakeapi.Region),
+					resource.TestCheckResourceAttr("selectel_vpc_project_quotas_v2.quotas_tf_acc_test_1", "quotas.#", "1"),
+				),
+			},
+			{
+				Config: api.ProviderConfig() + testUnitVPCV2ProjectQuotasBasic(8),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("selectel_vpc_project_quotas_v2.quotas_tf_acc_test_1", "quotas.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testVPCProjectQuotasV2Config(t *testing.T, api *fakeapi.Server) *Config {
+	t.Helper()
+
+	return testConfigureProvider(t, map[string]interface{}{
+		"auth_url":    api.AuthURL(),
+		"auth_region": fakeapi.Region,
+		"domain_name": fakeapi.DomainName,
+		"username":    fakeapi.Username,
+		"password":    fakeapi.Password,
+	})
+}
+
+func testVPCProjectQuotasV2ResourceData(t *testing.T, authoritative bool, quotas []interface{}) *schema.ResourceData {
+	t.Helper()
+
+	d := resourceVPCProjectQuotasV2().TestResourceData()
+	require.NoError(t, d.Set("project_id", fakeapi.ProjectID))
+	require.NoError(t, d.Set("region", fakeapi.Region))
+	require.NoError(t, d.Set("authoritative", authoritative))
+	require.NoError(t, d.Set("quotas", quotas))
+
+	return d
+}
+
+func testVPCProjectQuotasV2Quota(name, zone string, value int) map[string]interface{} {
+	return map[string]interface{}{
+		"resource_name": name,
+		"resource_quotas": []interface{}{
+			map[string]interface{}{"zone": zone, "value": value},
+		},
+	}
+}
+
+// testVPCProjectQuotasV2Values returns the quota values of the state by the resource name and zone.
+func testVPCProjectQuotasV2Values(d *schema.ResourceData) map[string]int {
+	values := map[string]int{}
+	for _, quotaRaw := range d.Get("quotas").(*schema.Set).List() {
+		quota := quotaRaw.(map[string]interface{})
+		for _, resourceQuotaRaw := range quota["resource_quotas"].(*schema.Set).List() {
+			resourceQuota := resourceQuotaRaw.(map[string]interface{})
+			values[fmt.Sprintf("%s/%s", quota["resource_name"], resourceQuota["zone"])] = resourceQuota["value"].(int)
+		}
+	}
+
+	return values
+}
+
+func TestVPCProjectQuotasV2NonAuthoritative(t *testing.T) {
+	ctx := context.Background()
+	api := newTestFakeAPI(t)
+	config := testVPCProjectQuotasV2Config(t, api)
+	r := resourceVPCProjectQuotasV2()
+
+	other := testVPCProjectQuotasV2ResourceData(t, false, []interface{}{
+		testVPCProjectQuotasV2Quota("compute_ram", "ru-1b", 8192),
+	})
+	require.False(t, r.CreateContext(ctx, other, config).HasError())
+
+	d := testVPCProjectQuotasV2ResourceData(t, false, []interface{}{
+		testVPCProjectQuotasV2Quota("compute_cores", "ru-1a", 4),
+	})
+	require.False(t, r.CreateContext(ctx, d, config).HasError())
+	assert.Equal(t, fakeapi.ProjectID+"/"+fakeapi.Region, d.Id())
+	assert.Equal(t, map[string]int{"compute_cores/ru-1a": 4}, testVPCProjectQuotasV2Values(d))
+
+	// The quotas of the other resource are left as is.
+	require.False(t, r.ReadContext(ctx, other, config).HasError())
+	assert.Equal(t, map[string]int{"compute_ram/ru-1b": 8192}, testVPCProjectQuotasV2Values(other))
+
+	// Deleting the resource doesn't change the quotas.
+	require.False(t, r.DeleteContext(ctx, d, config).HasError())
+	require.False(t, r.ReadContext(ctx, d, config).HasError())
+	assert.Equal(t, map[string]int{"compute_cores/ru-1a": 4}, testVPCProjectQuotasV2Values(d))
+}
+
+func TestVPCProjectQuotasV2Authoritative(t *testing.T) {
+	ctx := context.Background()
+	api := newTestFakeAPI(t)
+	config := testVPCProjectQuotasV2Config(t, api)
+	r := resourceVPCProjectQuotasV2()
+
+	other := testVPCProjectQuotasV2ResourceData(t, false, []interface{}{
+		testVPCProjectQuotasV2Quota("compute_ram", "ru-1b", 8192),
+	})
+	require.False(t, r.CreateContext(ctx, other, config).HasError())
+
+	// The unlisted quotas show up in the state of the authoritative resource.
+	d := testVPCProjectQuotasV2ResourceData(t, true, []interface{}{
+		testVPCProjectQuotasV2Quota("compute_cores", "ru-1a", 4),
+	})
+	d.SetId(fakeapi.ProjectID + "/" + fakeapi.Region)
+	require.False(t, r.ReadContext(ctx, d, config).HasError())
+	assert.Equal(t, map[string]int{
+		"compute_cores/ru-1a":   0,
+		"compute_ram/ru-1b":     8192,
+		"mks_cluster_regional/": clusterQuotaTestValue,
+		"mks_cluster_zonal/":    clusterQuotaTestValue,
+	}, testVPCProjectQuotasV2Values(d))
+
+	// The unlisted quotas are reset to zero.
+	d = testVPCProjectQuotasV2ResourceData(t, true, []interface{}{
+		testVPCProjectQuotasV2Quota("compute_cores", "ru-1a", 4),
+	})
+	require.False(t, r.CreateContext(ctx, d, config).HasError())
+	assert.Equal(t, map[string]int{"compute_cores/ru-1a": 4}, testVPCProjectQuotasV2Values(d))
+
+	require.False(t, r.ReadContext(ctx, other, config).HasError())
+	assert.Equal(t, map[string]int{"compute_ram/ru-1b": 0}, testVPCProjectQuotasV2Values(other))
+}
+
+func TestVPCProjectQuotasV2ImportState(t *testing.T) {
+	ctx := context.Background()
+	api := newTestFakeAPI(t)
+	config := testVPCProjectQuotasV2Config(t, api)
+	r := resourceVPCProjectQuotasV2()
+
+	d := testVPCProjectQuotasV2ResourceData(t, false, []interface{}{
+		testVPCProjectQuotasV2Quota("compute_cores", "ru-1a", 4),
+	})
+	require.False(t, r.CreateContext(ctx, d, config).HasError())
+
+	imported := r.TestResourceData()
+	imported.SetId(fakeapi.ProjectID + "/" + fakeapi.Region)
+	result, err := r.Importer.StateContext(ctx, imported, config)
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, fakeapi.ProjectID, result[0].Get("project_id"))
+	assert.Equal(t, fakeapi.Region, result[0].Get("region"))
+	assert.Equal(t, map[string]int{
+		"compute_cores/ru-1a":   4,
+		"mks_cluster_regional/": clusterQuotaTestValue,
+		"mks_cluster_zonal/":    clusterQuotaTestValue,
+	}, testVPCProjectQuotasV2Values(result[0]))
+
+	for _, id := range []string{fakeapi.ProjectID, fakeapi.ProjectID + "/", "a/b/c"} {
+		imported.SetId(id)
+		_, err := r.Importer.StateContext(ctx, imported, config)
+		assert.EqualError(t, err, "id must include two parts: project_id/region", id)
+	}
+}
+
+func TestCustomizeDiffVPCProjectQuotasV2Usage(t *testing.T) {
+	api := newTestFakeAPI(t)
+	api.SetQuotaUsage(fakeapi.ProjectID, fakeapi.Region, "compute_cores", "ru-1a", 6)
+	api.SetQuotaUsage(fakeapi.ProjectID, fakeapi.Region, "compute_ram", "ru-1b", 1024)
+	config := testVPCProjectQuotasV2Config(t, api)
+	r := resourceVPCProjectQuotasV2()
+
+	values := func(value int64, authoritative bool) map[string]cty.Value {
+		return map[string]cty.Value{
+			"project_id":    cty.StringVal(fakeapi.ProjectID),
+			"region":        cty.StringVal(fakeapi.Region),
+			"authoritative": cty.BoolVal(authoritative),
+			"quotas": cty.SetVal([]cty.Value{
+				cty.ObjectVal(map[string]cty.Value{
+					"resource_name": cty.StringVal("compute_cores"),
+					"resource_quotas": cty.SetVal([]cty.Value{
+						cty.ObjectVal(map[string]cty.Value{
+							"zone":  cty.StringVal("ru-1a"),
+							"value": cty.NumberIntVal(value),
+						}),
+					}),
+				}),
+			}),
+		}
+	}
+
+	_, err := testResourceDiffValues(t, r, nil, values(4, false), config)
+	assert.EqualError(t, err, "quotas of compute_cores are lower than the current usage of the project: "+
+		"compute_cores in ru-1a: value 4, used 6")
+
+	_, err = testResourceDiffValues(t, r, nil, values(8, false), config)
+	assert.NoError(t, err)
+
+	// The authoritative resource resets the quota of compute_ram which is in use.
+	other := testVPCProjectQuotasV2ResourceData(t, false, []interface{}{
+		testVPCProjectQuotasV2Quota("compute_ram", "ru-1b", 2048),
+	})
+	require.False(t, r.CreateContext(context.Background(), other, config).HasError())
+	_, err = testResourceDiffValues(t, r, nil, values(8, true), config)
+	assert.EqualError(t, err, "quotas of compute_ram are lower than the current usage of the project: "+
+		"compute_ram in ru-1b: value 0, used 1024")
+
+	config.allowQuotasBelowUsage = true
+	_, err = testResourceDiffValues(t, r, nil, values(4, false), config)
+	assert.NoError(t, err)
+
+	// The warning is reported when the quotas are applied.
+	d := testVPCProjectQuotasV2ResourceData(t, false, []interface{}{
+		testVPCProjectQuotasV2Quota("compute_cores", "ru-1a", 4),
+	})
+	diags := r.CreateContext(context.Background(), d, config)
+	require.NotEmpty(t, diags)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, "Quotas are lower than the current usage", diags[0].Summary)
+}
+
+func TestResourceVPCProjectQuotasV2ResetOpts(t *testing.T) {
+	projectQuotas := []*quotas.Quota{
+		{
+			Name: "compute_cores",
+			ResourceQuotasEntities: []quotas.ResourceQuotaEntity{
+				{Zone: "ru-3a", Value: 4},
+				{Zone: "ru-3b", Value: 0},
+			},
+		},
+		{
+			Name: "compute_ram",
+			ResourceQuotasEntities: []quotas.ResourceQuotaEntity{
+				{Zone: "ru-3a", Value: 1024},
+				{Zone: "ru-3b", Value: 2048},
+			},
+		},
+	}
+	keys := map[projectQuotaKey]struct{}{
+		{resourceName: "compute_cores", zone: "ru-3a"}: {},
+		{resourceName: "compute_ram", zone: "ru-3a"}:   {},
+	}
+
+	zone := "ru-3b"
+	value := 0
+	expected := []quotas.QuotaOpts{
+		{
+			Name:               "compute_ram",
+			ResourceQuotasOpts: []quotas.ResourceQuotaOpts{{Zone: &zone, Value: &value}},
+		},
+	}
+	assert.Equal(t, expected, resourceVPCProjectQuotasV2ResetOpts(projectQuotas, keys))
+}
+
+func testAccVPCV2ProjectQuotasBasic(projectName string, cores int) string {
+	return fmt.Sprintf(`
+resource "selectel_vpc_project_v2" "project_tf_acc_test_1" {
+  name = "%s"
+}
+
+resource "selectel_vpc_project_quotas_v2" "quotas_tf_acc_test_1" {
+  project_id = selectel_vpc_project_v2.project_tf_acc_test_1.id
+  region     = "ru-3"
+
+  quotas {
+    resource_name = "compute_cores"
+    resource_quotas {
+      zone  = "ru-3a"
+      value = %d
+    }
+  }
+}`, projectName, cores)
+}
+
+func testUnitVPCV2ProjectQuotasBasic(cores int) string {
+	return fmt.Sprintf(`
+resource "selectel_vpc_project_quotas_v2" "quotas_tf_acc_test_1" {
+  quotas {
+    resource_name = "compute_cores"
+    resource_quotas {
+      zone  = "%sa"
+      value = %d
+    }
+  }
+}`, fakeapi.Region, cores)
+}
@@ -0,0 +1,206 @@
+package selectel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/selectel/iam-go"
+	"github.com/selectel/iam-go/iamerrors"
+	"github.com/selectel/iam-go/service/roles"
+)
+
+const (
+	projectRoleUserTypeUser        = "user"
+	projectRoleUserTypeServiceUser = "service_user"
+)
+
+func resourceVPCProjectRoleV2() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVPCProjectRoleV2Create,
+		ReadContext:   resourceVPCProjectRoleV2Read,
+		DeleteContext: resourceVPCProjectRoleV2Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVPCProjectRoleV2ImportState,
+		},
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"user_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"user_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  projectRoleUserTypeUser,
+				ValidateFunc: validation.StringInSlice([]string{
+					projectRoleUserTypeUser,
+					projectRoleUserTypeServiceUser,
+				}, false),
+			},
+			"role_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      string(roles.Member),
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+	}
+}
+
+func resourceVPCProjectRoleV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	iamClient, diagErr := getIAMClient(meta)
+	if diagErr != nil {
+		return diagErr
+	}
+
+	role := projectRoleV2FromResourceData(d)
+	userType := d.Get("user_type").(string)
+	userID := d.Get("user_id").(string)
+
+	// Only the role of the resource is assigned, the other roles of the user are left as is.
+	log.Print(msgCreate(objectRole, role))
+	if err := assignIAMUserRoles(ctx, iamClient, userType, userID, []roles.Role{role}); err != nil {
+		return diagCreatingObject(objectRole, err)
+	}
+
+	d.SetId(strings.Join([]string{role.ProjectID, userID, string(role.RoleName)}, "/"))
+
+	return resourceVPCProjectRoleV2Read(ctx, d, meta)
+}
+
+func resourceVPCProjectRoleV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	iamClient, diagErr := getIAMClient(meta)
+	if diagErr != nil {
+		return diagErr
+	}
+
+	role := projectRoleV2FromResourceData(d)
+
+	log.Print(msgGet(objectRole, d.Id()))
+	userRoles, err := getIAMUserRoles(ctx, iamClient, d.Get("user_type").(string), d.Get("user_id").(string))
+	if err != nil {
+		if errors.Is(err, iamerrors.ErrUserNotFound) {
+			d.SetId("")
+			return nil
+		}
+
+		return diag.FromErr(errSearchingProjectRole(role.ProjectID, err))
+	}
+
+	if !slices.Contains(userRoles, role) {
+		log.Printf("[DEBUG] %s '%s' is not assigned anymore", objectRole, d.Id())
+		d.SetId("")
+	}
+
+	return nil
+}
+
+func resourceVPCProjectRoleV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	iamClient, diagErr := getIAMClient(meta)
+	if diagErr != nil {
+		return diagErr
+	}
+
+	role := projectRoleV2FromResourceData(d)
+	userType := d.Get("user_type").(string)
+	userID := d.Get("user_id").(string)
+
+	log.Print(msgDelete(objectRole, d.Id()))
+	err := unassignIAMUserRoles(ctx, iamClient, userType, userID, []roles.Role{role})
+	if err != nil && !errors.Is(err, iamerrors.ErrUserNotFound) {
+		return diagDeletingObject(objectRole, d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceVPCProjectRoleV2ImportState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, errors.New("id must include three parts: project_id/user_id/role_name")
+	}
+	projectID, userID, roleName := parts[0], parts[1], parts[2]
+
+	iamClient, diagErr := getIAMClient(meta)
+	if diagErr != nil {
+		return nil, fmt.Errorf("can't get iam client for role object: %s", diagErr[0].Summary)
+	}
+
+	// The ID doesn't include the type of the user, so it is found by the lookup.
+	log.Print(msgImport(objectRole, d.Id()))
+	userType := projectRoleUserTypeUser
+	if _, err := iamClient.Users.Get(ctx, userID); err != nil {
+		if !errors.Is(err, iamerrors.ErrUserNotFound) {
+			return nil, errGettingObject(objectUser, userID, err)
+		}
+		if _, err := iamClient.ServiceUsers.Get(ctx, userID); err != nil {
+			return nil, errGettingObject(objectServiceUser, userID, err)
+		}
+		userType = projectRoleUserTypeServiceUser
+	}
+
+	d.Set("project_id", projectID)
+	d.Set("user_id", userID)
+	d.Set("user_type", userType)
+	d.Set("role_name", roleName)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func projectRoleV2FromResourceData(d *schema.ResourceData) roles.Role {
+	return roles.Role{
+		ProjectID: d.Get("project_id").(string),
+		RoleName:  roles.Name(d.Get("role_name").(string)),
+		Scope:     roles.Project,
+	}
+}
+
+func getIAMUserRoles(ctx context.Context, iamClient *iam.Client, userType, userID string) ([]roles.Role, error) {
+	if userType == projectRoleUserTypeServiceUser {
+		serviceUser, err := iamClient.ServiceUsers.Get(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		return serviceUser.Roles, nil
+	}
+
+	user, err := iamClient.Users.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return user.Roles, nil
+}
+
+func assignIAMUserRoles(ctx context.Context, iamClient *iam.Client, userType, userID string, userRoles []roles.Role) error {
+	if userType == projectRoleUserTypeServiceUser {
+		return iamClient.ServiceUsers.AssignRoles(ctx, userID, userRoles)
+	}
+
+	return iamClient.Users.AssignRoles(ctx, userID, userRoles)
+}
+
+func unassignIAMUserRoles(ctx context.Context, iamClient *iam.Client, userType, userID string, userRoles []roles.Role) error {
+	if userType == projectRoleUserTypeServiceUser {
+		return iamClient.ServiceUsers.UnassignRoles(ctx, userID, userRoles)
+	}
+
+	return iamClient.Users.UnassignRoles(ctx, userID, userRoles)
+}
@@ -0,0 +1,248 @@
+package selectel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/selectel/iam-go"
+	"github.com/selectel/iam-go/service/roles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/fakeapi"
+)
+
+func TestAccVPCV2ProjectRoleBasic(t *testing.T) {
+	projectName := acctest.RandomWithPrefix("tf-acc")
+	serviceUserName := acctest.RandomWithPrefix("tf-acc")
+	serviceUserPassword := "A" + acctest.RandString(8) + "1"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVPCV2ProjectRoleBasic(projectName, serviceUserName, serviceUserPassword),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVPCV2ProjectRoleExists("selectel_vpc_project_role_v2.role_tf_acc_test_1"),
+					resource.TestCheckResourceAttr("selectel_vpc_project_role_v2.role_tf_acc_test_1", "user_type", "service_user"),
+					resource.TestCheckResourceAttr("selectel_vpc_project_role_v2.role_tf_acc_test_1", "role_name", "reader"),
+				),
+			},
+			{
+				ResourceName:      "selectel_vpc_project_role_v2.role_tf_acc_test_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestUnitVPCV2ProjectRoleBasic(t *testing.T) {
+	api := newTestFakeAPI(t)
+	userID := api.AddIAMUser(fakeapi.KindIAMUsers)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testUnitPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			if userRoles := api.IAMUserRoles(fakeapi.KindIAMUsers, userID); len(userRoles) != 0 {
+				return fmt.Errorf("roles are still assigned: %v", userRoles)
+			}
+
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: api.ProviderConfig() + testUnitVPCV2ProjectRoleBasic(userID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("selectel_vpc_project_role_v2.role_tf_acc_test_1", "id", fakeapi.ProjectID+"/"+userID+"/member"),
+					resource.TestCheckResourceAttr("selectel_vpc_project_role_v2.role_tf_acc_test_1", "user_type", "user"),
+				),
+			},
+			{
+				ResourceName:      "selectel_vpc_project_role_v2.role_tf_acc_test_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestVPCV2ProjectRoleCRUD(t *testing.T) {
+	ctx := context.Background()
+	api := newTestFakeAPI(t)
+	config := testVPCProjectQuotasV2Config(t, api)
+
+	for _, kind := range []string{fakeapi.KindIAMUsers, fakeapi.KindIAMServiceUsers} {
+		t.Run(kind, func(t *testing.T) {
+			userType := projectRoleUserTypeUser
+			if kind == fakeapi.KindIAMServiceUsers {
+				userType = projectRoleUserTypeServiceUser
+			}
+			userID := api.AddIAMUser(kind)
+			accountRole := "account//" + string(roles.Reader)
+			require.NoError(t, assignIAMUserRoles(ctx, testIAMClient(t, config), userType, userID, []roles.Role{
+				{RoleName: roles.Reader, Scope: roles.Account},
+			}))
+
+			r := resourceVPCProjectRoleV2()
+			d := r.TestResourceData()
+			require.NoError(t, d.Set("project_id", fakeapi.ProjectID))
+			require.NoError(t, d.Set("user_id", userID))
+			require.NoError(t, d.Set("user_type", userType))
+			require.NoError(t, d.Set("role_name", string(roles.Reader)))
+
+			require.False(t, r.CreateContext(ctx, d, config).HasError())
+			assert.Equal(t, fakeapi.ProjectID+"/"+userID+"/reader", d.Id())
+			// The other roles of the user are left as is.
+			assert.ElementsMatch(t, []string{accountRole, "project/" + fakeapi.ProjectID + "/reader"},
+				api.IAMUserRoles(kind, userID))
+
+			require.False(t, r.DeleteContext(ctx, d, config).HasError())
+			assert.Equal(t, []string{accountRole}, api.IAMUserRoles(kind, userID))
+
+			// The role unassigned outside of Terraform is removed from the state.
+			require.False(t, r.ReadContext(ctx, d, config).HasError())
+			assert.Empty(t, d.Id())
+		})
+	}
+}
+
+func TestVPCV2ProjectRoleImportState(t *testing.T) {
+	ctx := context.Background()
+	api := newTestFakeAPI(t)
+	config := testVPCProjectQuotasV2Config(t, api)
+	serviceUserID := api.AddIAMUser(fakeapi.KindIAMServiceUsers)
+
+	r := resourceVPCProjectRoleV2()
+	d := r.TestResourceData()
+	d.SetId(fakeapi.ProjectID + "/" + serviceUserID + "/member")
+
+	imported, err := r.Importer.StateContext(ctx, d, config)
+	require.NoError(t, err)
+	require.Len(t, imported, 1)
+	assert.Equal(t, fakeapi.ProjectID, imported[0].Get("project_id"))
+	assert.Equal(t, serviceUserID, imported[0].Get("user_id"))
+	assert.Equal(t, projectRoleUserTypeServiceUser, imported[0].Get("user_type"))
+	assert.Equal(t, "member", imported[0].Get("role_name"))
+
+	d = r.TestResourceData()
+	d.SetId(fakeapi.ProjectID + "/unknown/member")
+	_, err = r.Importer.StateContext(ctx, d, config)
+	assert.Error(t, err)
+
+	d = r.TestResourceData()
+	d.SetId(fakeapi.ProjectID + "/" + serviceUserID)
+	_, err = r.Importer.StateContext(ctx, d, config)
+	assert.EqualError(t, err, "id must include three parts: project_id/user_id/role_name")
+}
+
+func testIAMClient(t *testing.T, config *Config) *iam.Client {
+	t.Helper()
+
+	iamClient, diagErr := getIAMClient(config)
+	require.False(t, diagErr.HasError(), "unexpected diagnostics: %+v", diagErr)
+
+	return iamClient
+}
+
+func testAccCheckVPCV2ProjectRoleDestroy(s *terraform.State) error {
+	iamClient, diagErr := getIAMClient(testAccProvider.Meta())
+	if diagErr != nil {
+		return fmt.Errorf("can't get iamclient for test project role object")
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "selectel_vpc_project_role_v2" {
+			continue
+		}
+
+		userRoles, err := getIAMUserRoles(context.Background(), iamClient, rs.Primary.Attributes["user_type"], rs.Primary.Attributes["user_id"])
+		if err != nil {
+			continue
+		}
+		if slices.Contains(userRoles, testAccVPCV2ProjectRole(rs)) {
+			return errors.New("project role still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckVPCV2ProjectRoleExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return errors.New("no ID is set")
+		}
+
+		iamClient, diagErr := getIAMClient(testAccProvider.Meta())
+		if diagErr != nil {
+			return fmt.Errorf("can't get iamclient for test project role object")
+		}
+
+		userRoles, err := getIAMUserRoles(context.Background(), iamClient, rs.Primary.Attributes["user_type"], rs.Primary.Attributes["user_id"])
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(userRoles, testAccVPCV2ProjectRole(rs)) {
+			return errors.New("project role not found")
+		}
+
+		return nil
+	}
+}
+
+func testAccVPCV2ProjectRole(rs *terraform.ResourceState) roles.Role {
+	return roles.Role{
+		ProjectID: rs.Primary.Attributes["project_id"],
+		RoleName:  roles.Name(rs.Primary.Attributes["role_name"]),
+		Scope:     roles.Project,
+	}
+}
+
+func testAccVPCV2ProjectRoleBasic(projectName, serviceUserName, serviceUserPassword string) string {
+	return fmt.Sprintf(`
+resource "selectel_vpc_project_v2" "project_tf_acc_test_1" {
+  name = "%s"
+}
+
+resource "selectel_iam_serviceuser_v1" "serviceuser_tf_acc_test_1" {
+  name     = "%s"
+  password = "%s"
+  role {
+    role_name = "reader"
+    scope     = "account"
+  }
+
+  lifecycle {
+    ignore_changes = [role]
+  }
+}
+
+resource "selectel_vpc_project_role_v2" "role_tf_acc_test_1" {
+  project_id = selectel_vpc_project_v2.project_tf_acc_test_1.id
+  user_id    = selectel_iam_serviceuser_v1.serviceuser_tf_acc_test_1.id
+  user_type  = "service_user"
+  role_name  = "reader"
+}`, projectName, serviceUserName, serviceUserPassword)
+}
+
+func testUnitVPCV2ProjectRoleBasic(userID string) string {
+	return fmt.Sprintf(`
+resource "selectel_vpc_project_role_v2" "role_tf_acc_test_1" {
+  project_id = %q
+  user_id    = %q
+}`, fakeapi.ProjectID, userID)
+}
@@ -13,7 +13,7 @@ import (
 )
 
 func resourceVPCProjectV2() *schema.Resource {
-	return &schema.Resource{
+	return withDeletionProtection(objectProject, &schema.Resource{
 		CreateContext: resourceVPCProjectV2Create,
 		ReadContext:   resourceVPCProjectV2Read,
 		UpdateContext: resourceVPCProjectV2Update,
@@ -21,6 +21,7 @@ func resourceVPCProjectV2() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customizeDiffVPCProjectV2Quotas,
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -123,7 +124,7 @@ func resourceVPCProjectV2() *schema.Resource {
 				},
 			},
 		},
-	}
+	})
 }
 
 func resourceVPCProjectV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -144,7 +145,7 @@ func resourceVPCProjectV2Create(ctx context.Context, d *schema.ResourceData, met
 	log.Print(msgCreate(objectProject, opts))
 	project, _, err := projects.Create(selvpcClient, opts)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectProject, err))
+		return diagCreatingObject(objectProject, err)
 	}
 
 	d.SetId(project.ID)
@@ -161,7 +162,7 @@ func resourceVPCProjectV2Create(ctx context.Context, d *schema.ResourceData, met
 		for region, updateQuotas := range projectQuotasOpts {
 			_, _, err := quotas.UpdateProjectQuotas(selvpcClient, d.Id(), region, updateQuotas)
 			if err != nil {
-				return diag.FromErr(errUpdatingObject(objectProjectQuotas, d.Id(), err))
+				return diagUpdatingObject(objectProjectQuotas, d.Id(), err)
 			}
 		}
 	}
@@ -186,7 +187,7 @@ func resourceVPCProjectV2Read(_ context.Context, d *schema.ResourceData, meta in
 			}
 		}
 
-		return diag.FromErr(errGettingObject(objectProject, d.Id(), err))
+		return diagGettingObject(objectProject, d.Id(), err)
 	}
 
 	projectCustomURL, err := resourceVPCProjectV2URLWithoutSchema(project.CustomURL)
@@ -218,6 +219,7 @@ func resourceVPCProjectV2Update(ctx context.Context, d *schema.ResourceData, met
 		return diag.FromErr(fmt.Errorf("can't get selvpc client for project object: %w", err))
 	}
 	var hasChange, projectChange, quotaChange bool
+	var diags diag.Diagnostics
 	var projectOpts projects.UpdateOpts
 	var projectQuotasOpts map[string]quotas.UpdateProjectQuotasOpts
 
@@ -252,23 +254,25 @@ func resourceVPCProjectV2Update(ctx context.Context, d *schema.ResourceData, met
 			log.Print(msgUpdate(objectProject, d.Id(), projectOpts))
 			_, _, err := projects.Update(selvpcClient, d.Id(), projectOpts)
 			if err != nil {
-				return diag.FromErr(errUpdatingObject(objectProject, d.Id(), err))
+				return diagUpdatingObject(objectProject, d.Id(), err)
 			}
 		}
 		// Update project quotas if needed.
 		if quotaChange {
+			diags = diagProjectQuotasBelowUsage(config, selvpcClient, d.Id(), projectQuotasOpts)
+
 			log.Print(msgUpdate(objectProjectQuotas, d.Id(), projectQuotasOpts))
 
 			for region, updateQuotas := range projectQuotasOpts {
 				_, _, err := quotas.UpdateProjectQuotas(selvpcClient, d.Id(), region, updateQuotas)
 				if err != nil {
-					return diag.FromErr(errUpdatingObject(objectProjectQuotas, d.Id(), err))
+					return append(diags, diagUpdatingObject(objectProjectQuotas, d.Id(), err)...)
 				}
 			}
 		}
 	}
 
-	return resourceVPCProjectV2Read(ctx, d, meta)
+	return append(diags, resourceVPCProjectV2Read(ctx, d, meta)...)
 }
 
 func resourceVPCProjectV2Delete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -288,8 +292,32 @@ func resourceVPCProjectV2Delete(_ context.Context, d *schema.ResourceData, meta
 			}
 		}
 
-		return diag.FromErr(errDeletingObject(objectProject, d.Id(), err))
+		return diagDeletingObject(objectProject, d.Id(), err)
 	}
 
 	return nil
 }
+
+// customizeDiffVPCProjectV2Quotas checks that the changed quotas of the existing project
+// are not lower than the current usage.
+func customizeDiffVPCProjectV2Quotas(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("quotas") || !d.NewValueKnown("quotas") {
+		return nil
+	}
+	quotaSet := d.Get("quotas").(*schema.Set)
+	if quotaSet.Len() == 0 {
+		return nil
+	}
+	projectQuotasOpts, err := resourceVPCProjectV2QuotasOptsFromSet(quotaSet)
+	if err != nil {
+		return errParseProjectV2Quotas(err)
+	}
+
+	config := meta.(*Config)
+	selvpcClient, err := config.GetSelVPCClient()
+	if err != nil {
+		return fmt.Errorf("can't get selvpc client for project object: %w", err)
+	}
+
+	return customizeDiffProjectQuotasUsage(config, selvpcClient, d.Id(), projectQuotasOpts, nil)
+}
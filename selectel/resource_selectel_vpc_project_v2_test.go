@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -12,6 +13,7 @@ import (
 	"github.com/selectel/go-selvpcclient/v4/selvpcclient/quotamanager/quotas"
 	"github.com/selectel/go-selvpcclient/v4/selvpcclient/resell/v2/projects"
 	"github.com/stretchr/testify/assert"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/fakeapi"
 )
 
 func TestAccVPCV2ProjectBasic(t *testing.T) {
@@ -21,9 +23,9 @@ func TestAccVPCV2ProjectBasic(t *testing.T) {
 	projectCustomURL := acctest.RandomWithPrefix("tf-acc-url") + ".selvpc.ru"
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccVPCV2ProjectBasic(projectName),
@@ -77,9 +79,9 @@ func TestAccVPCV2ProjectWithSpecificQuotas(t *testing.T) {
 	projectName := acctest.RandomWithPrefix("tf-acc")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccSelectelPreCheck(t) },
-		ProviderFactories: testAccProviders,
-		CheckDestroy:      testAccCheckVPCV2ProjectDestroy,
+		PreCheck:                 func() { testAccSelectelPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		CheckDestroy:             testAccCheckVPCV2ProjectDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccVPCV2ProjectWithSpecificQuotas(projectName),
@@ -336,3 +338,53 @@ func TestResourceVPCProjectV2URLWithoutSchema(t *testing.T) {
 	assert.Empty(t, err)
 	assert.Equal(t, expectedURL, actualURL)
 }
+
+func testVPCProjectV2QuotasValue(resourceName, region, zone string, value int64) cty.Value {
+	return cty.SetVal([]cty.Value{
+		cty.ObjectVal(map[string]cty.Value{
+			"resource_name": cty.StringVal(resourceName),
+			"resource_quotas": cty.SetVal([]cty.Value{
+				cty.ObjectVal(map[string]cty.Value{
+					"region": cty.StringVal(region),
+					"zone":   cty.StringVal(zone),
+					"value":  cty.NumberIntVal(value),
+				}),
+			}),
+		}),
+	})
+}
+
+func TestCustomizeDiffVPCProjectV2Quotas(t *testing.T) {
+	api := newTestFakeAPI(t)
+	api.SetQuotaUsage(fakeapi.ProjectID, fakeapi.Region, "compute_cores", "ru-1a", 6)
+	config := testVPCProjectQuotasV2Config(t, api)
+	r := resourceVPCProjectV2()
+
+	state := func() *terraform.InstanceState {
+		return &terraform.InstanceState{
+			ID:         fakeapi.ProjectID,
+			Attributes: map[string]string{"id": fakeapi.ProjectID, "name": "project"},
+		}
+	}
+	values := func(value int64) map[string]cty.Value {
+		return map[string]cty.Value{
+			"name":   cty.StringVal("project"),
+			"quotas": testVPCProjectV2QuotasValue("compute_cores", fakeapi.Region, "ru-1a", value),
+		}
+	}
+
+	_, err := testResourceDiffValues(t, r, state(), values(4), config)
+	assert.EqualError(t, err, "quotas of compute_cores are lower than the current usage of the project: "+
+		"compute_cores in ru-1a: value 4, used 6")
+
+	_, err = testResourceDiffValues(t, r, state(), values(6), config)
+	assert.NoError(t, err)
+
+	// New projects have no usage, so their quotas aren't checked.
+	_, err = testResourceDiffValues(t, r, nil, values(4), config)
+	assert.NoError(t, err)
+
+	config.allowQuotasBelowUsage = true
+	_, err = testResourceDiffValues(t, r, state(), values(4), config)
+	assert.NoError(t, err)
+}
@@ -26,12 +26,14 @@ func resourceVPCSubnetV2() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"project_id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"region": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"prefix_length": {
@@ -120,7 +122,7 @@ func resourceVPCSubnetV2Create(ctx context.Context, d *schema.ResourceData, meta
 	log.Print(msgCreate(objectSubnet, opts))
 	subnetsResponse, _, err := subnets.Create(selvpcClient, projectID, opts)
 	if err != nil {
-		return diag.FromErr(errCreatingObject(objectSubnet, err))
+		return diagCreatingObject(objectSubnet, err)
 	}
 	if len(subnetsResponse) != 1 {
 		return diag.FromErr(errReadFromResponse(objectSubnet))
@@ -149,7 +151,7 @@ func resourceVPCSubnetV2Read(_ context.Context, d *schema.ResourceData, meta int
 			}
 		}
 
-		return diag.FromErr(errGettingObject(objectSubnet, d.Id(), err))
+		return diagGettingObject(objectSubnet, d.Id(), err)
 	}
 
 	d.Set("cidr", subnet.CIDR)
@@ -194,7 +196,7 @@ func resourceVPCSubnetV2Delete(_ context.Context, d *schema.ResourceData, meta i
 			}
 		}
 
-		return diag.FromErr(errDeletingObject(objectSubnet, d.Id(), err))
+		return diagDeletingObject(objectSubnet, d.Id(), err)
 	}
 
 	return nil
@@ -2,189 +2,320 @@ package selectel
 
 import (
 	"context"
-	"errors"
+	"encoding/base64"
 	"fmt"
 	"log"
 	"strings"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/selectel/secretsmanager-go"
 	"github.com/selectel/secretsmanager-go/service/secrets"
 )
 
-func resourceSecretsManagerSecretV1() *schema.Resource {
-	return &schema.Resource{
-		Description: "represents a Secret — entity from SecretsManager service",
+// secretsManagerSecretV1Resource is served by the framework provider. Its schema and ID are
+// the same as they were in the SDK provider, so the existing state is read as is.
+type secretsManagerSecretV1Resource struct {
+	frameworkResource
+}
 
-		CreateContext: resourceSecretsManagerSecretV1Create,
-		ReadContext:   resourceSecretsManagerSecretV1Read,
-		UpdateContext: resourceSecretsManagerSecretV1Update,
-		DeleteContext: resourceSecretsManagerSecretV1Delete,
+var (
+	_ resource.ResourceWithConfigure   = &secretsManagerSecretV1Resource{}
+	_ resource.ResourceWithImportState = &secretsManagerSecretV1Resource{}
+	_ resource.ResourceWithModifyPlan  = &secretsManagerSecretV1Resource{}
+)
 
-		Importer: &schema.ResourceImporter{
-			StateContext: resourceSecretsManagerSecretV1ImportState,
-		},
+func newSecretsManagerSecretV1Resource() resource.Resource {
+	return &secretsManagerSecretV1Resource{}
+}
+
+type secretsManagerSecretV1Model struct {
+	ID             types.String `tfsdk:"id"`
+	Key            types.String `tfsdk:"key"`
+	Description    types.String `tfsdk:"description"`
+	Value          types.String `tfsdk:"value"`
+	ValueWO        types.String `tfsdk:"value_wo"`
+	ValueWOVersion types.Int64  `tfsdk:"value_wo_version"`
+	ProjectID      types.String `tfsdk:"project_id"`
+	Name           types.String `tfsdk:"name"`
+	CreatedAt      types.String `tfsdk:"created_at"`
+}
 
-		Schema: map[string]*schema.Schema{
-			"key": {
+func (r *secretsManagerSecretV1Resource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_secretsmanager_secret_v1"
+}
+
+func (r *secretsManagerSecretV1Resource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "represents a Secret — entity from SecretsManager service",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "id of the secret in the <project_id>/<key> format",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"key": schema.StringAttribute{
 				Description: "unique key,name of the secret",
-				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
-			"description": {
+			"description": schema.StringAttribute{
 				Description: "optional description of the secret",
-				Type:        schema.TypeString,
 				Optional:    true,
-				ForceNew:    false,
+				// The description is stored as an empty string when it is not set, as it was in the SDK provider.
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
 			},
-			"value": {
+			"value": schema.StringAttribute{
 				Description: "secret value, e.g. password, API key, certificate key, or other",
-				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Sensitive:   true,
+				// The value doesn't replace the secret, otherwise an imported secret would be replaced.
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("value_wo")),
+				},
+			},
+			"value_wo": schema.StringAttribute{
+				Description: "secret value which is not stored in the state",
+				Optional:    true,
 				Sensitive:   true,
-				ForceNew:    false, // otherwise, will replace existing secret if you import it
+				WriteOnly:   true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("value_wo_version")),
+				},
+			},
+			"value_wo_version": schema.Int64Attribute{
+				Description: "version of the write-only value, a change of the version adds the new value to the secret",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("value_wo")),
+				},
 			},
-			"project_id": {
+			"project_id": schema.StringAttribute{
 				Description: "id of a project where secret is used",
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
-			"name": {
+			"name": schema.StringAttribute{
 				Description: "computed name of the secret same as key",
-				Type:        schema.TypeString,
 				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
-			"created_at": {
+			"created_at": schema.StringAttribute{
 				Description: "time when the secret was created",
-				Type:        schema.TypeString,
 				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 		},
 	}
 }
 
-func resourceSecretsManagerSecretV1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	cl, diagErr := getSecretsManagerClient(d, meta)
-	if diagErr != nil {
-		return diagErr
+func (r *secretsManagerSecretV1Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanProviderDefaults(ctx, r.config, []string{"project_id"}, req, resp)
+	// The key is the name of the secret.
+	modifyPlanNamingPolicy(ctx, r.config, "selectel_secretsmanager_secret_v1", "key", req, resp)
+}
+
+func (r *secretsManagerSecretV1Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan, config secretsManagerSecretV1Model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	// The write-only value is only in the configuration.
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	key := d.Get("key").(string)
-	desc := d.Get("description").(string)
-	value := d.Get("value").(string)
+	cl, err := getSecretsManagerClient(r.config, plan.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(err.Error(), "")
+
+		return
+	}
+
+	value := plan.Value.ValueString()
+	if plan.Value.IsNull() {
+		value = config.ValueWO.ValueString()
+	}
 
 	secret := secrets.UserSecret{
-		Key:         key,
-		Description: desc,
+		Key:         plan.Key.ValueString(),
+		Description: plan.Description.ValueString(),
 		Value:       value,
 	}
 
 	log.Print(msgCreate(objectSecret, secret.Key))
 
-	errCr := cl.Secrets.Create(ctx, secret)
-	if errCr != nil {
-		return diag.FromErr(errCreatingObject(objectSecret, errCr))
+	if err := cl.Secrets.Create(ctx, secret); err != nil {
+		resp.Diagnostics.Append(frameworkDiagnostics(diagCreatingObject(objectSecret, err))...)
+
+		return
 	}
 
-	projectID := d.Get("project_id").(string)
-	d.SetId(resourceSecretV1BuildID(projectID, key))
+	plan.ID = types.StringValue(resourceSecretV1BuildID(plan.ProjectID.ValueString(), secret.Key))
 
-	return resourceSecretsManagerSecretV1Read(ctx, d, meta)
+	resp.Diagnostics.Append(r.read(ctx, cl, &plan)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
-func resourceSecretsManagerSecretV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	cl, diagErr := getSecretsManagerClient(d, meta)
-	if diagErr != nil {
-		return diagErr
+func (r *secretsManagerSecretV1Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state secretsManagerSecretV1Model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	log.Print(msgGet(objectSecret, d.Id()))
-
-	_, key, err := resourceSecretsManagerSecretV1ParseID(d.Id())
+	cl, err := getSecretsManagerClient(r.config, state.ProjectID.ValueString())
 	if err != nil {
-		return diag.FromErr(errParseID(objectSecret, d.Id()))
-	}
+		resp.Diagnostics.AddError(err.Error(), "")
 
-	secret, errGet := cl.Secrets.Get(ctx, key)
-	if errGet != nil {
-		return diag.FromErr(errGettingObject(objectSecret, d.Id(), errGet))
+		return
 	}
 
-	d.Set("name", secret.Name)
-	d.Set("key", secret.Name)
-	d.Set("description", secret.Description)
-	if _, ok := d.GetOk("value"); !ok {
-		d.Set("value", "UNKNOWN")
+	resp.Diagnostics.Append(r.read(ctx, cl, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	d.Set("created_at", secret.Version.CreatedAt)
 
-	return nil
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 }
 
-func resourceSecretsManagerSecretV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	cl, diagErr := getSecretsManagerClient(d, meta)
-	if diagErr != nil {
-		return diagErr
+func (r *secretsManagerSecretV1Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state, config secretsManagerSecretV1Model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	// The write-only value is only in the configuration.
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	key := d.Get("key").(string)
+	cl, err := getSecretsManagerClient(r.config, plan.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(err.Error(), "")
+
+		return
+	}
 
-	log.Print(msgDelete(objectSecret, d.Id()))
+	secret := secrets.UserSecret{
+		Key:         plan.Key.ValueString(),
+		Description: plan.Description.ValueString(),
+	}
 
-	errDel := cl.Secrets.Delete(ctx, key)
-	if errDel != nil {
-		return diag.FromErr(errDeletingObject(objectSecret, d.Id(), errDel))
+	// The value is sent only when it has changed, PUT with a value adds a new version of the secret.
+	// Unlike Create, Update of the client doesn't encode the value.
+	switch {
+	case !plan.Value.IsNull() && !plan.Value.Equal(state.Value):
+		secret.Value = base64.StdEncoding.EncodeToString([]byte(plan.Value.ValueString()))
+	case plan.Value.IsNull() && !config.ValueWO.IsNull() &&
+		(!plan.ValueWOVersion.Equal(state.ValueWOVersion) || !state.Value.IsNull()):
+		secret.Value = base64.StdEncoding.EncodeToString([]byte(config.ValueWO.ValueString()))
 	}
 
-	return nil
-}
+	log.Print(msgUpdate(objectSecret, plan.ID.ValueString(), secrets.UserSecret{
+		Key:         secret.Key,
+		Description: secret.Description,
+	}))
 
-func resourceSecretsManagerSecretV1Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	cl, diagErr := getSecretsManagerClient(d, meta)
-	if diagErr != nil {
-		return diagErr
+	if err := cl.Secrets.Update(ctx, secret); err != nil {
+		resp.Diagnostics.Append(frameworkDiagnostics(diagUpdatingObject(objectSecret, plan.ID.ValueString(), err))...)
+
+		return
 	}
 
-	key := d.Get("key").(string)
-	desc := d.Get("description").(string)
+	resp.Diagnostics.Append(r.read(ctx, cl, &plan)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
 
-	secret := secrets.UserSecret{
-		Key:         key,
-		Description: desc,
+func (r *secretsManagerSecretV1Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state secretsManagerSecretV1Model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	log.Print(msgUpdate(objectSecret, d.Id(), secret))
+	cl, err := getSecretsManagerClient(r.config, state.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(err.Error(), "")
 
-	errUpd := cl.Secrets.Update(ctx, secret)
-	if errUpd != nil {
-		return diag.FromErr(errUpdatingObject(objectSecret, d.Id(), errUpd))
+		return
 	}
 
-	return resourceSecretsManagerSecretV1Read(ctx, d, meta)
-}
+	log.Print(msgDelete(objectSecret, state.ID.ValueString()))
 
-// resourceSecretsManagerSecretV1ImportState —  helper used in Importer: &schema.ResourceImporter
-// to avoid difficulties occurred with required INFRA_PROJECT_ID env in
-// resourceSecretsManagerSecretV1Read when uising schema.ImportStatePassthroughContext.
-func resourceSecretsManagerSecretV1ImportState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	config := meta.(*Config)
-	if config.ProjectID == "" {
-		return nil, errors.New("INFRA_PROJECT_ID must be set for the resource import")
+	if err := cl.Secrets.Delete(ctx, state.Key.ValueString()); err != nil {
+		resp.Diagnostics.Append(frameworkDiagnostics(diagDeletingObject(objectSecret, state.ID.ValueString(), err))...)
 	}
+}
 
-	d.Set("project_id", config.ProjectID)
+// ImportState requires INFRA_PROJECT_ID, because the secret is read with the project-scope token
+// and the project of the import ID can't be trusted.
+func (r *secretsManagerSecretV1Resource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if r.config == nil || r.config.ProjectID == "" {
+		resp.Diagnostics.AddError("INFRA_PROJECT_ID must be set for the resource import", "")
 
-	_, key, err := resourceSecretsManagerSecretV1ParseID(d.Id())
+		return
+	}
+
+	_, key, err := resourceSecretsManagerSecretV1ParseID(req.ID)
 	if err != nil {
-		return nil, errParseID(objectSecret, d.Id())
+		resp.Diagnostics.AddError(err.Error(), "")
+
+		return
 	}
 
 	log.Print(msgImport(objectSecret, key))
-	resourceSecretsManagerSecretV1Read(ctx, d, meta)
 
-	return []*schema.ResourceData{d}, nil
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), r.config.ProjectID)...)
+	// The API never returns the value, it is unknown after the import.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("value"), "UNKNOWN")...)
+}
+
+// read sets the attributes of the secret from the API.
+func (r *secretsManagerSecretV1Resource) read(ctx context.Context, cl *secretsmanager.Client, m *secretsManagerSecretV1Model) fwdiag.Diagnostics {
+	var diags fwdiag.Diagnostics
+
+	log.Print(msgGet(objectSecret, m.ID.ValueString()))
+
+	_, key, err := resourceSecretsManagerSecretV1ParseID(m.ID.ValueString())
+	if err != nil {
+		diags.AddError(err.Error(), "")
+
+		return diags
+	}
+
+	secret, err := cl.Secrets.Get(ctx, key)
+	if err != nil {
+		return frameworkDiagnostics(diagGettingObject(objectSecret, m.ID.ValueString(), err))
+	}
+
+	m.Name = types.StringValue(secret.Name)
+	m.Key = types.StringValue(secret.Name)
+	m.Description = types.StringValue(secret.Description)
+	m.CreatedAt = types.StringValue(secret.Version.CreatedAt)
+
+	return diags
 }
 
 // resourceSecretV1BuildID — helper that builds ID that is going to be set
@@ -6,12 +6,14 @@ func resourceDBaaSGrantV1Schema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"project_id": {
 			Type:     schema.TypeString,
-			Required: true,
+			Optional: true,
+			Computed: true,
 			ForceNew: true,
 		},
 		"region": {
 			Type:     schema.TypeString,
-			Required: true,
+			Optional: true,
+			Computed: true,
 			ForceNew: true,
 		},
 		"datastore_id": {
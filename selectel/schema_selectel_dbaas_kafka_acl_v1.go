@@ -14,7 +14,8 @@ func resourceDBaaSKafkaACKV1Schema() map[string]*schema.Schema {
 		},
 		"region": {
 			Type:     schema.TypeString,
-			Required: true,
+			Optional: true,
+			Computed: true,
 			ForceNew: true,
 		},
 		"pattern": {
@@ -51,7 +52,8 @@ func resourceDBaaSKafkaACKV1Schema() map[string]*schema.Schema {
 		},
 		"project_id": {
 			Type:     schema.TypeString,
-			Required: true,
+			Optional: true,
+			Computed: true,
 			ForceNew: true,
 		},
 	}
@@ -11,7 +11,8 @@ func resourceDBaaSKafkaTopicV1Schema() map[string]*schema.Schema {
 		},
 		"region": {
 			Type:     schema.TypeString,
-			Required: true,
+			Optional: true,
+			Computed: true,
 			ForceNew: true,
 		},
 		"name": {
@@ -29,7 +30,8 @@ func resourceDBaaSKafkaTopicV1Schema() map[string]*schema.Schema {
 		},
 		"project_id": {
 			Type:     schema.TypeString,
-			Required: true,
+			Optional: true,
+			Computed: true,
 			ForceNew: true,
 		},
 	}
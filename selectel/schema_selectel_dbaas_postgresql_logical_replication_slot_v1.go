@@ -11,12 +11,14 @@ func resourceDBaaSPostgreSQLLogicalReplicationSlotV1Schema() map[string]*schema.
 		},
 		"project_id": {
 			Type:     schema.TypeString,
-			Required: true,
+			Optional: true,
+			Computed: true,
 			ForceNew: true,
 		},
 		"region": {
 			Type:     schema.TypeString,
-			Required: true,
+			Optional: true,
+			Computed: true,
 			ForceNew: true,
 		},
 		"datastore_id": {
@@ -6,11 +6,15 @@ func resourceDBaaSPostgreSQLPrometheusMetricTokenV1Schema() map[string]*schema.S
 	return map[string]*schema.Schema{
 		"project_id": {
 			Type:     schema.TypeString,
-			Required: true,
+			Optional: true,
+			Computed: true,
+			ForceNew: true,
 		},
 		"region": {
 			Type:     schema.TypeString,
-			Required: true,
+			Optional: true,
+			Computed: true,
+			ForceNew: true,
 		},
 		"name": {
 			Type:     schema.TypeString,
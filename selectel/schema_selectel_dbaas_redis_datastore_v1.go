@@ -52,9 +52,23 @@ func resourceDBaaSRedisDatastoreV1Schema() map[string]*schema.Schema {
 		Required: true,
 	}
 	datastoreSchema["redis_password"] = &schema.Schema{
-		Type:      schema.TypeString,
-		Optional:  true,
-		Sensitive: true,
+		Type:         schema.TypeString,
+		Optional:     true,
+		Sensitive:    true,
+		ExactlyOneOf: []string{"redis_password", "redis_password_wo"},
+	}
+	datastoreSchema["redis_password_wo"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Sensitive:    true,
+		WriteOnly:    true,
+		ExactlyOneOf: []string{"redis_password", "redis_password_wo"},
+		RequiredWith: []string{"redis_password_wo_version"},
+	}
+	datastoreSchema["redis_password_wo_version"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		RequiredWith: []string{"redis_password_wo"},
 	}
 	datastoreSchema["floating_ips"] = &schema.Schema{
 		Type:     schema.TypeSet,
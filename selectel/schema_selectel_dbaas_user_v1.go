@@ -11,7 +11,8 @@ func resourceDBaaSUserV1Schema() map[string]*schema.Schema {
 		},
 		"region": {
 			Type:     schema.TypeString,
-			Required: true,
+			Optional: true,
+			Computed: true,
 			ForceNew: true,
 		},
 		"name": {
@@ -20,9 +21,23 @@ func resourceDBaaSUserV1Schema() map[string]*schema.Schema {
 			ForceNew: true,
 		},
 		"password": {
-			Type:      schema.TypeString,
-			Required:  true,
-			Sensitive: true,
+			Type:         schema.TypeString,
+			Optional:     true,
+			Sensitive:    true,
+			ExactlyOneOf: []string{"password", "password_wo"},
+		},
+		"password_wo": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Sensitive:    true,
+			WriteOnly:    true,
+			ExactlyOneOf: []string{"password", "password_wo"},
+			RequiredWith: []string{"password_wo_version"},
+		},
+		"password_wo_version": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			RequiredWith: []string{"password_wo"},
 		},
 		"status": {
 			Type:     schema.TypeString,
@@ -30,7 +45,8 @@ func resourceDBaaSUserV1Schema() map[string]*schema.Schema {
 		},
 		"project_id": {
 			Type:     schema.TypeString,
-			Required: true,
+			Optional: true,
+			Computed: true,
 			ForceNew: true,
 		},
 	}
@@ -4,38 +4,49 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/selectel/secretsmanager-go"
 )
 
-func getSecretsManagerClient(d *schema.ResourceData, meta interface{}) (*secretsmanager.Client, diag.Diagnostics) {
-	config := meta.(*Config)
-
-	selvpcClient, err := config.GetSelVPCClientWithProjectScope(d.Get("project_id").(string))
+func getSecretsManagerClient(config *Config, projectID string) (*secretsmanager.Client, error) {
+	selvpcClient, err := config.GetSelVPCClientWithProjectScope(projectID)
 	if err != nil {
-		return nil, diag.FromErr(fmt.Errorf("can't get project-scope selvpc client for secretsmanager: %w", err))
+		return nil, fmt.Errorf("can't get project-scope selvpc client for secretsmanager: %w", err)
 	}
 
-	endpointSM, err := selvpcClient.Catalog.GetEndpoint(SecretsManager, config.AuthRegion)
-	if err != nil {
-		return nil, diag.FromErr(fmt.Errorf("can't get %s endpoint to init secretsmanager client: %w", SecretsManager, err))
+	endpointSM, ok := config.endpointOverride(SecretsManager)
+	if !ok {
+		endpoint, err := selvpcClient.Catalog.GetEndpoint(SecretsManager, config.AuthRegion)
+		if err != nil {
+			return nil, fmt.Errorf("can't get %s endpoint to init secretsmanager client: %w", SecretsManager, err)
+		}
+		endpointSM = endpoint.URL
+	}
+
+	endpointCM, ok := config.endpointOverride(CertificateManager)
+	if !ok {
+		endpoint, err := selvpcClient.Catalog.GetEndpoint(CertificateManager, config.AuthRegion)
+		if err != nil {
+			return nil, fmt.Errorf("can't get %s endpoint to init secretsmanager client: %w", CertificateManager, err)
+		}
+		endpointCM = endpoint.URL
 	}
 
-	endpointCM, err := selvpcClient.Catalog.GetEndpoint(CertificateManager, config.AuthRegion)
+	token, err := config.GetXAuthTokenWithProjectScope(projectID)
 	if err != nil {
-		return nil, diag.FromErr(fmt.Errorf("can't get %s endpoint to init secretsmanager client: %w", CertificateManager, err))
+		return nil, fmt.Errorf("can't get project-scope token for secretsmanager: %w", err)
 	}
 
 	cl, err := secretsmanager.New(
 		secretsmanager.WithAuthOpts(
-			&secretsmanager.AuthOpts{KeystoneToken: selvpcClient.GetXAuthToken()},
+			&secretsmanager.AuthOpts{KeystoneToken: token},
 		),
 
-		secretsmanager.WithCustomURLSecrets(endpointSM.URL),
-		secretsmanager.WithCustomURLCertificates(endpointCM.URL),
+		secretsmanager.WithCustomURLSecrets(endpointSM),
+		secretsmanager.WithCustomURLCertificates(endpointCM),
+		secretsmanager.WithCustomHTTPClient(config.newHTTPClient(SecretsManager, projectID)),
 	)
 	if err != nil {
-		return nil, diag.FromErr(fmt.Errorf("can't init secretsmanager client: %w", err))
+		return nil, fmt.Errorf("can't init secretsmanager client: %w", err)
 	}
 
 	return cl, nil
@@ -44,37 +55,10 @@ func getSecretsManagerClient(d *schema.ResourceData, meta interface{}) (*secrets
 func getSecretsManagerClientForAccImportTests(meta interface{}) (*secretsmanager.Client, diag.Diagnostics) {
 	config := meta.(*Config)
 
-	selvpcClient, err := config.GetSelVPCClientWithProjectScope(config.ProjectID)
-	if err != nil {
-		return nil, diag.FromErr(fmt.Errorf("can't get project-scope selvpc client for secretsmanager: %w", err))
-	}
-
-	cl, err := secretsmanager.New(
-		secretsmanager.WithAuthOpts(
-			&secretsmanager.AuthOpts{KeystoneToken: selvpcClient.GetXAuthToken()},
-		),
-	)
+	cl, err := getSecretsManagerClient(config, config.ProjectID)
 	if err != nil {
-		return nil, diag.FromErr(fmt.Errorf("can't init secretsmanager client: %w", err))
+		return nil, diag.FromErr(err)
 	}
 
 	return cl, nil
 }
-
-func convertToStringSlice(sl []interface{}) []string {
-	result := make([]string, len(sl))
-	for i := range sl {
-		result[i] = sl[i].(string)
-	}
-
-	return result
-}
-
-func convertToInterfaceSlice(sl []string) []interface{} {
-	result := make([]interface{}, len(sl))
-	for i := range sl {
-		result[i] = sl[i]
-	}
-
-	return result
-}
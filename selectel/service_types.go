@@ -7,4 +7,7 @@ const (
 	IAM                = "iam"
 	SecretsManager     = "secrets-manager"
 	CertificateManager = "certificate-manager"
+
+	// Domains isn't published in the Keystone catalog, the API has a single global endpoint.
+	Domains = "domains"
 )
@@ -0,0 +1,536 @@
+package selectel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/selectel/craas-go/pkg/v1/registry"
+	"github.com/selectel/go-selvpcclient/v4/selvpcclient/resell/v2/projects"
+	"github.com/selectel/mks-go/pkg/v1/cluster"
+	"github.com/selectel/mks-go/pkg/v1/nodegroup"
+	"github.com/stretchr/testify/assert"
+)
+
+// testAccSweepPrefixes are the prefixes of names generated by acceptance tests
+// with acctest.RandomWithPrefix and RandomWithPrefix.
+var testAccSweepPrefixes = []string{"tf-acc", "tf_acc"}
+
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+func init() {
+	resource.AddTestSweepers("selectel_mks_nodegroup_v1", &resource.Sweeper{
+		Name: "selectel_mks_nodegroup_v1",
+		F:    testSweepMKSNodegroupsV1,
+	})
+	resource.AddTestSweepers("selectel_mks_cluster_v1", &resource.Sweeper{
+		Name:         "selectel_mks_cluster_v1",
+		F:            testSweepMKSClustersV1,
+		Dependencies: []string{"selectel_mks_nodegroup_v1"},
+	})
+	resource.AddTestSweepers("selectel_dbaas_user_v1", &resource.Sweeper{
+		Name: "selectel_dbaas_user_v1",
+		F:    testSweepDBaaSUsersV1,
+	})
+	resource.AddTestSweepers("selectel_dbaas_datastore_v1", &resource.Sweeper{
+		Name:         "selectel_dbaas_datastore_v1",
+		F:            testSweepDBaaSDatastoresV1,
+		Dependencies: []string{"selectel_dbaas_user_v1"},
+	})
+	resource.AddTestSweepers("selectel_craas_registry_v1", &resource.Sweeper{
+		Name: "selectel_craas_registry_v1",
+		F:    testSweepCRaaSRegistriesV1,
+	})
+	resource.AddTestSweepers("selectel_domains_zone_v2", &resource.Sweeper{
+		Name: "selectel_domains_zone_v2",
+		F:    testSweepDomainsZonesV2,
+	})
+	resource.AddTestSweepers("selectel_secretsmanager_secret_v1", &resource.Sweeper{
+		Name: "selectel_secretsmanager_secret_v1",
+		F:    testSweepSecretsManagerSecretsV1,
+	})
+	resource.AddTestSweepers("selectel_secretsmanager_certificate_v1", &resource.Sweeper{
+		Name: "selectel_secretsmanager_certificate_v1",
+		F:    testSweepSecretsManagerCertificatesV1,
+	})
+	resource.AddTestSweepers("selectel_iam_serviceuser_v1", &resource.Sweeper{
+		Name: "selectel_iam_serviceuser_v1",
+		F:    testSweepIAMServiceUsersV1,
+	})
+	resource.AddTestSweepers("selectel_iam_group_v1", &resource.Sweeper{
+		Name: "selectel_iam_group_v1",
+		F:    testSweepIAMGroupsV1,
+	})
+	resource.AddTestSweepers("selectel_vpc_project_v2", &resource.Sweeper{
+		Name: "selectel_vpc_project_v2",
+		F:    testSweepVPCProjectsV2,
+		Dependencies: []string{
+			"selectel_mks_cluster_v1",
+			"selectel_dbaas_datastore_v1",
+			"selectel_craas_registry_v1",
+			"selectel_domains_zone_v2",
+			"selectel_secretsmanager_secret_v1",
+			"selectel_secretsmanager_certificate_v1",
+		},
+	})
+}
+
+func isSweepable(name string) bool {
+	for _, prefix := range testAccSweepPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// testSweepConfig configures the provider from the environment variables of acceptance tests.
+func testSweepConfig(region string) (*Config, error) {
+	p := Provider()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"region": region,
+	}))
+	if diags.HasError() {
+		return nil, fmt.Errorf("error configuring the provider: %s", diags[0].Summary)
+	}
+
+	return p.Meta().(*Config), nil
+}
+
+// testSweepProjectIDs returns the projects created by acceptance tests and the project
+// from INFRA_PROJECT_ID which is shared between tests.
+func testSweepProjectIDs(config *Config) ([]string, error) {
+	selvpcClient, err := config.GetSelVPCClient()
+	if err != nil {
+		return nil, err
+	}
+	allProjects, _, err := projects.List(selvpcClient)
+	if err != nil {
+		return nil, fmt.Errorf("error listing projects: %w", err)
+	}
+
+	var projectIDs []string
+	if projectID := os.Getenv("INFRA_PROJECT_ID"); projectID != "" {
+		projectIDs = append(projectIDs, projectID)
+	}
+	for _, project := range allProjects {
+		if isSweepable(project.Name) {
+			projectIDs = append(projectIDs, project.ID)
+		}
+	}
+
+	return projectIDs, nil
+}
+
+// testSweepResourceData returns the data of the resource with the attributes
+// used by the client getters and the delete function of the resource.
+func testSweepResourceData(resourceName, id string, attrs map[string]interface{}) (*schema.Resource, *schema.ResourceData, error) {
+	r := Provider().ResourcesMap[resourceName]
+	d := r.Data(nil)
+	d.SetId(id)
+	for attr, value := range attrs {
+		if err := d.Set(attr, value); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return r, d, nil
+}
+
+// testSweepResource deletes the object with the delete function of the resource,
+// so the sweeper waits for the deletion the same way Terraform does.
+func testSweepResource(resourceName, id string, attrs map[string]interface{}, config *Config) error {
+	r, d, err := testSweepResourceData(resourceName, id, attrs)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] sweeping %s %s", resourceName, id)
+	diags := r.DeleteContext(context.Background(), d, config)
+	if diags.HasError() {
+		return fmt.Errorf("error sweeping %s %s: %s", resourceName, id, diags[0].Summary)
+	}
+
+	return nil
+}
+
+// testSweepFrameworkResource deletes the resource of the framework provider with its Delete.
+func testSweepFrameworkResource(r fwresource.Resource, id string, attrs map[string]string, config *Config) error {
+	ctx := context.Background()
+
+	var metadataResp fwresource.MetadataResponse
+	r.Metadata(ctx, fwresource.MetadataRequest{ProviderTypeName: "selectel"}, &metadataResp)
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	diags := state.SetAttribute(ctx, path.Root("id"), id)
+	for attr, value := range attrs {
+		diags.Append(state.SetAttribute(ctx, path.Root(attr), value)...)
+	}
+	if r, ok := r.(fwresource.ResourceWithConfigure); ok {
+		configureResp := &fwresource.ConfigureResponse{}
+		r.Configure(ctx, fwresource.ConfigureRequest{ProviderData: config}, configureResp)
+		diags.Append(configureResp.Diagnostics...)
+	}
+	if diags.HasError() {
+		return fmt.Errorf("error sweeping %s %s: %s", metadataResp.TypeName, id, diags.Errors()[0].Summary())
+	}
+
+	log.Printf("[INFO] sweeping %s %s", metadataResp.TypeName, id)
+	deleteResp := &fwresource.DeleteResponse{State: state}
+	r.Delete(ctx, fwresource.DeleteRequest{State: state}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		return fmt.Errorf("error sweeping %s %s: %s", metadataResp.TypeName, id, deleteResp.Diagnostics.Errors()[0].Summary())
+	}
+
+	return nil
+}
+
+// testSweepProjects runs the sweep function for every test project and collects the errors.
+func testSweepProjects(region string, sweep func(config *Config, projectID string) error) error {
+	config, err := testSweepConfig(region)
+	if err != nil {
+		return err
+	}
+	projectIDs, err := testSweepProjectIDs(config)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, projectID := range projectIDs {
+		errs = append(errs, sweep(config, projectID))
+	}
+
+	return errors.Join(errs...)
+}
+
+func testSweepMKSNodegroupsV1(region string) error {
+	return testSweepProjects(region, func(config *Config, projectID string) error {
+		attrs := map[string]interface{}{"project_id": projectID, "region": region}
+		_, d, err := testSweepResourceData("selectel_mks_cluster_v1", "", attrs)
+		if err != nil {
+			return err
+		}
+		mksClient, diagErr := getMKSClient(d, config)
+		if diagErr != nil {
+			return fmt.Errorf("%s", diagErr[0].Summary)
+		}
+
+		clusters, _, err := cluster.List(context.Background(), mksClient)
+		if err != nil {
+			return fmt.Errorf("error listing clusters of project %s: %w", projectID, err)
+		}
+
+		var errs []error
+		for _, c := range clusters {
+			if !isSweepable(c.Name) {
+				continue
+			}
+			nodegroups, _, err := nodegroup.List(context.Background(), mksClient, c.ID)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("error listing nodegroups of cluster %s: %w", c.ID, err))
+				continue
+			}
+			for _, ng := range nodegroups {
+				id := fmt.Sprintf("%s/%s", c.ID, ng.ID)
+				errs = append(errs, testSweepResource("selectel_mks_nodegroup_v1", id, attrs, config))
+			}
+		}
+
+		return errors.Join(errs...)
+	})
+}
+
+func testSweepMKSClustersV1(region string) error {
+	return testSweepProjects(region, func(config *Config, projectID string) error {
+		attrs := map[string]interface{}{"project_id": projectID, "region": region}
+		_, d, err := testSweepResourceData("selectel_mks_cluster_v1", "", attrs)
+		if err != nil {
+			return err
+		}
+		mksClient, diagErr := getMKSClient(d, config)
+		if diagErr != nil {
+			return fmt.Errorf("%s", diagErr[0].Summary)
+		}
+
+		clusters, _, err := cluster.List(context.Background(), mksClient)
+		if err != nil {
+			return fmt.Errorf("error listing clusters of project %s: %w", projectID, err)
+		}
+
+		var errs []error
+		for _, c := range clusters {
+			if isSweepable(c.Name) {
+				errs = append(errs, testSweepResource("selectel_mks_cluster_v1", c.ID, attrs, config))
+			}
+		}
+
+		return errors.Join(errs...)
+	})
+}
+
+func testSweepDBaaSUsersV1(region string) error {
+	return testSweepProjects(region, func(config *Config, projectID string) error {
+		attrs := map[string]interface{}{"project_id": projectID, "region": region}
+		_, d, err := testSweepResourceData("selectel_dbaas_user_v1", "", attrs)
+		if err != nil {
+			return err
+		}
+		dbaasClient, diagErr := getDBaaSClient(d, config)
+		if diagErr != nil {
+			return fmt.Errorf("%s", diagErr[0].Summary)
+		}
+
+		users, err := dbaasClient.Users(context.Background())
+		if err != nil {
+			return fmt.Errorf("error listing datastore users of project %s: %w", projectID, err)
+		}
+
+		var errs []error
+		for _, user := range users {
+			if isSweepable(user.Name) {
+				errs = append(errs, testSweepResource("selectel_dbaas_user_v1", user.ID, attrs, config))
+			}
+		}
+
+		return errors.Join(errs...)
+	})
+}
+
+func testSweepDBaaSDatastoresV1(region string) error {
+	return testSweepProjects(region, func(config *Config, projectID string) error {
+		attrs := map[string]interface{}{"project_id": projectID, "region": region}
+		_, d, err := testSweepResourceData("selectel_dbaas_datastore_v1", "", attrs)
+		if err != nil {
+			return err
+		}
+		dbaasClient, diagErr := getDBaaSClient(d, config)
+		if diagErr != nil {
+			return fmt.Errorf("%s", diagErr[0].Summary)
+		}
+
+		datastores, err := dbaasClient.Datastores(context.Background(), nil)
+		if err != nil {
+			return fmt.Errorf("error listing datastores of project %s: %w", projectID, err)
+		}
+
+		var errs []error
+		for _, datastore := range datastores {
+			if isSweepable(datastore.Name) {
+				errs = append(errs, testSweepResource("selectel_dbaas_datastore_v1", datastore.ID, attrs, config))
+			}
+		}
+
+		return errors.Join(errs...)
+	})
+}
+
+func testSweepCRaaSRegistriesV1(region string) error {
+	return testSweepProjects(region, func(config *Config, projectID string) error {
+		attrs := map[string]interface{}{"project_id": projectID}
+		_, d, err := testSweepResourceData("selectel_craas_registry_v1", "", attrs)
+		if err != nil {
+			return err
+		}
+		craasClient, diagErr := getCRaaSClient(d, config)
+		if diagErr != nil {
+			return fmt.Errorf("%s", diagErr[0].Summary)
+		}
+
+		registries, _, err := registry.List(context.Background(), craasClient)
+		if err != nil {
+			return fmt.Errorf("error listing registries of project %s: %w", projectID, err)
+		}
+
+		var errs []error
+		for _, r := range registries {
+			if isSweepable(r.Name) {
+				errs = append(errs, testSweepResource("selectel_craas_registry_v1", r.ID, attrs, config))
+			}
+		}
+
+		return errors.Join(errs...)
+	})
+}
+
+func testSweepDomainsZonesV2(region string) error {
+	return testSweepProjects(region, func(config *Config, projectID string) error {
+		attrs := map[string]interface{}{"project_id": projectID}
+		_, d, err := testSweepResourceData("selectel_domains_zone_v2", "", attrs)
+		if err != nil {
+			return err
+		}
+		client, err := getDomainsV2Client(d, config)
+		if err != nil {
+			return err
+		}
+
+		zones, err := client.ListZones(context.Background(), &map[string]string{"limit": "1000"})
+		if err != nil {
+			return fmt.Errorf("error listing zones of project %s: %w", projectID, err)
+		}
+
+		var errs []error
+		for _, zone := range zones.GetItems() {
+			if isSweepable(zone.Name) {
+				errs = append(errs, testSweepResource("selectel_domains_zone_v2", zone.ID, attrs, config))
+			}
+		}
+
+		return errors.Join(errs...)
+	})
+}
+
+func testSweepSecretsManagerSecretsV1(region string) error {
+	return testSweepProjects(region, func(config *Config, projectID string) error {
+		cl, err := getSecretsManagerClient(config, projectID)
+		if err != nil {
+			return err
+		}
+
+		secrets, err := cl.Secrets.List(context.Background())
+		if err != nil {
+			return fmt.Errorf("error listing secrets of project %s: %w", projectID, err)
+		}
+
+		var errs []error
+		for _, key := range secrets.Keys {
+			if !isSweepable(key.Name) {
+				continue
+			}
+			secretAttrs := map[string]string{"project_id": projectID, "key": key.Name}
+			id := resourceSecretV1BuildID(projectID, key.Name)
+			errs = append(errs, testSweepFrameworkResource(newSecretsManagerSecretV1Resource(), id, secretAttrs, config))
+		}
+
+		return errors.Join(errs...)
+	})
+}
+
+func testSweepSecretsManagerCertificatesV1(region string) error {
+	return testSweepProjects(region, func(config *Config, projectID string) error {
+		cl, err := getSecretsManagerClient(config, projectID)
+		if err != nil {
+			return err
+		}
+
+		certificates, err := cl.Certificates.List(context.Background())
+		if err != nil {
+			return fmt.Errorf("error listing certificates of project %s: %w", projectID, err)
+		}
+
+		var errs []error
+		attrs := map[string]string{"project_id": projectID}
+		for _, certificate := range certificates {
+			if isSweepable(certificate.Name) {
+				errs = append(errs, testSweepFrameworkResource(newSecretsManagerCertificateV1Resource(), certificate.ID, attrs, config))
+			}
+		}
+
+		return errors.Join(errs...)
+	})
+}
+
+func testSweepIAMServiceUsersV1(region string) error {
+	config, err := testSweepConfig(region)
+	if err != nil {
+		return err
+	}
+	iamClient, diagErr := getIAMClient(config)
+	if diagErr != nil {
+		return fmt.Errorf("%s", diagErr[0].Summary)
+	}
+
+	serviceUsers, err := iamClient.ServiceUsers.List(context.Background())
+	if err != nil {
+		return fmt.Errorf("error listing service users: %w", err)
+	}
+
+	var errs []error
+	for _, user := range serviceUsers.Users {
+		if isSweepable(user.Name) {
+			errs = append(errs, testSweepResource("selectel_iam_serviceuser_v1", user.ID, nil, config))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func testSweepIAMGroupsV1(region string) error {
+	config, err := testSweepConfig(region)
+	if err != nil {
+		return err
+	}
+	iamClient, diagErr := getIAMClient(config)
+	if diagErr != nil {
+		return fmt.Errorf("%s", diagErr[0].Summary)
+	}
+
+	groups, err := iamClient.Groups.List(context.Background())
+	if err != nil {
+		return fmt.Errorf("error listing groups: %w", err)
+	}
+
+	var errs []error
+	for _, group := range groups.Groups {
+		if isSweepable(group.Name) {
+			errs = append(errs, testSweepResource("selectel_iam_group_v1", group.ID, nil, config))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func testSweepVPCProjectsV2(region string) error {
+	config, err := testSweepConfig(region)
+	if err != nil {
+		return err
+	}
+	selvpcClient, err := config.GetSelVPCClient()
+	if err != nil {
+		return err
+	}
+	allProjects, _, err := projects.List(selvpcClient)
+	if err != nil {
+		return fmt.Errorf("error listing projects: %w", err)
+	}
+
+	var errs []error
+	for _, project := range allProjects {
+		if isSweepable(project.Name) {
+			errs = append(errs, testSweepResource("selectel_vpc_project_v2", project.ID, nil, config))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func TestIsSweepable(t *testing.T) {
+	for name, expected := range map[string]bool{
+		"tf-acc-cl-5577006791947779410":   true,
+		"tf_acc_user_8674665223082153551": true,
+		"production":                      false,
+		"my-tf-acc":                       false,
+	} {
+		assert.Equal(t, expected, isSweepable(name), name)
+	}
+}
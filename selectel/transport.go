@@ -0,0 +1,221 @@
+package selectel
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"math"
+	"math/rand" // nosemgrep: go.lang.security.audit.crypto.math_random.math-random-used
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// httpClientTimeout is the timeout of a single HTTP request to Selectel APIs.
+const httpClientTimeout = 120 * time.Second
+
+// authTransport sets the actual X-Auth-Token of the project scope on every request
+// and retries the request once with a re-issued token if the API responds with 401.
+type authTransport struct {
+	config    *Config
+	projectID string
+	next      http.RoundTripper
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.config.GetXAuthTokenWithProjectScope(t.projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.next.RoundTrip(withAuthToken(req, token))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	// The body of the original request has already been consumed, it can be
+	// sent again only if it can be recreated.
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return resp, nil
+	}
+
+	log.Printf("[DEBUG] Got 401 from %s %s, re-authenticating", req.Method, req.URL.Redacted())
+
+	newToken, err := t.config.reauthenticateWithProjectScope(t.projectID, token)
+	if err != nil {
+		log.Printf("[DEBUG] %s", err)
+
+		return resp, nil
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	retry := withAuthToken(req, newToken)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		retry.Body = body
+	}
+
+	return t.next.RoundTrip(retry)
+}
+
+// withAuthToken returns a copy of the request with the X-Auth-Token header set.
+func withAuthToken(req *http.Request, token string) *http.Request {
+	r := req.Clone(req.Context())
+	r.Header.Set("X-Auth-Token", token)
+
+	return r
+}
+
+// retryWaitMin is the minimum time to wait before retrying a request.
+var retryWaitMin = time.Second
+
+// userAgentTransport prepends the User-Agent of the provider to the one set by the API client,
+// so that every request tells the provider and Terraform versions.
+type userAgentTransport struct {
+	userAgent string
+	next      http.RoundTripper
+}
+
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	userAgent := t.userAgent
+	if clientUserAgent := req.Header.Get("User-Agent"); clientUserAgent != "" {
+		userAgent += " " + clientUserAgent
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", userAgent)
+
+	return t.next.RoundTrip(req)
+}
+
+// newRetryableTransport wraps the transport with retries of requests that got 429, 5xx
+// or a connection error. Retries are spaced with a jittered exponential backoff
+// limited by MaxBackoff, the Retry-After header of the response is honored.
+// POST and PATCH requests are not idempotent, they are retried only with nonIdempotentRetryPolicy.
+func (c *Config) newRetryableTransport(next http.RoundTripper) http.RoundTripper {
+	return &methodTransport{
+		idempotent:    c.newRetryableClientTransport(next, retryablehttp.DefaultRetryPolicy),
+		nonIdempotent: c.newRetryableClientTransport(next, nonIdempotentRetryPolicy),
+	}
+}
+
+// methodTransport sends POST and PATCH requests with the nonIdempotent transport
+// and the other requests with the idempotent one.
+type methodTransport struct {
+	idempotent    http.RoundTripper
+	nonIdempotent http.RoundTripper
+}
+
+func (t *methodTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodPost || req.Method == http.MethodPatch {
+		return t.nonIdempotent.RoundTrip(req)
+	}
+
+	return t.idempotent.RoundTrip(req)
+}
+
+// nonIdempotentRetryPolicy retries the requests that the API can't have applied: the ones
+// that have been throttled, and the ones that failed before they were sent because
+// the connection couldn't be established. A 5xx response or a broken connection
+// doesn't tell whether the object has been created, so such requests are not retried.
+func nonIdempotentRetryPolicy(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+	if err != nil {
+		var opErr *net.OpError
+
+		return errors.As(err, &opErr) && opErr.Op == "dial", nil
+	}
+
+	return resp.StatusCode == http.StatusTooManyRequests, nil
+}
+
+func (c *Config) newRetryableClientTransport(next http.RoundTripper, checkRetry retryablehttp.CheckRetry) http.RoundTripper {
+	client := retryablehttp.NewClient()
+	client.HTTPClient = &http.Client{
+		Timeout:   httpClientTimeout,
+		Transport: next,
+	}
+	client.Logger = nil // Retries are logged in RequestLogHook.
+	client.RetryMax = c.MaxRetries
+	client.RetryWaitMin = retryWaitMin
+	client.RetryWaitMax = c.MaxBackoff
+	client.CheckRetry = checkRetry
+	client.Backoff = jitteredBackoff
+	// Return the last response as is, so that API clients can parse the error from its body.
+	client.ErrorHandler = retryablehttp.PassthroughErrorHandler
+	client.RequestLogHook = func(_ retryablehttp.Logger, req *http.Request, attempt int) {
+		if attempt > 0 {
+			log.Printf("[DEBUG] Retrying %s %s, attempt %d of %d", req.Method, req.URL.Redacted(), attempt, c.MaxRetries)
+		}
+	}
+
+	return &retryablehttp.RoundTripper{Client: client}
+}
+
+// jitteredBackoff returns a random wait time between min and an exponentially growing limit
+// capped by max. If the API has asked to wait with the Retry-After header, that time is used instead,
+// but it is capped by max as well.
+func jitteredBackoff(min, max time.Duration, attempt int, resp *http.Response) time.Duration {
+	if max < min {
+		max = min
+	}
+
+	if wait, ok := retryAfter(resp); ok {
+		if wait > max {
+			return max
+		}
+
+		return wait
+	}
+
+	limit := float64(min) * math.Pow(2, float64(attempt))
+	if limit > float64(max) || math.IsInf(limit, 0) {
+		limit = float64(max)
+	}
+
+	// #nosec G404
+	jitter := rand.Int63n(int64(limit-float64(min)) + 1) // nosemgrep: go.lang.security.audit.crypto.math_random.math-random-used
+
+	return min + time.Duration(jitter)
+}
+
+// retryAfter parses the Retry-After header of throttled and unavailable responses.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+		return 0, false
+	}
+
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+
+		return wait, true
+	}
+
+	return 0, false
+}
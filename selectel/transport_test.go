@@ -0,0 +1,417 @@
+package selectel
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestServiceAPI starts an API that accepts only tokens that are valid in the keystone
+// and echoes the request body back.
+func newTestServiceAPI(t *testing.T, keystone *testKeystone, calls *int32) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+		if !keystone.Valid(r.Header.Get("X-Auth-Token")) {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+		_, _ = io.Copy(w, r.Body)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestAuthTransportRetriesUnauthorizedRequest(t *testing.T) {
+	keystone := newTestKeystone(t)
+	var calls int32
+	api := newTestServiceAPI(t, keystone, &calls)
+
+	config := testConfigureProvider(t, map[string]interface{}{
+		"auth_url":    keystone.AuthURL(),
+		"auth_region": testKeystoneAuthRegion,
+		"domain_name": testKeystoneDomainName,
+		"username":    "alice",
+		"password":    "secret-alice",
+	})
+
+	token, err := config.GetXAuthTokenWithProjectScope("project-a")
+	require.NoError(t, err)
+	keystone.Revoke(token)
+
+	req, err := http.NewRequest(http.MethodPost, api.URL, strings.NewReader(`{"name":"test"}`))
+	require.NoError(t, err)
+	req.Header.Set("X-Auth-Token", token)
+
+	resp, err := config.newHTTPClient(DBaaS, "project-a").Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `{"name":"test"}`, string(body))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestAuthTransportRetriesOnlyOnce(t *testing.T) {
+	keystone := newTestKeystone(t)
+	var calls int32
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	t.Cleanup(api.Close)
+
+	config := testConfigureProvider(t, map[string]interface{}{
+		"auth_url":    keystone.AuthURL(),
+		"auth_region": testKeystoneAuthRegion,
+		"domain_name": testKeystoneDomainName,
+		"username":    "alice",
+		"password":    "secret-alice",
+	})
+
+	req, err := http.NewRequest(http.MethodGet, api.URL, nil)
+	require.NoError(t, err)
+
+	resp, err := config.newHTTPClient(IAM, "").Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestAuthTransportRefreshesShortLivedToken(t *testing.T) {
+	keystone := newTestKeystone(t)
+	keystone.TTL = tokenRefreshMargin / 2
+	var calls int32
+	api := newTestServiceAPI(t, keystone, &calls)
+
+	config := testConfigureProvider(t, map[string]interface{}{
+		"auth_url":    keystone.AuthURL(),
+		"auth_region": testKeystoneAuthRegion,
+		"domain_name": testKeystoneDomainName,
+		"username":    "alice",
+		"password":    "secret-alice",
+	})
+	client := config.newHTTPClient(DBaaS, "project-a")
+
+	for i := 0; i < 3; i++ {
+		req, err := http.NewRequest(http.MethodGet, api.URL, nil)
+		require.NoError(t, err)
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	// Every request got a fresh token, so none of them has been rejected.
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func testRetryableClient(t *testing.T, maxRetries int) *http.Client {
+	t.Helper()
+
+	waitMin := retryWaitMin
+	retryWaitMin = time.Millisecond
+	t.Cleanup(func() {
+		retryWaitMin = waitMin
+	})
+
+	config := &Config{
+		MaxRetries: maxRetries,
+		MaxBackoff: 10 * time.Millisecond,
+	}
+
+	return &http.Client{
+		Transport: config.newRetryableTransport(config.httpTransport()),
+	}
+}
+
+func TestRetryableTransportRetriesServerErrors(t *testing.T) {
+	var calls int32
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+
+			return
+		}
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(api.Close)
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPut, api.URL, strings.NewReader(`{"name":"test"}`))
+	require.NoError(t, err)
+	resp, err := testRetryableClient(t, 5).Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `{"name":"test"}`, string(body))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestRetryableTransportNonIdempotentRequests(t *testing.T) {
+	testCases := []struct {
+		name          string
+		method        string
+		status        int
+		expectedCalls int32
+	}{
+		{name: "POST with a server error", method: http.MethodPost, status: http.StatusBadGateway, expectedCalls: 1},
+		{name: "PATCH with a server error", method: http.MethodPatch, status: http.StatusServiceUnavailable, expectedCalls: 1},
+		{name: "POST with a throttled request", method: http.MethodPost, status: http.StatusTooManyRequests, expectedCalls: 2},
+		{name: "PATCH with a throttled request", method: http.MethodPatch, status: http.StatusTooManyRequests, expectedCalls: 2},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls int32
+			api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&calls, 1) == 1 {
+					w.WriteHeader(tc.status)
+
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			t.Cleanup(api.Close)
+
+			req, err := http.NewRequestWithContext(context.Background(), tc.method, api.URL, strings.NewReader(`{"name":"test"}`))
+			require.NoError(t, err)
+			resp, err := testRetryableClient(t, 5).Do(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+
+			assert.Equal(t, tc.expectedCalls, atomic.LoadInt32(&calls))
+		})
+	}
+}
+
+func TestNonIdempotentRetryPolicy(t *testing.T) {
+	ctx := context.Background()
+
+	retry, err := nonIdempotentRetryPolicy(ctx, nil, &url.Error{
+		Op:  "Post",
+		URL: "http://127.0.0.1:1",
+		Err: &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED},
+	})
+	require.NoError(t, err)
+	assert.True(t, retry, "the request that hasn't been sent is retried")
+
+	retry, err = nonIdempotentRetryPolicy(ctx, nil, &url.Error{
+		Op:  "Post",
+		URL: "http://127.0.0.1:1",
+		Err: &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET},
+	})
+	require.NoError(t, err)
+	assert.False(t, retry, "the request that may have been applied is not retried")
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	retry, err = nonIdempotentRetryPolicy(canceled, &http.Response{StatusCode: http.StatusTooManyRequests}, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, retry)
+}
+
+func TestRetryableTransportRetriesThrottledRequests(t *testing.T) {
+	var calls int32
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(api.Close)
+
+	resp, err := testRetryableClient(t, 5).Get(api.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestRetryableTransportRetriesConnectionResets(t *testing.T) {
+	var calls int32
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			require.NoError(t, err)
+			conn.Close()
+
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(api.Close)
+
+	resp, err := testRetryableClient(t, 5).Get(api.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestRetryableTransportDoesNotRetryClientErrors(t *testing.T) {
+	var calls int32
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	t.Cleanup(api.Close)
+
+	resp, err := testRetryableClient(t, 5).Get(api.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestRetryableTransportReturnsLastResponse(t *testing.T) {
+	var calls int32
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"error": "internal"}`))
+	}))
+	t.Cleanup(api.Close)
+
+	resp, err := testRetryableClient(t, 2).Get(api.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Equal(t, `{"error": "internal"}`, string(body))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestJitteredBackoff(t *testing.T) {
+	minWait := time.Second
+	maxWait := 30 * time.Second
+
+	for attempt := 0; attempt < 10; attempt++ {
+		limit := minWait << attempt
+		if limit > maxWait {
+			limit = maxWait
+		}
+
+		for i := 0; i < 100; i++ {
+			wait := jitteredBackoff(minWait, maxWait, attempt, nil)
+			assert.GreaterOrEqual(t, wait, minWait)
+			assert.LessOrEqual(t, wait, limit)
+		}
+	}
+}
+
+func TestJitteredBackoffRetryAfter(t *testing.T) {
+	throttled := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": []string{"7"}},
+	}
+	assert.Equal(t, 7*time.Second, jitteredBackoff(time.Second, 30*time.Second, 0, throttled))
+	assert.Equal(t, 5*time.Second, jitteredBackoff(time.Second, 5*time.Second, 0, throttled))
+
+	unavailable := &http.Response{
+		StatusCode: http.StatusServiceUnavailable,
+		Header:     http.Header{"Retry-After": []string{time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)}},
+	}
+	assert.Equal(t, 30*time.Second, jitteredBackoff(time.Second, 30*time.Second, 0, unavailable))
+
+	badGateway := &http.Response{
+		StatusCode: http.StatusBadGateway,
+		Header:     http.Header{"Retry-After": []string{"7"}},
+	}
+	assert.LessOrEqual(t, jitteredBackoff(time.Second, 30*time.Second, 0, badGateway), time.Second)
+}
+
+func TestUserAgentTransport(t *testing.T) {
+	var userAgents []string
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		userAgents = append(userAgents, r.Header.Get("User-Agent"))
+	}))
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: &userAgentTransport{
+			userAgent: "terraform-provider-selectel/1.2.3",
+			next:      http.DefaultTransport,
+		},
+	}
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	req.Header.Set("User-Agent", "mks-go/v1")
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	// The original request is left untouched.
+	assert.Equal(t, "mks-go/v1", req.Header.Get("User-Agent"))
+
+	resp, err = client.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, []string{
+		"terraform-provider-selectel/1.2.3 mks-go/v1",
+		"terraform-provider-selectel/1.2.3",
+	}, userAgents)
+}
+
+func TestConfigureProviderUserAgent(t *testing.T) {
+	keystone := newTestKeystone(t)
+
+	var userAgent string
+	api := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+	}))
+	defer api.Close()
+
+	p := New("1.2.3")()
+	p.TerraformVersion = "1.6.0"
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"auth_url":          keystone.AuthURL(),
+		"auth_region":       testKeystoneAuthRegion,
+		"domain_name":       testKeystoneDomainName,
+		"username":          "alice",
+		"password":          "secret-alice",
+		"user_agent_suffix": "ci-pipeline/42",
+	}))
+	require.False(t, diags.HasError(), "unexpected diagnostics: %+v", diags)
+	config := p.Meta().(*Config)
+
+	resp, err := config.newHTTPClient(DBaaS, "").Get(api.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Regexp(t, `^Terraform/1\.6\.0 .*terraform-provider-selectel/1\.2\.3 ci-pipeline/42$`, userAgent)
+}
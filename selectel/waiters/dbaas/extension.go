@@ -2,68 +2,35 @@ package waiters
 
 import (
 	"context"
-	"errors"
-	"fmt"
-	"strconv"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/selectel/dbaas-go"
 )
 
 func WaitForDBaaSExtensionV1ActiveState(
 	ctx context.Context, client *dbaas.API, extensionID string, timeout time.Duration,
 ) error {
-	pending := []string{
-		string(dbaas.StatusPendingCreate),
-		string(dbaas.StatusPendingUpdate),
-	}
-	target := []string{
-		string(dbaas.StatusActive),
-	}
-
-	stateConf := &resource.StateChangeConf{
-		Pending:    pending,
-		Target:     target,
-		Refresh:    dbaasExtensionV1StateRefreshFunc(ctx, client, extensionID),
-		Timeout:    timeout,
-		Delay:      10 * time.Second,
-		MinTimeout: 15 * time.Second,
+	pending := []dbaas.Status{
+		dbaas.StatusPendingCreate,
+		dbaas.StatusPendingUpdate,
 	}
+	refresh := func(ctx context.Context) (dbaas.Extension, dbaas.Status, error) {
+		extension, err := client.Extension(ctx, extensionID)
 
-	_, err := stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return fmt.Errorf(
-			"error waiting for the extension %s to become 'ACTIVE': %s",
-			extensionID, err)
+		return extension, extension.Status, err
 	}
 
-	return nil
+	return waitForActiveState(ctx, "extension "+extensionID, pending, failureStatuses, refresh, timeout, 15*time.Second)
 }
 
-func DBaaSExtensionV1DeleteStateRefreshFunc(ctx context.Context, client *dbaas.API, extensionID string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		d, err := client.Extension(ctx, extensionID)
-		if err != nil {
-			var dbaasError *dbaas.DBaaSAPIError
-			if errors.As(err, &dbaasError) {
-				return d, strconv.Itoa(dbaasError.StatusCode()), nil
-			}
-
-			return nil, "", err
-		}
+func WaitForDBaaSExtensionV1Deleted(
+	ctx context.Context, client *dbaas.API, extensionID string, timeout time.Duration,
+) error {
+	refresh := func(ctx context.Context) (dbaas.Extension, dbaas.Status, error) {
+		extension, err := client.Extension(ctx, extensionID)
 
-		return d, strconv.Itoa(200), err
+		return extension, extension.Status, err
 	}
-}
 
-func dbaasExtensionV1StateRefreshFunc(ctx context.Context, client *dbaas.API, extensionID string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		d, err := client.Extension(ctx, extensionID)
-		if err != nil {
-			return nil, "", err
-		}
-
-		return d, string(d.Status), nil
-	}
+	return waitForDeleted(ctx, "extension "+extensionID, refresh, timeout, 20*time.Second)
 }
@@ -2,68 +2,35 @@ package waiters
 
 import (
 	"context"
-	"errors"
-	"fmt"
-	"strconv"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/selectel/dbaas-go"
 )
 
 func WaitForDBaaSGrantV1ActiveState(
 	ctx context.Context, client *dbaas.API, grantID string, timeout time.Duration,
 ) error {
-	pending := []string{
-		string(dbaas.StatusPendingCreate),
-		string(dbaas.StatusPendingUpdate),
-	}
-	target := []string{
-		string(dbaas.StatusActive),
-	}
-
-	stateConf := &resource.StateChangeConf{
-		Pending:    pending,
-		Target:     target,
-		Refresh:    dbaasGrantV1StateRefreshFunc(ctx, client, grantID),
-		Timeout:    timeout,
-		Delay:      10 * time.Second,
-		MinTimeout: 10 * time.Second,
+	pending := []dbaas.Status{
+		dbaas.StatusPendingCreate,
+		dbaas.StatusPendingUpdate,
 	}
+	refresh := func(ctx context.Context) (dbaas.Grant, dbaas.Status, error) {
+		grant, err := client.Grant(ctx, grantID)
 
-	_, err := stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return fmt.Errorf(
-			"error waiting for the grant %s to become 'ACTIVE': %s",
-			grantID, err)
+		return grant, grant.Status, err
 	}
 
-	return nil
+	return waitForActiveState(ctx, "grant "+grantID, pending, failureStatuses, refresh, timeout, 10*time.Second)
 }
 
-func DBaaSGrantV1DeleteStateRefreshFunc(ctx context.Context, client *dbaas.API, grantID string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		d, err := client.Grant(ctx, grantID)
-		if err != nil {
-			var dbaasError *dbaas.DBaaSAPIError
-			if errors.As(err, &dbaasError) {
-				return d, strconv.Itoa(dbaasError.StatusCode()), nil
-			}
-
-			return nil, "", err
-		}
+func WaitForDBaaSGrantV1Deleted(
+	ctx context.Context, client *dbaas.API, grantID string, timeout time.Duration,
+) error {
+	refresh := func(ctx context.Context) (dbaas.Grant, dbaas.Status, error) {
+		grant, err := client.Grant(ctx, grantID)
 
-		return d, strconv.Itoa(200), err
+		return grant, grant.Status, err
 	}
-}
 
-func dbaasGrantV1StateRefreshFunc(ctx context.Context, client *dbaas.API, grantID string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		d, err := client.Grant(ctx, grantID)
-		if err != nil {
-			return nil, "", err
-		}
-
-		return d, string(d.Status), nil
-	}
+	return waitForDeleted(ctx, "grant "+grantID, refresh, timeout, 20*time.Second)
 }
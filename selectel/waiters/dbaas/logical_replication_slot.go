@@ -2,69 +2,35 @@ package waiters
 
 import (
 	"context"
-	"errors"
-	"fmt"
-	"net/http"
-	"strconv"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/selectel/dbaas-go"
 )
 
 func WaitForDBaaSLogicalReplicationSlotV1ActiveState(
 	ctx context.Context, client *dbaas.API, slotID string, timeout time.Duration,
 ) error {
-	pending := []string{
-		string(dbaas.StatusPendingCreate),
-		string(dbaas.StatusPendingUpdate),
-	}
-	target := []string{
-		string(dbaas.StatusActive),
-	}
-
-	stateConf := &resource.StateChangeConf{
-		Pending:    pending,
-		Target:     target,
-		Refresh:    dbaasLogicalReplicationSlotV1StateRefreshFunc(ctx, client, slotID),
-		Timeout:    timeout,
-		Delay:      10 * time.Second,
-		MinTimeout: 15 * time.Second,
+	pending := []dbaas.Status{
+		dbaas.StatusPendingCreate,
+		dbaas.StatusPendingUpdate,
 	}
+	refresh := func(ctx context.Context) (dbaas.LogicalReplicationSlot, dbaas.Status, error) {
+		slot, err := client.LogicalReplicationSlot(ctx, slotID)
 
-	_, err := stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return fmt.Errorf(
-			"error waiting for the slot %s to become 'ACTIVE': %s",
-			slotID, err)
+		return slot, slot.Status, err
 	}
 
-	return nil
+	return waitForActiveState(ctx, "slot "+slotID, pending, failureStatuses, refresh, timeout, 15*time.Second)
 }
 
-func DBaaSLogicalReplicationSlotV1DeleteStateRefreshFunc(ctx context.Context, client *dbaas.API, slotID string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		d, err := client.LogicalReplicationSlot(ctx, slotID)
-		if err != nil {
-			var dbaasError *dbaas.DBaaSAPIError
-			if errors.As(err, &dbaasError) {
-				return d, strconv.Itoa(dbaasError.StatusCode()), nil
-			}
-
-			return nil, "", err
-		}
+func WaitForDBaaSLogicalReplicationSlotV1Deleted(
+	ctx context.Context, client *dbaas.API, slotID string, timeout time.Duration,
+) error {
+	refresh := func(ctx context.Context) (dbaas.LogicalReplicationSlot, dbaas.Status, error) {
+		slot, err := client.LogicalReplicationSlot(ctx, slotID)
 
-		return d, strconv.Itoa(http.StatusOK), err
+		return slot, slot.Status, err
 	}
-}
 
-func dbaasLogicalReplicationSlotV1StateRefreshFunc(ctx context.Context, client *dbaas.API, slotID string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		d, err := client.LogicalReplicationSlot(ctx, slotID)
-		if err != nil {
-			return nil, "", err
-		}
-
-		return d, string(d.Status), nil
-	}
+	return waitForDeleted(ctx, "slot "+slotID, refresh, timeout, 20*time.Second)
 }
@@ -0,0 +1,21 @@
+package waiters
+
+import (
+	"context"
+	"time"
+
+	"github.com/selectel/dbaas-go"
+)
+
+func WaitForDBaaSPrometheusMetricTokenV1Deleted(
+	ctx context.Context, client *dbaas.API, tokenID string, timeout time.Duration,
+) error {
+	// Tokens have no status, the token is being deleted until it is not found.
+	refresh := func(ctx context.Context) (dbaas.PrometheusMetricToken, dbaas.Status, error) {
+		token, err := client.PrometheusMetricToken(ctx, tokenID)
+
+		return token, dbaas.StatusPendingDelete, err
+	}
+
+	return waitForDeleted(ctx, "token "+tokenID, refresh, timeout, 10*time.Second)
+}
@@ -2,69 +2,35 @@ package waiters
 
 import (
 	"context"
-	"errors"
-	"fmt"
-	"net/http"
-	"strconv"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/selectel/dbaas-go"
 )
 
 func WaitForDBaaSTopicV1ActiveState(
 	ctx context.Context, client *dbaas.API, topicID string, timeout time.Duration,
 ) error {
-	pending := []string{
-		string(dbaas.StatusPendingCreate),
-		string(dbaas.StatusPendingUpdate),
-	}
-	target := []string{
-		string(dbaas.StatusActive),
-	}
-
-	stateConf := &resource.StateChangeConf{
-		Pending:    pending,
-		Target:     target,
-		Refresh:    dbaasTopicV1StateRefreshFunc(ctx, client, topicID),
-		Timeout:    timeout,
-		Delay:      10 * time.Second,
-		MinTimeout: 20 * time.Second,
+	pending := []dbaas.Status{
+		dbaas.StatusPendingCreate,
+		dbaas.StatusPendingUpdate,
 	}
+	refresh := func(ctx context.Context) (dbaas.Topic, dbaas.Status, error) {
+		topic, err := client.Topic(ctx, topicID)
 
-	_, err := stateConf.WaitForStateContext(ctx)
-	if err != nil {
-		return fmt.Errorf(
-			"error waiting for the topic %s to become 'ACTIVE': %s",
-			topicID, err)
+		return topic, topic.Status, err
 	}
 
-	return nil
+	return waitForActiveState(ctx, "topic "+topicID, pending, failureStatuses, refresh, timeout, 20*time.Second)
 }
 
-func DBaaSTopicV1DeleteStateRefreshFunc(ctx context.Context, client *dbaas.API, topicID string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		d, err := client.Topic(ctx, topicID)
-		if err != nil {
-			var dbaasError *dbaas.DBaaSAPIError
-			if errors.As(err, &dbaasError) {
-				return d, strconv.Itoa(dbaasError.StatusCode()), nil
-			}
-
-			return nil, "", err
-		}
+func WaitForDBaaSTopicV1Deleted(
+	ctx context.Context, client *dbaas.API, topicID string, timeout time.Duration,
+) error {
+	refresh := func(ctx context.Context) (dbaas.Topic, dbaas.Status, error) {
+		topic, err := client.Topic(ctx, topicID)
 
-		return d, strconv.Itoa(http.StatusOK), err
+		return topic, topic.Status, err
 	}
-}
 
-func dbaasTopicV1StateRefreshFunc(ctx context.Context, client *dbaas.API, topicID string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		d, err := client.Topic(ctx, topicID)
-		if err != nil {
-			return nil, "", err
-		}
-
-		return d, string(d.Status), nil
-	}
+	return waitForDeleted(ctx, "topic "+topicID, refresh, timeout, 20*time.Second)
 }
@@ -0,0 +1,90 @@
+package waiters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/selectel/dbaas-go"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/waiter"
+)
+
+var (
+	// failureStatuses are terminal error statuses of DBaaS objects.
+	failureStatuses = []dbaas.Status{dbaas.StatusError}
+
+	// datastoreFailureStatuses are terminal error statuses of datastores.
+	datastoreFailureStatuses = []dbaas.Status{dbaas.StatusError, dbaas.StatusDegraded}
+
+	// deletePendingStatuses are statuses of DBaaS objects that are not deleted yet.
+	deletePendingStatuses = []dbaas.Status{
+		dbaas.StatusActive,
+		dbaas.StatusPendingCreate,
+		dbaas.StatusPendingUpdate,
+		dbaas.StatusPendingDelete,
+		dbaas.StatusResizing,
+		dbaas.StatusDegraded,
+		dbaas.StatusDiskFull,
+		dbaas.StatusDown,
+	}
+)
+
+// waitForActiveState waits for the DBaaS object to become ACTIVE. The object is polled
+// 10 seconds after the start and then with exponential backoff starting from minInterval.
+func waitForActiveState[T any](
+	ctx context.Context, object string, pending, failure []dbaas.Status,
+	refresh waiter.RefreshFunc[T, dbaas.Status], timeout, minInterval time.Duration,
+) error {
+	stateConf := &waiter.StateConf[T, dbaas.Status]{
+		Object:      object,
+		Pending:     pending,
+		Target:      []dbaas.Status{dbaas.StatusActive},
+		Failure:     failure,
+		Refresh:     refresh,
+		Timeout:     timeout,
+		Delay:       10 * time.Second,
+		MinInterval: minInterval,
+	}
+
+	_, err := stateConf.WaitForState(ctx)
+	if err != nil {
+		return fmt.Errorf("error waiting for the %s to become 'ACTIVE': %w", object, err)
+	}
+
+	return nil
+}
+
+// waitForDeleted waits for the DBaaS object to be deleted. The object that is not found
+// is in the DELETED status. The object is polled 10 seconds after the start and then
+// with exponential backoff starting from minInterval.
+func waitForDeleted[T any](
+	ctx context.Context, object string, refresh waiter.RefreshFunc[T, dbaas.Status], timeout, minInterval time.Duration,
+) error {
+	stateConf := &waiter.StateConf[T, dbaas.Status]{
+		Object:  object,
+		Pending: deletePendingStatuses,
+		Target:  []dbaas.Status{dbaas.StatusDeleted},
+		Failure: failureStatuses,
+		Refresh: func(ctx context.Context) (T, dbaas.Status, error) {
+			result, status, err := refresh(ctx)
+			var dbaasError *dbaas.DBaaSAPIError
+			if errors.As(err, &dbaasError) && dbaasError.StatusCode() == http.StatusNotFound {
+				return result, dbaas.StatusDeleted, nil
+			}
+
+			return result, status, err
+		},
+		Timeout:     timeout,
+		Delay:       10 * time.Second,
+		MinInterval: minInterval,
+	}
+
+	_, err := stateConf.WaitForState(ctx)
+	if err != nil {
+		return fmt.Errorf("error waiting for the %s to become deleted: %w", object, err)
+	}
+
+	return nil
+}
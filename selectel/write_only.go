@@ -0,0 +1,32 @@
+package selectel
+
+import (
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// writeOnlyString returns the value of the write-only attribute. Terraform never stores
+// write-only values, so the value is read from the configuration of the current run.
+func writeOnlyString(d *schema.ResourceData, attr string) string {
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return ""
+	}
+
+	value := rawConfig.GetAttr(attr)
+	if value.IsNull() || !value.IsKnown() || !value.Type().Equals(cty.String) {
+		return ""
+	}
+
+	return value.AsString()
+}
+
+// secretValue returns the value of the sensitive attribute, or the value of its write-only
+// variant when the attribute is not set.
+func secretValue(d *schema.ResourceData, attr, writeOnlyAttr string) string {
+	if value, ok := d.GetOk(attr); ok {
+		return value.(string)
+	}
+
+	return writeOnlyString(d, writeOnlyAttr)
+}
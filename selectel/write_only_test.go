@@ -0,0 +1,293 @@
+package selectel
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/fakeapi"
+)
+
+func testResourceDataWithRawConfig(t *testing.T, r *schema.Resource, state map[string]string, rawConfig map[string]cty.Value) *schema.ResourceData {
+	t.Helper()
+
+	configType := schema.InternalMap(r.SchemaMap()).CoreConfigSchema().ImpliedType()
+	attrs := make(map[string]cty.Value)
+	for name, attrType := range configType.AttributeTypes() {
+		if v, ok := rawConfig[name]; ok {
+			attrs[name] = v
+		} else {
+			attrs[name] = cty.NullVal(attrType)
+		}
+	}
+
+	d, err := schema.InternalMap(r.SchemaMap()).Data(
+		&terraform.InstanceState{ID: "id", Attributes: state},
+		&terraform.InstanceDiff{RawConfig: cty.ObjectVal(attrs)},
+	)
+	require.NoError(t, err)
+
+	return d
+}
+
+func TestSecretValue(t *testing.T) {
+	r := resourceDBaaSUserV1()
+
+	d := testResourceDataWithRawConfig(t, r, map[string]string{"password": "secret"}, map[string]cty.Value{
+		"password": cty.StringVal("secret"),
+	})
+	assert.Equal(t, "secret", secretValue(d, "password", "password_wo"))
+
+	d = testResourceDataWithRawConfig(t, r, nil, map[string]cty.Value{
+		"password_wo":         cty.StringVal("write-only-secret"),
+		"password_wo_version": cty.NumberIntVal(1),
+	})
+	assert.Equal(t, "write-only-secret", secretValue(d, "password", "password_wo"))
+	assert.Empty(t, d.Get("password_wo"))
+
+	d = testResourceDataWithRawConfig(t, r, nil, map[string]cty.Value{
+		"password_wo": cty.UnknownVal(cty.String),
+	})
+	assert.Empty(t, secretValue(d, "password", "password_wo"))
+}
+
+func TestWriteOnlyAttributesValidation(t *testing.T) {
+	ctx := context.Background()
+	server, _, _ := testFakeAPIProviderServer(t)
+
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	require.NoError(t, err)
+
+	str := func(v string) tftypes.Value { return tftypes.NewValue(tftypes.String, v) }
+	num := func(v int64) tftypes.Value { return tftypes.NewValue(tftypes.Number, v) }
+
+	testCases := []struct {
+		name     string
+		typeName string
+		values   map[string]tftypes.Value
+		// expectedError is a part of the expected error, the config is valid when it is empty.
+		expectedError string
+	}{
+		{
+			name:     "dbaas user with a write-only password",
+			typeName: "selectel_dbaas_user_v1",
+			values:   map[string]tftypes.Value{"datastore_id": str("ds"), "name": str("user"), "password_wo": str("secret"), "password_wo_version": num(1)},
+		},
+		{
+			name:          "dbaas user with both passwords",
+			typeName:      "selectel_dbaas_user_v1",
+			values:        map[string]tftypes.Value{"datastore_id": str("ds"), "name": str("user"), "password": str("secret"), "password_wo": str("secret")},
+			expectedError: "only one of `password,password_wo` can be specified",
+		},
+		{
+			name:          "dbaas user without a password",
+			typeName:      "selectel_dbaas_user_v1",
+			values:        map[string]tftypes.Value{"datastore_id": str("ds"), "name": str("user")},
+			expectedError: "one of `password,password_wo` must be specified",
+		},
+		{
+			name:     "service user with a write-only password",
+			typeName: "selectel_iam_serviceuser_v1",
+			values:   map[string]tftypes.Value{"name": str("user"), "password_wo": str("secret"), "password_wo_version": num(1)},
+		},
+		{
+			name:          "service user with a write-only password without a version",
+			typeName:      "selectel_iam_serviceuser_v1",
+			values:        map[string]tftypes.Value{"name": str("user"), "password_wo": str("secret")},
+			expectedError: "all of `password_wo,password_wo_version` must be specified",
+		},
+		{
+			name:          "dbaas user with a write-only password without a version",
+			typeName:      "selectel_dbaas_user_v1",
+			values:        map[string]tftypes.Value{"datastore_id": str("ds"), "name": str("user"), "password_wo": str("secret")},
+			expectedError: "all of `password_wo,password_wo_version` must be specified",
+		},
+		{
+			name:          "redis datastore with both passwords",
+			typeName:      "selectel_dbaas_redis_datastore_v1",
+			values:        map[string]tftypes.Value{"redis_password": str("secret"), "redis_password_wo": str("secret")},
+			expectedError: "only one of `redis_password,redis_password_wo` can be specified",
+		},
+		{
+			name:          "redis datastore without a password",
+			typeName:      "selectel_dbaas_redis_datastore_v1",
+			values:        map[string]tftypes.Value{},
+			expectedError: "one of `redis_password,redis_password_wo` must be specified",
+		},
+		{
+			name:          "redis datastore with a write-only password without a version",
+			typeName:      "selectel_dbaas_redis_datastore_v1",
+			values:        map[string]tftypes.Value{"redis_password_wo": str("secret")},
+			expectedError: "all of `redis_password_wo,redis_password_wo_version` must be specified",
+		},
+		{
+			name:          "redis datastore with a version without a write-only password",
+			typeName:      "selectel_dbaas_redis_datastore_v1",
+			values:        map[string]tftypes.Value{"redis_password_wo_version": num(1)},
+			expectedError: "all of `redis_password_wo,redis_password_wo_version` must be specified",
+		},
+		{
+			name:     "secret with a write-only value",
+			typeName: "selectel_secretsmanager_secret_v1",
+			values:   map[string]tftypes.Value{"key": str("key"), "value_wo": str("secret"), "value_wo_version": num(1)},
+		},
+		{
+			name:          "secret with both values",
+			typeName:      "selectel_secretsmanager_secret_v1",
+			values:        map[string]tftypes.Value{"key": str("key"), "value": str("secret"), "value_wo": str("secret")},
+			expectedError: "one (and only one) of [value_wo] is required",
+		},
+		{
+			name:          "secret with a write-only value without a version",
+			typeName:      "selectel_secretsmanager_secret_v1",
+			values:        map[string]tftypes.Value{"key": str("key"), "value_wo": str("secret")},
+			expectedError: "Attribute \"value_wo_version\" must be specified when \"value_wo\" is specified",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := server.ValidateResourceTypeConfig(ctx, &tfprotov5.ValidateResourceTypeConfigRequest{
+				TypeName: tc.typeName,
+				Config:   testProviderServerConfig(t, schemaResp.ResourceSchemas[tc.typeName], tc.values),
+				ClientCapabilities: &tfprotov5.ValidateResourceTypeConfigClientCapabilities{
+					WriteOnlyAttributesAllowed: true,
+				},
+			})
+			require.NoError(t, err)
+
+			var errs []string
+			for _, d := range resp.Diagnostics {
+				if d.Severity == tfprotov5.DiagnosticSeverityError {
+					errs = append(errs, d.Summary+": "+d.Detail)
+				}
+			}
+			if tc.expectedError == "" {
+				assert.Empty(t, errs)
+
+				return
+			}
+			assert.Condition(t, func() bool {
+				for _, err := range errs {
+					if strings.Contains(err, tc.expectedError) {
+						return true
+					}
+				}
+
+				return false
+			}, "%q is not found in %q", tc.expectedError, errs)
+		})
+	}
+}
+
+func TestSecretsManagerSecretV1UpdateValue(t *testing.T) {
+	encode := func(value string) string {
+		return base64.StdEncoding.EncodeToString([]byte(value))
+	}
+	testCases := []struct {
+		name             string
+		state            map[string]tftypes.Value
+		config           map[string]tftypes.Value
+		expectedVersions []string
+	}{
+		{
+			name: "write-only version bump",
+			state: map[string]tftypes.Value{
+				"value_wo_version": tftypes.NewValue(tftypes.Number, 1),
+			},
+			config: map[string]tftypes.Value{
+				"value_wo":         tftypes.NewValue(tftypes.String, "new-secret"),
+				"value_wo_version": tftypes.NewValue(tftypes.Number, 2),
+			},
+			expectedVersions: []string{encode("old-secret"), encode("new-secret")},
+		},
+		{
+			name: "value change",
+			state: map[string]tftypes.Value{
+				"value": tftypes.NewValue(tftypes.String, "old-secret"),
+			},
+			config: map[string]tftypes.Value{
+				"value": tftypes.NewValue(tftypes.String, "new-secret"),
+			},
+			expectedVersions: []string{encode("old-secret"), encode("new-secret")},
+		},
+		{
+			name: "description change",
+			state: map[string]tftypes.Value{
+				"value": tftypes.NewValue(tftypes.String, "old-secret"),
+			},
+			config: map[string]tftypes.Value{
+				"value":       tftypes.NewValue(tftypes.String, "old-secret"),
+				"description": tftypes.NewValue(tftypes.String, "new description"),
+			},
+			expectedVersions: []string{encode("old-secret")},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			server, api, _ := testFakeAPIProviderServer(t)
+			api.AddSecretsManagerSecret("key", encode("old-secret"))
+
+			schemaResp, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+			require.NoError(t, err)
+			secretSchema := schemaResp.ResourceSchemas["selectel_secretsmanager_secret_v1"]
+
+			state := map[string]tftypes.Value{
+				"id":          tftypes.NewValue(tftypes.String, fakeapi.ProjectID+"/key"),
+				"key":         tftypes.NewValue(tftypes.String, "key"),
+				"description": tftypes.NewValue(tftypes.String, ""),
+				"project_id":  tftypes.NewValue(tftypes.String, fakeapi.ProjectID),
+				"name":        tftypes.NewValue(tftypes.String, "key"),
+				"created_at":  tftypes.NewValue(tftypes.String, "2024-05-01T12:00:00Z"),
+			}
+			for name, value := range tc.state {
+				state[name] = value
+			}
+			config := map[string]tftypes.Value{
+				"key": tftypes.NewValue(tftypes.String, "key"),
+			}
+			for name, value := range tc.config {
+				config[name] = value
+			}
+			proposed := make(map[string]tftypes.Value, len(state))
+			for name, value := range state {
+				proposed[name] = value
+			}
+			for name, value := range config {
+				if name != "value_wo" {
+					proposed[name] = value
+				}
+			}
+
+			planResp, err := server.PlanResourceChange(ctx, &tfprotov5.PlanResourceChangeRequest{
+				TypeName:         "selectel_secretsmanager_secret_v1",
+				PriorState:       testProviderServerConfig(t, secretSchema, state),
+				ProposedNewState: testProviderServerConfig(t, secretSchema, proposed),
+				Config:           testProviderServerConfig(t, secretSchema, config),
+			})
+			require.NoError(t, err)
+			require.Empty(t, planResp.Diagnostics)
+			assert.Empty(t, planResp.RequiresReplace)
+
+			applyResp, err := server.ApplyResourceChange(ctx, &tfprotov5.ApplyResourceChangeRequest{
+				TypeName:     "selectel_secretsmanager_secret_v1",
+				PriorState:   testProviderServerConfig(t, secretSchema, state),
+				PlannedState: planResp.PlannedState,
+				Config:       testProviderServerConfig(t, secretSchema, config),
+			})
+			require.NoError(t, err)
+			require.Empty(t, applyResp.Diagnostics)
+			assert.Equal(t, tc.expectedVersions, api.SecretsManagerSecretVersions("key"))
+		})
+	}
+}